@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
 )
 
 const (
@@ -31,6 +32,22 @@ const (
 	// the parser will throw an error. This is useful for scenarios where arguments
 	// are interdependent and must be specified together for correct operation.
 	ARGUMENT_GROUP_TYPE_DEPENDENT = 3
+
+	// ARGUMENT_GROUP_TYPE_AT_LEAST_ONE defines a group where at least one
+	// argument must be set, any combination of them being allowed. If none of the
+	// arguments in this group are specified, the parser will throw an error.
+	// This is useful when several ways of giving the same input can be combined.
+	ARGUMENT_GROUP_TYPE_AT_LEAST_ONE = 4
+
+	// ARGUMENT_GROUP_TYPE_EXACTLY_N defines a group where exactly Count arguments
+	// must be set. If fewer or more arguments of this group are specified, the parser
+	// will throw an error.
+	ARGUMENT_GROUP_TYPE_EXACTLY_N = 5
+
+	// ARGUMENT_GROUP_TYPE_AT_MOST_N defines a group where at most Count arguments
+	// can be set, but it is not mandatory to set any. If more arguments of this group
+	// are specified, the parser will throw an error.
+	ARGUMENT_GROUP_TYPE_AT_MOST_N = 6
 )
 
 // ArgumentGroup represents a group of arguments with associated metadata.
@@ -39,6 +56,13 @@ type ArgumentGroup struct {
 	// Name is the identifier for the argument group, often used for display purposes.
 	Name string
 
+	// Description is a text explaining the purpose of the group, displayed under its name in the help message.
+	Description string
+
+	// Order sets the position of the group in the help message. Groups with a lower Order are displayed
+	// first, and groups with the same Order are displayed in the order they were created.
+	Order int
+
 	// Arguments is a list of Argument pointers that belong to this group.
 	Arguments []arguments.Argument
 
@@ -51,6 +75,24 @@ type ArgumentGroup struct {
 	// Type is an integer that can represent the type or category of the argument group.
 	// This can be used for conditional handling or to differentiate between groups.
 	Type int
+
+	// Count is the number of arguments the group requires for ARGUMENT_GROUP_TYPE_EXACTLY_N,
+	// or allows for ARGUMENT_GROUP_TYPE_AT_MOST_N. It is unused by the other group types.
+	Count int
+
+	// Positionals is a list of positional arguments that take part in the constraint of the group.
+	// They are parsed with the other positional arguments of the parser, and only referenced here.
+	Positionals []positionals.PositionalArgument
+
+	// SubGroups is a list of argument groups nested in this group, displayed indented under it in the
+	// help message. The constraint of this group counts each subgroup as a single unit, which is set
+	// when at least one of its arguments is set.
+	SubGroups []*ArgumentGroup
+
+	// Visibility is where the group is displayed, one of the arguments.VISIBILITY_* constants. The
+	// arguments and subgroups of a group that is not displayed are not displayed either, but they are
+	// still parsed and the constraint of the group is still checked.
+	Visibility int
 }
 
 // Register registers a new argument with the argument group if it does not already exist.
@@ -87,6 +129,109 @@ func (ag *ArgumentGroup) Register(arg arguments.Argument) error {
 	return nil
 }
 
+// RegisterPositional adds a positional argument to the constraint of the argument group, if it is not
+// already part of it.
+//
+// Parameters:
+// - posarg: The positional argument to be added.
+func (ag *ArgumentGroup) RegisterPositional(posarg positionals.PositionalArgument) error {
+	for _, existing := range ag.Positionals {
+		if existing.GetName() == posarg.GetName() {
+			return fmt.Errorf("positional argument %s is already in the group", posarg.GetName())
+		}
+	}
+
+	ag.Positionals = append(ag.Positionals, posarg)
+	return nil
+}
+
+// NewSubGroup creates a new argument group nested in this group.
+//
+// Parameters:
+// - name: The name of the new argument group, unique among the subgroups of this group.
+// - groupType: The type of the new argument group, one of the ARGUMENT_GROUP_TYPE_* constants.
+// - count: The number of arguments used by the constraint of ARGUMENT_GROUP_TYPE_EXACTLY_N and
+// ARGUMENT_GROUP_TYPE_AT_MOST_N groups. It is ignored by the other group types.
+//
+// Returns:
+// A pointer to the newly created ArgumentGroup struct, which can be used to add arguments to the group,
+// or an error if the name is empty or already used by a subgroup, or if the count is lower than 1 for
+// a group type that needs one.
+func (ag *ArgumentGroup) NewSubGroup(name string, groupType int, count int) (*ArgumentGroup, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("name of group cannot be empty")
+	}
+
+	for _, subgroup := range ag.SubGroups {
+		if subgroup.Name == name {
+			return nil, fmt.Errorf("an argument group with name \"%s\" already exists in group \"%s\"", name, ag.Name)
+		}
+	}
+
+	if groupType == ARGUMENT_GROUP_TYPE_EXACTLY_N || groupType == ARGUMENT_GROUP_TYPE_AT_MOST_N {
+		if count < 1 {
+			return nil, fmt.Errorf("the number of arguments of group \"%s\" needs to be at least 1, got %d", name, count)
+		}
+	} else {
+		count = 0
+	}
+
+	subgroup := &ArgumentGroup{
+		Name:  name,
+		Type:  groupType,
+		Count: count,
+	}
+	ag.SubGroups = append(ag.SubGroups, subgroup)
+
+	return subgroup, nil
+}
+
+// AllArguments returns the arguments of the group followed by the arguments of its subgroups, recursively.
+//
+// Returns:
+// - The arguments of the group and of all its subgroups.
+func (ag *ArgumentGroup) AllArguments() []arguments.Argument {
+	allArguments := append([]arguments.Argument{}, ag.Arguments...)
+	for _, subgroup := range ag.SubGroups {
+		allArguments = append(allArguments, subgroup.AllArguments()...)
+	}
+
+	return allArguments
+}
+
+// AllPositionals returns the positional arguments of the group followed by the positional arguments of
+// its subgroups, recursively.
+//
+// Returns:
+// - The positional arguments of the group and of all its subgroups.
+func (ag *ArgumentGroup) AllPositionals() []positionals.PositionalArgument {
+	allPositionals := append([]positionals.PositionalArgument{}, ag.Positionals...)
+	for _, subgroup := range ag.SubGroups {
+		allPositionals = append(allPositionals, subgroup.AllPositionals()...)
+	}
+
+	return allPositionals
+}
+
+// VisibleArguments returns the arguments of the group displayed at a help level, which are the ones
+// whose visibility does not go past it. The arguments of the subgroups are not included.
+//
+// Parameters:
+// - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+// - The arguments of the group displayed at the help level, in the order they were registered.
+func (ag *ArgumentGroup) VisibleArguments(level int) []arguments.Argument {
+	visibleArguments := []arguments.Argument{}
+	for _, arg := range ag.Arguments {
+		if arguments.VisibilityOf(arg) <= level {
+			visibleArguments = append(visibleArguments, arg)
+		}
+	}
+
+	return visibleArguments
+}
+
 // ArgumentIsPresent checks if a given argument is present in the parsed arguments.
 // It supports both short (e.g., -e) and long (e.g., --example) argument names.
 //
@@ -128,8 +273,9 @@ func (ag *ArgumentGroup) ArgumentIsPresent(argumentName string) bool {
 // - indent: The indentation level for the printed output.
 //
 // The function prints the name of the argument group and its arguments in a tree-like structure,
-// with each level of indentation represented by "  │ ". The output includes the group name and
-// the names of the arguments within the group.
+// with each level of indentation represented by "  │ ". The output includes the group name, its
+// description, the names of the arguments within the group and its subgroups. The hidden arguments and
+// subgroups are left out, see arguments.VISIBILITY_HIDDEN.
 func (ag *ArgumentGroup) PrintArgumentTree(indent int) {
 	indentPrompt := strings.Repeat("  │ ", indent)
 	//
@@ -137,19 +283,33 @@ func (ag *ArgumentGroup) PrintArgumentTree(indent int) {
 
 	fmt.Printf("%s  │   ├─ Name: \"%s\"\n", indentPrompt, ag.Name)
 
-	fmt.Printf("%s  │   ├─ Arguments (%d): \n", indentPrompt, len(ag.Arguments))
-	for _, argument := range ag.Arguments {
+	if len(ag.Description) != 0 {
+		fmt.Printf("%s  │   ├─ Description: \"%s\"\n", indentPrompt, ag.Description)
+	}
+
+	visibleArguments := ag.VisibleArguments(arguments.VISIBILITY_ADVANCED)
+	fmt.Printf("%s  │   ├─ Arguments (%d): \n", indentPrompt, len(visibleArguments))
+	for _, argument := range visibleArguments {
 		argtype := ""
-		if _, ok := argument.(*arguments.BoolArgument); ok {
-			argtype = "bool"
-		} else if _, ok := argument.(*arguments.StringArgument); ok {
-			argtype = "string"
-		} else if _, ok := argument.(*arguments.IntArgument); ok {
-			argtype = "int"
+		if metadata, ok := argument.(arguments.ArgumentMetadata); ok {
+			argtype = metadata.GetTypeName()
 		}
 		fmt.Printf("%s  │   │   ├─ (\"%s\",\"%s\") [%s] \"%s\"\n", indentPrompt, argument.GetShortName(), argument.GetLongName(), argtype, argument.GetHelp())
 	}
 	fmt.Printf("%s  │   │   └──\n", indentPrompt)
+
+	visibleSubGroups := []*ArgumentGroup{}
+	for _, subgroup := range ag.SubGroups {
+		if subgroup.Visibility != arguments.VISIBILITY_HIDDEN {
+			visibleSubGroups = append(visibleSubGroups, subgroup)
+		}
+	}
+	if len(visibleSubGroups) != 0 {
+		fmt.Printf("%s  │   ├─ SubGroups (%d): \n", indentPrompt, len(visibleSubGroups))
+		for _, subgroup := range visibleSubGroups {
+			subgroup.PrintArgumentTree(indent + 1)
+		}
+	}
 	fmt.Printf("%s  │   └──\n", indentPrompt)
 	fmt.Printf("%s  └──\n", indentPrompt)
 }
@@ -2,6 +2,8 @@ package argumentgroup
 
 import (
 	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
 )
 
 func TestArgumentGroup_Register_ExistingArgument(t *testing.T) {
@@ -73,3 +75,44 @@ func TestArgumentGroup_PrintArgumentTree_Empty(t *testing.T) {
 
 	// This test simply checks that the function runs without panic for an empty group.
 }
+
+func TestArgumentGroup_NewSubGroup(t *testing.T) {
+	var a, b string
+	ag := ArgumentGroup{Name: "Parent"}
+	ag.NewStringArgument(&a, "-a", "--alpha", "", false, "Alpha")
+
+	sub, err := ag.NewSubGroup("Child", ARGUMENT_GROUP_TYPE_EXACTLY_N, 1)
+	if err != nil {
+		t.Fatalf("Expected no error creating a subgroup, got %v", err)
+	}
+	sub.NewStringArgument(&b, "-b", "--beta", "", false, "Beta")
+
+	if _, err := ag.NewSubGroup("Child", ARGUMENT_GROUP_TYPE_NORMAL, 0); err == nil {
+		t.Error("Expected an error for a duplicate subgroup name, got none")
+	}
+	if _, err := ag.NewSubGroup("Limit", ARGUMENT_GROUP_TYPE_AT_MOST_N, 0); err == nil {
+		t.Error("Expected an error for an at-most-N subgroup with a count of 0, got none")
+	}
+
+	all := ag.AllArguments()
+	if len(all) != 2 || all[0].GetLongName() != "--alpha" || all[1].GetLongName() != "--beta" {
+		t.Errorf("Expected the arguments of the group then of its subgroup, got %v", all)
+	}
+}
+
+func TestArgumentGroup_VisibleArguments(t *testing.T) {
+	var a, b, c string
+	ag := ArgumentGroup{Name: "Group"}
+	ag.NewStringArgument(&a, "-a", "--alpha", "", false, "Alpha")
+	ag.NewStringArgument(&b, "-b", "--beta", "", false, "Beta")
+	ag.NewStringArgument(&c, "-c", "--gamma", "", false, "Gamma")
+	ag.Arguments[1].(arguments.VisibilityArgument).SetVisibility(arguments.VISIBILITY_ADVANCED)
+	ag.Arguments[2].(arguments.VisibilityArgument).SetVisibility(arguments.VISIBILITY_HIDDEN)
+
+	if visible := ag.VisibleArguments(arguments.VISIBILITY_DEFAULT); len(visible) != 1 || visible[0].GetLongName() != "--alpha" {
+		t.Errorf("Expected only the default argument, got %v", visible)
+	}
+	if visible := ag.VisibleArguments(arguments.VISIBILITY_ADVANCED); len(visible) != 2 || visible[1].GetLongName() != "--beta" {
+		t.Errorf("Expected the default and advanced arguments, got %v", visible)
+	}
+}
@@ -91,3 +91,21 @@ func (ag *ArgumentGroup) NewTcpPortArgument(ptr *int, shortName, longName string
 	err := ag.Register(&arg)
 	return err
 }
+
+// NewActionArgument registers a new action argument with the argument parser.
+//
+// Parameters:
+// - shortName: The short name (single character) of the argument, prefixed with a dash (e.g., "-l").
+// - longName: The long name of the argument, prefixed with two dashes (e.g., "--list-modules").
+// - action: The callback run when the argument is given.
+// - behavior: What the parser does after running the action, one of the arguments.ACTION_BEHAVIOR_* constants.
+// - exitCode: The code the program exits with after running the action, when behavior is arguments.ACTION_BEHAVIOR_EXIT.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// The function creates a new ActionArgument with the provided parameters and adds it to the argument group.
+func (ag *ArgumentGroup) NewActionArgument(shortName, longName string, action func() error, behavior int, exitCode int, help string) error {
+	arg := arguments.ActionArgument{}
+	arg.Init(shortName, longName, action, behavior, exitCode, help)
+	err := ag.Register(&arg)
+	return err
+}
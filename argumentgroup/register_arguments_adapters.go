@@ -0,0 +1,44 @@
+package argumentgroup
+
+import (
+	"encoding"
+	"flag"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// NewFlagValueArgument registers a new argument handled by a flag.Value with the argument group.
+//
+// Parameters:
+// - value: The flag.Value that parses and stores the value of the argument.
+// - shortName: The short name (single character) of the argument, prefixed with a dash (e.g., "-l").
+// - longName: The long name of the argument, prefixed with two dashes (e.g., "--level").
+// - defaultValue: The textual form of the default value of the argument if it is not provided by the user.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// The function creates a new FlagValueArgument with the provided parameters and adds it to the argument group.
+func (ag *ArgumentGroup) NewFlagValueArgument(value flag.Value, shortName, longName string, defaultValue string, required bool, help string) error {
+	arg := arguments.FlagValueArgument{}
+	arg.Init(value, shortName, longName, defaultValue, required, help)
+	err := ag.Register(&arg)
+	return err
+}
+
+// NewTextUnmarshalerArgument registers a new argument parsed by an encoding.TextUnmarshaler with the argument group.
+//
+// Parameters:
+// - value: The encoding.TextUnmarshaler that parses and stores the value of the argument.
+// - shortName: The short name (single character) of the argument, prefixed with a dash (e.g., "-t").
+// - longName: The long name of the argument, prefixed with two dashes (e.g., "--target").
+// - defaultValue: The textual form of the default value of the argument if it is not provided by the user.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// The function creates a new TextUnmarshalerArgument with the provided parameters and adds it to the argument group.
+func (ag *ArgumentGroup) NewTextUnmarshalerArgument(value encoding.TextUnmarshaler, shortName, longName string, defaultValue string, required bool, help string) error {
+	arg := arguments.TextUnmarshalerArgument{}
+	arg.Init(value, shortName, longName, defaultValue, required, help)
+	err := ag.Register(&arg)
+	return err
+}
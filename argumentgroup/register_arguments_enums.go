@@ -0,0 +1,72 @@
+package argumentgroup
+
+import (
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// NewEnumArgument registers a new argument accepting one of the given choices with the argument group.
+//
+// Parameters:
+// - ptr: A pointer to the string variable where the argument value will be stored.
+// - shortName: The short name (single character) of the argument, prefixed with a dash (e.g., "-f").
+// - longName: The long name of the argument, prefixed with two dashes (e.g., "--format").
+// - defaultValue: The default choice of the argument if it is not provided by the user. It can be empty.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the value given by the user matches a choice regardless of its case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// The function creates a new EnumArgument with the provided parameters and adds it to the argument group.
+func (ag *ArgumentGroup) NewEnumArgument(ptr *string, shortName, longName string, defaultValue string, choices []string, caseInsensitive bool, required bool, help string) error {
+	return NewTypedEnumArgument(ag, ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+}
+
+// NewTypedEnumArgument registers a new argument accepting one of the given choices, stored in a type of
+// typed constants based on string, with the argument group.
+//
+// The function creates a new EnumArgument with the provided parameters and adds it to the argument group.
+// It takes the same parameters as ArgumentGroup.NewEnumArgument, preceded by the argument group.
+func NewTypedEnumArgument[T ~string](ag *ArgumentGroup, ptr *T, shortName, longName string, defaultValue T, choices []T, caseInsensitive bool, required bool, help string) error {
+	if err := arguments.CheckChoices(choices, defaultValue); err != nil {
+		return err
+	}
+
+	arg := arguments.EnumArgument[T]{}
+	arg.Init(ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+	err := ag.Register(&arg)
+	return err
+}
+
+// NewListOfEnumsArgument registers a new argument with the argument group, each occurrence of which adds
+// one of the given choices to a list.
+//
+// Parameters:
+// - ptr: A pointer to the slice of strings where the argument values will be stored.
+// - shortName: The short name (single character) of the argument, prefixed with a dash (e.g., "-m").
+// - longName: The long name of the argument, prefixed with two dashes (e.g., "--module").
+// - defaultValue: The default list of choices if the argument is not provided by the user.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the values given by the user match a choice regardless of their case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// The function creates a new ListOfEnumsArgument with the provided parameters and adds it to the argument group.
+func (ag *ArgumentGroup) NewListOfEnumsArgument(ptr *[]string, shortName, longName string, defaultValue []string, choices []string, caseInsensitive bool, required bool, help string) error {
+	return NewTypedListOfEnumsArgument(ag, ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+}
+
+// NewTypedListOfEnumsArgument registers a new argument with the argument group, each occurrence of which
+// adds one of the given choices, stored in a type of typed constants based on string, to a list.
+//
+// The function creates a new ListOfEnumsArgument with the provided parameters and adds it to the argument group.
+// It takes the same parameters as ArgumentGroup.NewListOfEnumsArgument, preceded by the argument group.
+func NewTypedListOfEnumsArgument[T ~string](ag *ArgumentGroup, ptr *[]T, shortName, longName string, defaultValue []T, choices []T, caseInsensitive bool, required bool, help string) error {
+	if err := arguments.CheckChoices(choices, defaultValue...); err != nil {
+		return err
+	}
+
+	arg := arguments.ListOfEnumsArgument[T]{}
+	arg.Init(ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+	err := ag.Register(&arg)
+	return err
+}
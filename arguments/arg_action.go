@@ -0,0 +1,187 @@
+package arguments
+
+import (
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+const (
+	// ACTION_BEHAVIOR_CONTINUE runs the action of an ActionArgument and continues parsing normally,
+	// including the checks of the required arguments and of the argument groups.
+	ACTION_BEHAVIOR_CONTINUE = 0
+
+	// ACTION_BEHAVIOR_EXIT runs the action of an ActionArgument and exits with its exit code, without
+	// checking the required arguments and the argument groups, like the help and version flags do.
+	ACTION_BEHAVIOR_EXIT = 1
+)
+
+// ActionRunner is an optional interface implemented by arguments that run an action when they are
+// given on the command line, such as ActionArgument. The parser runs the actions once all the flags
+// have been read, in the order they were given, so that the actions can use the values of the other
+// arguments.
+type ActionRunner interface {
+	// RunAction runs the action of the argument.
+	RunAction() error
+
+	// GetActionBehavior returns what the parser does after running the action, one of the
+	// ACTION_BEHAVIOR_* constants.
+	GetActionBehavior() int
+
+	// GetExitCode returns the code the program exits with after running the action, when its
+	// behavior is ACTION_BEHAVIOR_EXIT.
+	GetExitCode() int
+}
+
+// ActionArgument represents a command-line flag running a callback when it is given, such as
+// "--list-modules" or "--dump-config". Depending on its behavior, the parser either continues normally
+// after running the callback, or exits with the exit code of the argument without checking the required
+// arguments and the argument groups.
+type ActionArgument struct {
+	// ShortName is the short flag (e.g., "-l") used to run the action.
+	// It can be empty if no short flag is defined.
+	ShortName string
+	// LongName is the long flag (e.g., "--list-modules") used to run the action.
+	// It can be empty if no long flag is defined.
+	LongName string
+	// Help provides a description of what this argument does.
+	// This message is displayed when showing help/usage information.
+	Help string
+	// Action is the callback run when the argument is given. An error it returns is reported like
+	// the parsing errors.
+	Action func() error
+	// Behavior is what the parser does after running the action, one of the ACTION_BEHAVIOR_* constants.
+	Behavior int
+	// ExitCode is the code the program exits with after running the action, when Behavior is
+	// ACTION_BEHAVIOR_EXIT.
+	ExitCode int
+	// Present indicates whether this argument was set by the user during execution.
+	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
+}
+
+// GetShortName returns the short flag name of the argument.
+// If no short flag is defined, it returns an empty string.
+func (arg ActionArgument) GetShortName() string {
+	return arg.ShortName
+}
+
+// GetLongName returns the long flag name of the argument.
+// If no long flag is defined, it returns an empty string.
+func (arg ActionArgument) GetLongName() string {
+	return arg.LongName
+}
+
+// GetHelp returns the help message of the argument.
+// This provides a description of how to use the argument.
+func (arg ActionArgument) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue returns whether the argument was given, as an interface{}.
+func (arg ActionArgument) GetValue() any {
+	return arg.Present
+}
+
+// SetValue does nothing, as the argument holds no value.
+func (arg *ActionArgument) SetValue(value any) {}
+
+// GetDefaultValue returns false, as the argument is not given by default.
+func (arg ActionArgument) GetDefaultValue() any {
+	return false
+}
+
+// ResetDefaultValue does nothing, as the argument holds no value.
+func (arg *ActionArgument) ResetDefaultValue() {}
+
+// IsRequired returns false, as an action argument is never required.
+func (arg ActionArgument) IsRequired() bool {
+	return false
+}
+
+// IsPresent checks if the argument was set in the command line.
+func (arg ActionArgument) IsPresent() bool {
+	return arg.Present
+}
+
+// GetTypeName returns the name of the type of the argument, which is displayed in the usage and
+// help messages when no metavar is set.
+func (arg ActionArgument) GetTypeName() string {
+	return "action"
+}
+
+// GetDefaultValueString returns an empty string, as the argument has no default value to display.
+func (arg ActionArgument) GetDefaultValueString() string {
+	return ""
+}
+
+// GetChoices returns nil, as the argument holds no value.
+func (arg ActionArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the action is run once however many times the argument is given.
+func (arg ActionArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns false, as action arguments are flags that are not followed by a value.
+func (arg ActionArgument) ExpectsValue() bool {
+	return false
+}
+
+// RunAction runs the action of the argument, if it has one.
+func (arg ActionArgument) RunAction() error {
+	if arg.Action == nil {
+		return nil
+	}
+
+	return arg.Action()
+}
+
+// GetActionBehavior returns what the parser does after running the action.
+func (arg ActionArgument) GetActionBehavior() int {
+	return arg.Behavior
+}
+
+// GetExitCode returns the code the program exits with after running the action.
+func (arg ActionArgument) GetExitCode() int {
+	return arg.ExitCode
+}
+
+// Init initializes the ActionArgument with the provided parameters.
+// It sets the flag names, help message, action, behavior and exit code.
+func (arg *ActionArgument) Init(shortName, longName string, action func() error, behavior int, exitCode int, help string) {
+	arg.LongName, arg.ShortName = utils.GenerateLongAndShortNames(longName, shortName)
+
+	arg.Help = help
+
+	arg.Present = false
+
+	arg.Action = action
+
+	arg.Behavior = behavior
+
+	arg.ExitCode = exitCode
+}
+
+// Consume processes the command-line arguments and marks the ActionArgument as present.
+// The action itself is run by the parser once all the flags have been read.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the ActionArgument.
+func (arg *ActionArgument) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 1
+
+	if len(arguments) >= sizeToConsume {
+		if (arguments[0] == arg.ShortName) || (arguments[0] == arg.LongName) {
+			arg.Present = true
+
+			return arguments[sizeToConsume:], nil
+		}
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,51 @@
+package arguments
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestActionArgument_Init(t *testing.T) {
+	arg := ActionArgument{}
+	arg.Init("l", "list-modules", func() error { return nil }, ACTION_BEHAVIOR_EXIT, 3, "List the modules and exit")
+
+	if arg.ShortName != "-l" {
+		t.Errorf("Expected ShortName to be '-l', got '%s'", arg.ShortName)
+	}
+	if arg.LongName != "--list-modules" {
+		t.Errorf("Expected LongName to be '--list-modules', got '%s'", arg.LongName)
+	}
+	if arg.GetActionBehavior() != ACTION_BEHAVIOR_EXIT || arg.GetExitCode() != 3 {
+		t.Errorf("Expected behavior %d and exit code 3, got %d and %d", ACTION_BEHAVIOR_EXIT, arg.GetActionBehavior(), arg.GetExitCode())
+	}
+	if arg.IsRequired() || arg.ExpectsValue() {
+		t.Errorf("Expected an action argument to be neither required nor followed by a value")
+	}
+}
+
+func TestActionArgument_ConsumeAndRun(t *testing.T) {
+	runs := 0
+	arg := ActionArgument{}
+	arg.Init("", "dump-config", func() error { runs++; return errors.New("no config") }, ACTION_BEHAVIOR_CONTINUE, 0, "Dump the configuration")
+
+	remaining, err := arg.Consume([]string{"--dump-config", "--verbose"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0] != "--verbose" {
+		t.Errorf("Expected remaining arguments [--verbose], got %v", remaining)
+	}
+	if !arg.IsPresent() {
+		t.Errorf("Expected the argument to be present")
+	}
+	if runs != 0 {
+		t.Errorf("Expected Consume not to run the action")
+	}
+
+	if err := arg.RunAction(); err == nil || err.Error() != "no config" {
+		t.Errorf("Expected the error of the action, got %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("Expected the action to run once, got %d", runs)
+	}
+}
@@ -1,6 +1,8 @@
 package arguments
 
 import (
+	"fmt"
+
 	"github.com/TheManticoreProject/goopts/utils"
 )
 
@@ -29,6 +31,8 @@ type BoolArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -83,6 +87,32 @@ func (arg BoolArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg BoolArgument) GetTypeName() string {
+	return "bool"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg BoolArgument) GetDefaultValueString() string {
+	return fmt.Sprintf("%t", arg.DefaultValue)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg BoolArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg BoolArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns false, as boolean arguments are flags that are not followed by a value.
+func (arg BoolArgument) ExpectsValue() bool {
+	return false
+}
+
 // Init initializes the BoolArgument with the provided parameters.
 // It sets the flag names, help message, actual value, and default value.
 func (arg *BoolArgument) Init(value *bool, shortName, longName string, defaultValue bool, help string) {
@@ -0,0 +1,183 @@
+package arguments
+
+import (
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// EnumArgument represents a command-line argument that expects one value out of a fixed set of
+// choices, such as "--format {json|csv|table}". The type parameter allows the value to be stored in
+// a string or in a type of typed constants based on string.
+// It contains information about the argument's short and long flag names, help message,
+// the default value, the accepted choices, and whether the argument is required.
+type EnumArgument[T ~string] struct {
+	// ShortName is the short flag (e.g., "-f") used to specify the value.
+	// It can be empty if no short flag is defined.
+	ShortName string
+	// LongName is the long flag (e.g., "--format") used to specify the value.
+	// It can be empty if no long flag is defined.
+	LongName string
+	// Help provides a description of what this argument represents.
+	// This message is displayed when showing help/usage information.
+	Help string
+	// Value stores the choice provided by the user.
+	// If no value is specified by the user, Value will hold the DefaultValue.
+	Value *T
+	// DefaultValue is the choice to be used if the argument is not provided by the user.
+	DefaultValue T
+	// Choices is the list of values accepted by the argument.
+	Choices []T
+	// CaseInsensitive indicates whether the value given by the user matches a choice regardless of its case.
+	// The value stored is always spelled as in Choices.
+	CaseInsensitive bool
+	// Required indicates whether this argument must be specified by the user.
+	// If true, the argument must be included when running the program.
+	Required bool
+	// Present indicates whether this argument was set by the user during execution.
+	// This can be used to differentiate between arguments that were provided and those that were not,
+	// allowing for different handling of default values or other logic in the program.
+	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
+}
+
+// GetShortName returns the short flag name of the argument.
+// If no short flag is defined, it returns an empty string.
+func (arg EnumArgument[T]) GetShortName() string {
+	return arg.ShortName
+}
+
+// GetLongName returns the long flag name of the argument.
+// If no long flag is defined, it returns an empty string.
+func (arg EnumArgument[T]) GetLongName() string {
+	return arg.LongName
+}
+
+// GetHelp returns the help message of the argument.
+// The default value is not part of it, the parser displays it along with the other details of the argument.
+func (arg EnumArgument[T]) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue returns the current choice as an interface{}.
+// It will return the actual value provided by the user or the default value if none was specified.
+func (arg EnumArgument[T]) GetValue() any {
+	return *arg.Value
+}
+
+// SetValue sets the value of the EnumArgument.
+// This is the choice provided by the user or set by default.
+func (arg *EnumArgument[T]) SetValue(value any) {
+	*(arg.Value) = value.(T)
+}
+
+// GetDefaultValue returns the default choice as an interface{}.
+// This is used when the argument is not specified by the user.
+func (arg EnumArgument[T]) GetDefaultValue() any {
+	return arg.DefaultValue
+}
+
+// ResetDefaultValue resets the value of the argument to the default value.
+func (arg *EnumArgument[T]) ResetDefaultValue() {
+	*(arg.Value) = arg.DefaultValue
+}
+
+// IsRequired returns whether the argument is required.
+// If true, the argument must be specified when running the program.
+func (arg EnumArgument[T]) IsRequired() bool {
+	return arg.Required
+}
+
+// IsPresent checks if the argument was set in the command line.
+func (arg EnumArgument[T]) IsPresent() bool {
+	return arg.Present
+}
+
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg EnumArgument[T]) GetTypeName() string {
+	return "choice"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg EnumArgument[T]) GetDefaultValueString() string {
+	return fmt.Sprintf("\"%s\"", arg.DefaultValue)
+}
+
+// GetChoices returns the list of values accepted by the argument.
+func (arg EnumArgument[T]) GetChoices() []string {
+	return utils.ChoicesToStrings(arg.Choices)
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg EnumArgument[T]) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg EnumArgument[T]) ExpectsValue() bool {
+	return true
+}
+
+// Init initializes the EnumArgument with the provided values.
+//
+// Parameters:
+//   - value: A pointer to where the choice of the argument will be stored.
+//   - shortName: The short name of the argument (single character). If empty, it will be set to an empty string.
+//   - longName: The long name of the argument (string). If empty, it will be set to an empty string.
+//   - defaultValue: The default value of the argument.
+//   - choices: The list of values accepted by the argument.
+//   - caseInsensitive: Whether the value given by the user matches a choice regardless of its case.
+//   - required: Indicates whether the argument must be specified by the user.
+//   - help: The help message describing the argument.
+func (arg *EnumArgument[T]) Init(value *T, shortName, longName string, defaultValue T, choices []T, caseInsensitive bool, required bool, help string) {
+	arg.LongName, arg.ShortName = utils.GenerateLongAndShortNames(longName, shortName)
+
+	arg.Required = required
+
+	arg.Present = false
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.DefaultValue = defaultValue
+
+	arg.Choices = choices
+
+	arg.CaseInsensitive = caseInsensitive
+}
+
+// Consume processes the command-line arguments and sets the value of the EnumArgument.
+//
+// If the first argument matches the short or long name of the EnumArgument, the next argument is
+// looked up in the choices, regardless of its case if CaseInsensitive is set, and the matching choice
+// is stored in the value.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the EnumArgument.
+// - An error listing the accepted choices if the value is not one of them.
+func (arg *EnumArgument[T]) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 2
+
+	if len(arguments) >= sizeToConsume {
+		if (arguments[0] == arg.ShortName) || (arguments[0] == arg.LongName) {
+			choice, ok := utils.MatchChoice(arguments[1], arg.Choices, arg.CaseInsensitive)
+			if !ok {
+				// Return the original arguments if the value is not an accepted choice
+				return arguments, fmt.Errorf("%s %s: invalid choice, choose from %s", arguments[0], arguments[1], utils.ListOfStrings(arg.GetChoices()))
+			}
+			(*arg.Value) = choice
+
+			arg.Present = true
+
+			return arguments[sizeToConsume:], nil
+		}
+	}
+
+	return arguments, nil
+}
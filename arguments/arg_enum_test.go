@@ -0,0 +1,105 @@
+package arguments
+
+import (
+	"strings"
+	"testing"
+)
+
+type authMethod string
+
+const (
+	authNTLM     authMethod = "ntlm"
+	authKerberos authMethod = "kerberos"
+)
+
+func TestEnumArgument_Consume(t *testing.T) {
+	var value string
+
+	arg := EnumArgument[string]{}
+	arg.Init(&value, "f", "format", "json", []string{"json", "csv", "table"}, false, false, "Output format")
+	arg.ResetDefaultValue()
+
+	if value != "json" {
+		t.Errorf("Expected value to be reset to 'json', got '%s'", value)
+	}
+
+	remainingArgs, err := arg.Consume([]string{"--format", "csv", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != "csv" {
+		t.Errorf("Expected value to be 'csv', got '%s'", value)
+	}
+	if len(remainingArgs) != 1 || remainingArgs[0] != "anotherArg" {
+		t.Errorf("Expected remaining arguments to be '[anotherArg]', got '%v'", remainingArgs)
+	}
+}
+
+func TestEnumArgument_ConsumeInvalidChoice(t *testing.T) {
+	var value string
+
+	arg := EnumArgument[string]{}
+	arg.Init(&value, "f", "format", "json", []string{"json", "csv"}, false, false, "Output format")
+
+	arguments := []string{"-f", "CSV"}
+	remainingArgs, err := arg.Consume(arguments)
+	if err == nil {
+		t.Fatalf("Expected an error for a value with a different case")
+	}
+	if !strings.Contains(err.Error(), "[\"json\", \"csv\"]") {
+		t.Errorf("Expected the error to list the choices, got '%s'", err)
+	}
+	if len(remainingArgs) != len(arguments) || arg.IsPresent() {
+		t.Errorf("Expected the original arguments to be returned and the argument not to be present")
+	}
+}
+
+func TestEnumArgument_TypedCaseInsensitive(t *testing.T) {
+	var value authMethod
+
+	arg := EnumArgument[authMethod]{}
+	arg.Init(&value, "", "auth", authNTLM, []authMethod{authNTLM, authKerberos}, true, false, "Authentication method")
+
+	if _, err := arg.Consume([]string{"--auth", "KERBEROS"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != authKerberos {
+		t.Errorf("Expected value to be spelled as the choice 'kerberos', got '%s'", value)
+	}
+	if choices := arg.GetChoices(); len(choices) != 2 || choices[1] != "kerberos" {
+		t.Errorf("Expected choices to be [ntlm kerberos], got %v", choices)
+	}
+}
+
+func TestListOfEnumsArgument_Consume(t *testing.T) {
+	var values []string
+
+	arg := ListOfEnumsArgument[string]{}
+	arg.Init(&values, "m", "module", []string{"smb"}, []string{"smb", "ldap", "http"}, true, false, "Modules")
+	arg.ResetDefaultValue()
+
+	remainingArgs, _ := arg.Consume([]string{"-m", "LDAP", "-m", "http"})
+	remainingArgs, _ = arg.Consume(remainingArgs)
+
+	if strings.Join(values, ",") != "smb,ldap,http" {
+		t.Errorf("Expected values to be [smb ldap http], got %v", values)
+	}
+	if len(remainingArgs) != 0 {
+		t.Errorf("Expected no remaining arguments, got %v", remainingArgs)
+	}
+	if _, err := arg.Consume([]string{"-m", "ftp"}); err == nil {
+		t.Errorf("Expected an error for an invalid choice")
+	}
+}
+
+func TestCheckChoices(t *testing.T) {
+	if err := CheckChoices([]string{}); err == nil {
+		t.Errorf("Expected an error for an empty list of choices")
+	}
+	if err := CheckChoices([]string{"a", "b"}, "c"); err == nil {
+		t.Errorf("Expected an error for a default value that is not a choice")
+	}
+	if err := CheckChoices([]string{"a", "b"}, "", "b"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
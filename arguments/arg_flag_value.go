@@ -0,0 +1,217 @@
+package arguments
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// boolFlag is the interface implemented by flag.Value types that represent boolean flags, such as
+// the values created by flag.Bool. The standard flag package uses it to let these flags be given
+// without a value, and so does FlagValueArgument.
+type boolFlag interface {
+	flag.Value
+	IsBoolFlag() bool
+}
+
+// FlagValueArgument represents a command-line argument whose value is handled by a flag.Value,
+// the interface used by the standard flag package. It allows any type already implementing
+// flag.Value to be used as a goopts argument without writing a dedicated argument type.
+// It contains information about the argument's short and long flag names, help message,
+// the default value, and whether the argument is required.
+type FlagValueArgument struct {
+	// ShortName is the short flag (e.g., "-l") used to specify the value.
+	// It can be empty if no short flag is defined.
+	ShortName string
+	// LongName is the long flag (e.g., "--level") used to specify the value.
+	// It can be empty if no long flag is defined.
+	LongName string
+	// Help provides a description of what this argument represents.
+	// This message is displayed when showing help/usage information.
+	Help string
+	// Value is the flag.Value that parses and stores the value provided by the user.
+	Value flag.Value
+	// DefaultValue is the textual form of the value to be used if the argument is not provided by the user.
+	// It is given to the Set method of Value when the argument is reset to its default value.
+	DefaultValue string
+	// Required indicates whether this argument must be specified by the user.
+	// If true, the argument must be included when running the program.
+	Required bool
+	// Present indicates whether this argument was set by the user during execution.
+	// This can be used to differentiate between arguments that were provided and those that were not,
+	// allowing for different handling of default values or other logic in the program.
+	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
+	// changed indicates whether Value may differ from the default value, in which case the default
+	// value is given to the Set method of Value when the argument is reset.
+	changed bool
+}
+
+// GetShortName returns the short flag name of the argument.
+// If no short flag is defined, it returns an empty string.
+func (arg FlagValueArgument) GetShortName() string {
+	return arg.ShortName
+}
+
+// GetLongName returns the long flag name of the argument.
+// If no long flag is defined, it returns an empty string.
+func (arg FlagValueArgument) GetLongName() string {
+	return arg.LongName
+}
+
+// GetHelp returns the help message of the argument.
+// This provides a description of how to use the argument.
+func (arg FlagValueArgument) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue returns the current value of the argument as an interface{}.
+// If Value implements flag.Getter, the result of its Get method is returned, otherwise Value itself is returned.
+func (arg FlagValueArgument) GetValue() any {
+	if getter, ok := arg.Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return arg.Value
+}
+
+// SetValue sets the value of the FlagValueArgument from its textual form.
+// The value has to be a string, which is given to the Set method of Value. An invalid value leaves Value unchanged.
+func (arg *FlagValueArgument) SetValue(value any) {
+	if arg.Value.Set(value.(string)) == nil {
+		arg.changed = true
+	}
+}
+
+// GetDefaultValue returns the textual form of the default value as an interface{}.
+// This is used when the argument is not specified by the user.
+func (arg FlagValueArgument) GetDefaultValue() any {
+	return arg.DefaultValue
+}
+
+// ResetDefaultValue resets the value of the argument to the default value.
+//
+// The default value is given to the Set method of Value only when one was given and Value was set
+// since the last reset, so that callbacks and values accumulating the values given to Set, such as
+// the ones of flag.Func, are not called with the default value on every parse. Value is left as it
+// was when no default value was given.
+func (arg *FlagValueArgument) ResetDefaultValue() {
+	if arg.changed && len(arg.DefaultValue) != 0 {
+		_ = arg.Value.Set(arg.DefaultValue)
+	}
+	arg.changed = false
+}
+
+// IsRequired returns whether the argument is required.
+// If true, the argument must be specified when running the program.
+func (arg FlagValueArgument) IsRequired() bool {
+	return arg.Required
+}
+
+// IsPresent checks if the argument was set in the command line.
+func (arg FlagValueArgument) IsPresent() bool {
+	return arg.Present
+}
+
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg FlagValueArgument) GetTypeName() string {
+	if arg.isBoolFlag() {
+		return "bool"
+	}
+	return "value"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg FlagValueArgument) GetDefaultValueString() string {
+	return arg.DefaultValue
+}
+
+// GetChoices returns nil, as the accepted values are only known to the flag.Value.
+func (arg FlagValueArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument is handled as holding a single value. A flag.Value
+// accumulating the values given to its Set method still receives every occurrence.
+func (arg FlagValueArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns whether the flag of the argument is followed by its value, which is
+// not the case for boolean flags.
+func (arg FlagValueArgument) ExpectsValue() bool {
+	return !arg.isBoolFlag()
+}
+
+// isBoolFlag reports whether Value represents a boolean flag, that is given without a value.
+func (arg FlagValueArgument) isBoolFlag() bool {
+	if value, ok := arg.Value.(boolFlag); ok {
+		return value.IsBoolFlag()
+	}
+	return false
+}
+
+// Init initializes the FlagValueArgument with the provided values.
+//
+// Parameters:
+//   - value: The flag.Value that parses and stores the value of the argument.
+//   - shortName: The short name of the argument (single character). If empty, it will be set to an empty string.
+//   - longName: The long name of the argument (string). If empty, it will be set to an empty string.
+//   - defaultValue: The textual form of the default value of the argument.
+//   - required: Indicates whether the argument must be specified by the user.
+//   - help: The help message describing the argument.
+func (arg *FlagValueArgument) Init(value flag.Value, shortName, longName string, defaultValue string, required bool, help string) {
+	arg.LongName, arg.ShortName = utils.GenerateLongAndShortNames(longName, shortName)
+
+	arg.Required = required
+
+	arg.Present = false
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.DefaultValue = defaultValue
+
+	arg.changed = value.String() != defaultValue
+}
+
+// Consume processes the command-line arguments and sets the value of the FlagValueArgument.
+//
+// If the first argument matches the short or long name of the FlagValueArgument, the next argument
+// is given to the Set method of Value. Boolean flags are not followed by a value and are set to "true".
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the FlagValueArgument.
+// - An error if Value rejected the value.
+func (arg *FlagValueArgument) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 2
+	if arg.isBoolFlag() {
+		sizeToConsume = 1
+	}
+
+	if len(arguments) >= sizeToConsume {
+		if (arguments[0] == arg.ShortName) || (arguments[0] == arg.LongName) {
+			value := "true"
+			if sizeToConsume == 2 {
+				value = arguments[1]
+			}
+			if err := arg.Value.Set(value); err != nil {
+				// Return the original arguments if parsing fails
+				return arguments, fmt.Errorf("%s %s: invalid value: %s", arguments[0], value, err)
+			}
+
+			arg.Present = true
+			arg.changed = true
+
+			return arguments[sizeToConsume:], nil
+		}
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,82 @@
+package arguments
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestFlagValueArgument_Consume(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	level := fs.Int("level", 1, "Level")
+
+	arg := FlagValueArgument{}
+	arg.Init(fs.Lookup("level").Value, "l", "level", "1", false, "Level")
+
+	remainingArgs, err := arg.Consume([]string{"--level", "3", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *level != 3 {
+		t.Errorf("Expected level to be 3, got %d", *level)
+	}
+	if arg.GetValue() != 3 {
+		t.Errorf("Expected GetValue to return 3, got %v", arg.GetValue())
+	}
+	if !arg.IsPresent() {
+		t.Errorf("Expected argument to be present")
+	}
+	if len(remainingArgs) != 1 || remainingArgs[0] != "anotherArg" {
+		t.Errorf("Expected remaining arguments to be '[anotherArg]', got '%v'", remainingArgs)
+	}
+
+	arg.ResetDefaultValue()
+	if *level != 1 {
+		t.Errorf("Expected level to be reset to 1, got %d", *level)
+	}
+}
+
+func TestFlagValueArgument_ConsumeInvalidValue(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("level", 1, "Level")
+
+	arg := FlagValueArgument{}
+	arg.Init(fs.Lookup("level").Value, "l", "level", "1", false, "Level")
+
+	arguments := []string{"--level", "abc"}
+	remainingArgs, err := arg.Consume(arguments)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid value")
+	}
+	if len(remainingArgs) != len(arguments) {
+		t.Errorf("Expected the original arguments to be returned, got '%v'", remainingArgs)
+	}
+	if arg.IsPresent() {
+		t.Errorf("Expected argument not to be present after an invalid value")
+	}
+}
+
+func TestFlagValueArgument_BoolFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "Verbose")
+
+	arg := FlagValueArgument{}
+	arg.Init(fs.Lookup("v").Value, "v", "", "false", false, "Verbose")
+
+	if arg.ExpectsValue() {
+		t.Errorf("Expected a boolean flag not to expect a value")
+	}
+	if arg.GetTypeName() != "bool" {
+		t.Errorf("Expected type name to be 'bool', got '%s'", arg.GetTypeName())
+	}
+
+	remainingArgs, err := arg.Consume([]string{"-v", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !*verbose {
+		t.Errorf("Expected verbose to be true")
+	}
+	if len(remainingArgs) != 1 || remainingArgs[0] != "anotherArg" {
+		t.Errorf("Expected remaining arguments to be '[anotherArg]', got '%v'", remainingArgs)
+	}
+}
@@ -31,6 +31,8 @@ type IntArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -46,13 +48,9 @@ func (arg IntArgument) GetLongName() string {
 }
 
 // GetHelp returns the help message of the argument.
-// This provides a description of how to use the argument.
+// The default value is not part of it, the parser displays it along with the other details of the argument.
 func (arg IntArgument) GetHelp() string {
-	if !arg.IsRequired() {
-		return fmt.Sprintf("%s (default: %d)", arg.Help, arg.GetDefaultValue())
-	} else {
-		return arg.Help
-	}
+	return arg.Help
 }
 
 // GetValue returns the current integer value as an interface{}.
@@ -89,6 +87,32 @@ func (arg IntArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg IntArgument) GetTypeName() string {
+	return "int"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg IntArgument) GetDefaultValueString() string {
+	return fmt.Sprintf("%d", arg.DefaultValue)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg IntArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg IntArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg IntArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the IntArgument with the provided parameters.
 // It sets the flag names, required status, help message, actual value, and default value.
 func (arg *IntArgument) Init(value *int, shortName, longName string, defaultValue int, required bool, help string) {
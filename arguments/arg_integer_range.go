@@ -35,6 +35,8 @@ type IntRangeArgument struct {
 	RangeStart int
 	// RangeStop defines the inclusive upper bound of the valid range for the integer argument.
 	RangeStop int
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -50,13 +52,9 @@ func (arg IntRangeArgument) GetLongName() string {
 }
 
 // GetHelp returns the help message of the argument.
-// This provides a description of how to use the argument.
+// The default value is not part of it, the parser displays it along with the other details of the argument.
 func (arg IntRangeArgument) GetHelp() string {
-	if !arg.IsRequired() {
-		return fmt.Sprintf("%s (default: %d)", arg.Help, arg.GetDefaultValue())
-	} else {
-		return arg.Help
-	}
+	return arg.Help
 }
 
 // GetValue returns the current integer value as an interface{}.
@@ -93,6 +91,37 @@ func (arg IntRangeArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg IntRangeArgument) GetTypeName() string {
+	return "int"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg IntRangeArgument) GetDefaultValueString() string {
+	return fmt.Sprintf("%d", arg.DefaultValue)
+}
+
+// GetRange returns the inclusive lower and upper bounds of the values accepted by the argument.
+func (arg IntRangeArgument) GetRange() (int, int) {
+	return arg.RangeStart, arg.RangeStop
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg IntRangeArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg IntRangeArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg IntRangeArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the IntRangeArgument with the provided parameters.
 // It sets the flag names, required status, help message, actual value, and default value.
 func (arg *IntRangeArgument) Init(value *int, shortName, longName string, defaultValue, rangeStart, rangeStop int, required bool, help string) {
@@ -148,3 +177,16 @@ func (arg *IntRangeArgument) Consume(arguments []string) ([]string, error) {
 
 	return arguments, nil
 }
+
+// CheckDefaultValue returns an error if the default value of the argument is not in its range, or if
+// the range itself is empty.
+func (arg IntRangeArgument) CheckDefaultValue() error {
+	if arg.RangeStart > arg.RangeStop {
+		return fmt.Errorf("range [%d, %d] is empty", arg.RangeStart, arg.RangeStop)
+	}
+	if arg.DefaultValue < arg.RangeStart || arg.DefaultValue > arg.RangeStop {
+		return fmt.Errorf("default value %d is not in range [%d, %d]", arg.DefaultValue, arg.RangeStart, arg.RangeStop)
+	}
+
+	return nil
+}
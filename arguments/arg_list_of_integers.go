@@ -2,6 +2,8 @@ package arguments
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/TheManticoreProject/goopts/utils"
 )
@@ -31,6 +33,8 @@ type ListOfIntsArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -85,6 +89,36 @@ func (arg ListOfIntsArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg ListOfIntsArgument) GetTypeName() string {
+	return "int"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg ListOfIntsArgument) GetDefaultValueString() string {
+	values := make([]string, 0, len(arg.DefaultValue))
+	for _, value := range arg.DefaultValue {
+		values = append(values, strconv.Itoa(value))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg ListOfIntsArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns true, as each occurrence of the argument adds to its value.
+func (arg ListOfIntsArgument) IsRepeatable() bool {
+	return true
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg ListOfIntsArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the ListOfIntsArgument with the provided parameters.
 // It sets the flag names, required status, help message, actual value, and default value.
 func (arg *ListOfIntsArgument) Init(value *[]int, shortName, longName string, defaultValue []int, required bool, help string) {
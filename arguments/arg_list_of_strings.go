@@ -27,6 +27,8 @@ type ListOfStringsArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -81,6 +83,32 @@ func (arg ListOfStringsArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg ListOfStringsArgument) GetTypeName() string {
+	return "string"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg ListOfStringsArgument) GetDefaultValueString() string {
+	return utils.ListOfStrings(arg.DefaultValue)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg ListOfStringsArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns true, as each occurrence of the argument adds to its value.
+func (arg ListOfStringsArgument) IsRepeatable() bool {
+	return true
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg ListOfStringsArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the ListOfStringsArgument with the provided values.
 //
 // The function sets the short name, long name, help message, value, and default value for the ListOfStringsArgument.
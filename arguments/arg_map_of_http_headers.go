@@ -1,6 +1,7 @@
 package arguments
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/TheManticoreProject/goopts/utils"
@@ -31,6 +32,8 @@ type MapOfHttpHeadersArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -89,6 +92,37 @@ func (arg MapOfHttpHeadersArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg MapOfHttpHeadersArgument) GetTypeName() string {
+	return "http header"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg MapOfHttpHeadersArgument) GetDefaultValueString() string {
+	headers := make([]string, 0, len(arg.DefaultValue))
+	for key, value := range arg.DefaultValue {
+		headers = append(headers, key+": "+value)
+	}
+	sort.Strings(headers)
+	return utils.ListOfStrings(headers)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg MapOfHttpHeadersArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns true, as each occurrence of the argument adds to its value.
+func (arg MapOfHttpHeadersArgument) IsRepeatable() bool {
+	return true
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg MapOfHttpHeadersArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the MapOfHttpHeadersArgument with the provided values.
 //
 // The function sets the short name, long name, help message, value, and default value for the MapOfHttpHeadersArgument.
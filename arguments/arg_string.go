@@ -31,6 +31,8 @@ type StringArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name of the argument.
@@ -46,13 +48,9 @@ func (arg StringArgument) GetLongName() string {
 }
 
 // GetHelp returns the help message of the argument.
-// If the argument is optional, it appends the default value to the message.
+// The default value is not part of it, the parser displays it along with the other details of the argument.
 func (arg StringArgument) GetHelp() string {
-	if !arg.IsRequired() {
-		return fmt.Sprintf("%s (default: \"%v\")", arg.Help, arg.GetDefaultValue())
-	} else {
-		return arg.Help
-	}
+	return arg.Help
 }
 
 // GetValue returns the current value of the argument as an interface{}.
@@ -89,6 +87,32 @@ func (arg StringArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg StringArgument) GetTypeName() string {
+	return "string"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg StringArgument) GetDefaultValueString() string {
+	return fmt.Sprintf("\"%s\"", arg.DefaultValue)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg StringArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg StringArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg StringArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the StringArgument with the provided values.
 //
 // The function sets the short name, long name, help message, value, and default value for the StringArgument.
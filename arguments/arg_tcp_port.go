@@ -31,6 +31,8 @@ type TcpPortArgument struct {
 	// This can be used to differentiate between arguments that were provided and those that were not,
 	// allowing for different handling of default values or other logic in the program.
 	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
 }
 
 // GetShortName returns the short flag name (e.g., "-p") of the TcpPortArgument.
@@ -45,14 +47,10 @@ func (arg TcpPortArgument) GetLongName() string {
 	return arg.LongName
 }
 
-// GetHelp returns the help message associated with the TcpPortArgument.
-// If the argument is optional, the default value is appended to the help message.
+// GetHelp returns the help message of the argument.
+// The default value is not part of it, the parser displays it along with the other details of the argument.
 func (arg TcpPortArgument) GetHelp() string {
-	if !arg.IsRequired() {
-		return fmt.Sprintf("%s (default: %d)", arg.Help, arg.GetDefaultValue())
-	} else {
-		return arg.Help
-	}
+	return arg.Help
 }
 
 // GetValue retrieves the value of the TcpPortArgument.
@@ -87,6 +85,32 @@ func (arg TcpPortArgument) IsPresent() bool {
 	return arg.Present
 }
 
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg TcpPortArgument) GetTypeName() string {
+	return "tcp port"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg TcpPortArgument) GetDefaultValueString() string {
+	return fmt.Sprintf("%d", arg.DefaultValue)
+}
+
+// GetChoices returns nil, as the argument accepts any value of its type.
+func (arg TcpPortArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg TcpPortArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg TcpPortArgument) ExpectsValue() bool {
+	return true
+}
+
 // Init initializes the TcpPortArgument with the provided values.
 //
 // Parameters:
@@ -145,3 +169,12 @@ func (arg *TcpPortArgument) Consume(arguments []string) ([]string, error) {
 
 	return arguments, nil
 }
+
+// CheckDefaultValue returns an error if the default value of the argument is not a valid TCP port.
+func (arg TcpPortArgument) CheckDefaultValue() error {
+	if arg.DefaultValue < 0 || arg.DefaultValue > 65535 {
+		return fmt.Errorf("default value %d is not in range 0-65535", arg.DefaultValue)
+	}
+
+	return nil
+}
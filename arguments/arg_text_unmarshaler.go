@@ -0,0 +1,190 @@
+package arguments
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// TextUnmarshalerArgument represents a command-line argument whose value is parsed by an
+// encoding.TextUnmarshaler, such as *net.IP, *netip.Addr, *slog.Level or *big.Int. It allows
+// these types to be used as goopts arguments without writing a dedicated argument type.
+// It contains information about the argument's short and long flag names, help message,
+// the default value, and whether the argument is required.
+type TextUnmarshalerArgument struct {
+	// ShortName is the short flag (e.g., "-t") used to specify the value.
+	// It can be empty if no short flag is defined.
+	ShortName string
+	// LongName is the long flag (e.g., "--target") used to specify the value.
+	// It can be empty if no long flag is defined.
+	LongName string
+	// Help provides a description of what this argument represents.
+	// This message is displayed when showing help/usage information.
+	Help string
+	// Value is the encoding.TextUnmarshaler that parses and stores the value provided by the user.
+	Value encoding.TextUnmarshaler
+	// DefaultValue is the textual form of the value to be used if the argument is not provided by the user.
+	// It is given to the UnmarshalText method of Value when the argument is reset to its default value.
+	DefaultValue string
+	// Required indicates whether this argument must be specified by the user.
+	// If true, the argument must be included when running the program.
+	Required bool
+	// Present indicates whether this argument was set by the user during execution.
+	// This can be used to differentiate between arguments that were provided and those that were not,
+	// allowing for different handling of default values or other logic in the program.
+	Present bool
+	// Attributes holds the settings shared by all argument types, such as the metavar of the argument.
+	Attributes
+	// changed indicates whether Value may differ from the default value, in which case the default
+	// value is given to the UnmarshalText method of Value when the argument is reset.
+	changed bool
+}
+
+// GetShortName returns the short flag name of the argument.
+// If no short flag is defined, it returns an empty string.
+func (arg TextUnmarshalerArgument) GetShortName() string {
+	return arg.ShortName
+}
+
+// GetLongName returns the long flag name of the argument.
+// If no long flag is defined, it returns an empty string.
+func (arg TextUnmarshalerArgument) GetLongName() string {
+	return arg.LongName
+}
+
+// GetHelp returns the help message of the argument.
+// This provides a description of how to use the argument.
+func (arg TextUnmarshalerArgument) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue returns the encoding.TextUnmarshaler holding the current value as an interface{}.
+// It holds the value provided by the user or the default value if none was specified.
+func (arg TextUnmarshalerArgument) GetValue() any {
+	return arg.Value
+}
+
+// SetValue sets the value of the TextUnmarshalerArgument from its textual form.
+// The value has to be a string, which is given to the UnmarshalText method of Value. An invalid value leaves Value unchanged.
+func (arg *TextUnmarshalerArgument) SetValue(value any) {
+	if arg.Value.UnmarshalText([]byte(value.(string))) == nil {
+		arg.changed = true
+	}
+}
+
+// GetDefaultValue returns the textual form of the default value as an interface{}.
+// This is used when the argument is not specified by the user.
+func (arg TextUnmarshalerArgument) GetDefaultValue() any {
+	return arg.DefaultValue
+}
+
+// ResetDefaultValue resets the value of the argument to the default value.
+//
+// The default value is given to the UnmarshalText method of Value only when one was given and Value
+// was set since the last reset, so that types rejecting an empty text, such as slog.Level, are not
+// given one on every parse. Value is left as it was when no default value was given.
+func (arg *TextUnmarshalerArgument) ResetDefaultValue() {
+	if arg.changed && len(arg.DefaultValue) != 0 {
+		_ = arg.Value.UnmarshalText([]byte(arg.DefaultValue))
+	}
+	arg.changed = false
+}
+
+// IsRequired returns whether the argument is required.
+// If true, the argument must be specified when running the program.
+func (arg TextUnmarshalerArgument) IsRequired() bool {
+	return arg.Required
+}
+
+// IsPresent checks if the argument was set in the command line.
+func (arg TextUnmarshalerArgument) IsPresent() bool {
+	return arg.Present
+}
+
+// GetTypeName returns the name of the type of value expected by the argument, which is
+// displayed in the usage and help messages when no metavar is set.
+func (arg TextUnmarshalerArgument) GetTypeName() string {
+	return "value"
+}
+
+// GetDefaultValueString returns the default value of the argument formatted for display.
+func (arg TextUnmarshalerArgument) GetDefaultValueString() string {
+	return arg.DefaultValue
+}
+
+// GetChoices returns nil, as the accepted values are only known to the encoding.TextUnmarshaler.
+func (arg TextUnmarshalerArgument) GetChoices() []string {
+	return nil
+}
+
+// IsRepeatable returns false, as the argument holds a single value.
+func (arg TextUnmarshalerArgument) IsRepeatable() bool {
+	return false
+}
+
+// ExpectsValue returns true, as the flag of the argument is followed by its value.
+func (arg TextUnmarshalerArgument) ExpectsValue() bool {
+	return true
+}
+
+// Init initializes the TextUnmarshalerArgument with the provided values.
+//
+// Parameters:
+//   - value: The encoding.TextUnmarshaler that parses and stores the value of the argument.
+//   - shortName: The short name of the argument (single character). If empty, it will be set to an empty string.
+//   - longName: The long name of the argument (string). If empty, it will be set to an empty string.
+//   - defaultValue: The textual form of the default value of the argument.
+//   - required: Indicates whether the argument must be specified by the user.
+//   - help: The help message describing the argument.
+func (arg *TextUnmarshalerArgument) Init(value encoding.TextUnmarshaler, shortName, longName string, defaultValue string, required bool, help string) {
+	arg.LongName, arg.ShortName = utils.GenerateLongAndShortNames(longName, shortName)
+
+	arg.Required = required
+
+	arg.Present = false
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.DefaultValue = defaultValue
+
+	arg.changed = true
+	if marshaler, ok := value.(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			arg.changed = string(text) != defaultValue
+		}
+	}
+}
+
+// Consume processes the command-line arguments and sets the value of the TextUnmarshalerArgument.
+//
+// If the first argument matches the short or long name of the TextUnmarshalerArgument, the next
+// argument is given to the UnmarshalText method of Value.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the TextUnmarshalerArgument.
+// - An error if Value rejected the value.
+func (arg *TextUnmarshalerArgument) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 2
+
+	if len(arguments) >= sizeToConsume {
+		if (arguments[0] == arg.ShortName) || (arguments[0] == arg.LongName) {
+			if err := arg.Value.UnmarshalText([]byte(arguments[1])); err != nil {
+				// Return the original arguments if parsing fails
+				return arguments, fmt.Errorf("%s %s: invalid value: %s", arguments[0], arguments[1], err)
+			}
+
+			arg.Present = true
+			arg.changed = true
+
+			return arguments[sizeToConsume:], nil
+		}
+	}
+
+	return arguments, nil
+}
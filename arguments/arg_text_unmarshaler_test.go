@@ -0,0 +1,56 @@
+package arguments
+
+import (
+	"log/slog"
+	"net/netip"
+	"testing"
+)
+
+func TestTextUnmarshalerArgument_Consume(t *testing.T) {
+	var addr netip.Addr
+
+	arg := TextUnmarshalerArgument{}
+	arg.Init(&addr, "t", "target", "127.0.0.1", false, "Target address")
+	arg.ResetDefaultValue()
+
+	if addr.String() != "127.0.0.1" {
+		t.Errorf("Expected address to be reset to '127.0.0.1', got '%s'", addr)
+	}
+
+	remainingArgs, err := arg.Consume([]string{"-t", "10.0.0.1", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if addr.String() != "10.0.0.1" {
+		t.Errorf("Expected address to be '10.0.0.1', got '%s'", addr)
+	}
+	if !arg.IsPresent() {
+		t.Errorf("Expected argument to be present")
+	}
+	if len(remainingArgs) != 1 || remainingArgs[0] != "anotherArg" {
+		t.Errorf("Expected remaining arguments to be '[anotherArg]', got '%v'", remainingArgs)
+	}
+}
+
+func TestTextUnmarshalerArgument_ConsumeInvalidValue(t *testing.T) {
+	var level slog.Level
+
+	arg := TextUnmarshalerArgument{}
+	arg.Init(&level, "", "log-level", "INFO", false, "Log level")
+
+	arguments := []string{"--log-level", "LOUD"}
+	remainingArgs, err := arg.Consume(arguments)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid value")
+	}
+	if len(remainingArgs) != len(arguments) {
+		t.Errorf("Expected the original arguments to be returned, got '%v'", remainingArgs)
+	}
+
+	if _, err := arg.Consume([]string{"--log-level", "debug"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if level != slog.LevelDebug {
+		t.Errorf("Expected level to be DEBUG, got '%s'", level)
+	}
+}
@@ -0,0 +1,252 @@
+package arguments
+
+import (
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// ArgumentMetadata is an optional interface describing how an argument is presented in the usage
+// and help messages. All the argument types of this package implement it, and the parser relies on
+// it instead of inspecting concrete types, so that custom Argument implementations can describe
+// themselves in the same way.
+//
+// An Argument that does not implement ArgumentMetadata is still parsed normally, it is simply
+// displayed with its flag names only.
+type ArgumentMetadata interface {
+	// GetTypeName returns a short, human readable name of the type of value the argument
+	// expects (e.g., "string", "int", "tcp port").
+	GetTypeName() string
+
+	// GetMetavar returns the name displayed for the value of the argument in the usage and
+	// help messages (e.g., "FILE" for "--output <FILE>"). It is empty when no metavar was set,
+	// in which case the type name or the choices are displayed instead.
+	GetMetavar() string
+
+	// SetMetavar sets the name displayed for the value of the argument.
+	SetMetavar(metavar string)
+
+	// GetDefaultValueString returns the default value of the argument formatted for display.
+	GetDefaultValueString() string
+
+	// GetChoices returns the list of values accepted by the argument, or nil when any value
+	// of the expected type is accepted.
+	GetChoices() []string
+
+	// IsRepeatable returns whether the argument can be given several times on the command
+	// line, each occurrence adding to its value.
+	IsRepeatable() bool
+
+	// ExpectsValue returns whether the argument is followed by a value on the command line.
+	// It is false for flags such as boolean arguments.
+	ExpectsValue() bool
+}
+
+// EnvVarArgument is an optional interface implemented by arguments whose value can be read from an
+// environment variable when they are not given on the command line. All the argument types of this
+// package implement it.
+type EnvVarArgument interface {
+	// GetEnvVar returns the name of the environment variable the value of the argument is
+	// read from, or an empty string when the argument has none.
+	GetEnvVar() string
+
+	// SetEnvVar sets the name of the environment variable the value of the argument is read from.
+	SetEnvVar(name string)
+}
+
+// Validator checks the value of an argument once it has been parsed, and returns an error
+// describing the problem when the value is not acceptable. The value is the one returned by the
+// GetValue method of the argument.
+type Validator func(value any) error
+
+// ValidatedArgument is an optional interface implemented by arguments whose value can be checked by
+// validators after it has been parsed. All the argument types of this package implement it.
+type ValidatedArgument interface {
+	// GetValidators returns the validators of the argument, in the order they were added.
+	GetValidators() []Validator
+
+	// AddValidator adds a validator to the argument.
+	AddValidator(validator Validator)
+}
+
+// RangeArgument is an optional interface implemented by arguments only accepting values within a range,
+// such as IntRangeArgument, so that the range can be displayed in the help message.
+type RangeArgument interface {
+	// GetRange returns the inclusive lower and upper bounds of the values accepted by the argument.
+	GetRange() (int, int)
+}
+
+const (
+	// VISIBILITY_DEFAULT displays the argument in the help message, which is the default.
+	VISIBILITY_DEFAULT = 0
+	// VISIBILITY_ADVANCED only displays the argument in the full help message, requested with
+	// "--help-all" or "-hh", for options that would clutter the usual one.
+	VISIBILITY_ADVANCED = 1
+	// VISIBILITY_HIDDEN never displays the argument, which is still parsed, for debug options
+	// or options kept for compatibility.
+	VISIBILITY_HIDDEN = 2
+)
+
+// VisibilityArgument is an optional interface implemented by arguments that can be left out of the help
+// message and of the generated documentation. All the argument types of this package implement it.
+type VisibilityArgument interface {
+	// GetVisibility returns where the argument is displayed, one of the VISIBILITY_* constants.
+	GetVisibility() int
+
+	// SetVisibility sets where the argument is displayed, one of the VISIBILITY_* constants.
+	SetVisibility(visibility int)
+}
+
+// VisibilityOf returns where an argument is displayed, VISIBILITY_DEFAULT for the arguments that do
+// not implement VisibilityArgument.
+//
+// Parameters:
+//   - arg: The argument to inspect.
+//
+// Returns:
+//   - One of the VISIBILITY_* constants.
+func VisibilityOf(arg Argument) int {
+	if visibilityArgument, ok := arg.(VisibilityArgument); ok {
+		return visibilityArgument.GetVisibility()
+	}
+
+	return VISIBILITY_DEFAULT
+}
+
+// Alias is an additional name of an argument, such as "--dc-ip" for an argument named "--dc-host",
+// which is given on the command line exactly like its short or long name.
+type Alias struct {
+	// Name is the alias with its leading dashes, such as "--dc-ip" or "-D".
+	Name string
+
+	// Deprecated indicates that the alias is only kept for compatibility: using it emits a warning,
+	// and it is not displayed by the help message.
+	Deprecated bool
+
+	// Hint completes the warning emitted when a deprecated alias is used, such as
+	// "use \"--dc-host\" instead".
+	Hint string
+}
+
+// AliasedArgument is an optional interface implemented by arguments that can be given under additional
+// names. All the argument types of this package implement it.
+type AliasedArgument interface {
+	// GetAliases returns the aliases of the argument, in the order they were added.
+	GetAliases() []Alias
+
+	// AddAlias adds an alias to the argument.
+	AddAlias(alias Alias)
+}
+
+// DefaultValueChecker is an optional interface implemented by arguments whose values are restricted,
+// such as IntRangeArgument or TcpPortArgument, to verify that their default value is itself acceptable.
+// It is used to catch mistakes in the definition of a parser before it runs.
+type DefaultValueChecker interface {
+	// CheckDefaultValue returns an error if the default value of the argument would be rejected
+	// had it been given on the command line.
+	CheckDefaultValue() error
+}
+
+// Attributes holds the settings that are common to all argument types and do not depend on the
+// type of their value, such as their metavar, environment variable or validators. It is embedded in
+// every argument type of this package and provides the corresponding part of ArgumentMetadata, as
+// well as EnvVarArgument, ValidatedArgument, VisibilityArgument and AliasedArgument.
+type Attributes struct {
+	// Metavar is the name displayed for the value of the argument in the usage and help
+	// messages. When empty, the type name or the choices of the argument are displayed.
+	Metavar string
+
+	// EnvVar is the name of the environment variable the value of the argument is read from
+	// when it is not given on the command line. It is empty when the argument has none.
+	EnvVar string
+
+	// Validators are the functions checking the value of the argument once it has been parsed.
+	Validators []Validator
+
+	// Visibility is where the argument is displayed, one of the VISIBILITY_* constants.
+	Visibility int
+
+	// Aliases are the additional names the argument can be given under.
+	Aliases []Alias
+}
+
+// GetMetavar returns the name displayed for the value of the argument.
+// If no metavar was set, it returns an empty string.
+func (attr Attributes) GetMetavar() string {
+	return attr.Metavar
+}
+
+// SetMetavar sets the name displayed for the value of the argument.
+// Leading and trailing angle brackets are not needed, they are added when rendering.
+func (attr *Attributes) SetMetavar(metavar string) {
+	attr.Metavar = metavar
+}
+
+// GetEnvVar returns the name of the environment variable the value of the argument is read from.
+// If no environment variable was set, it returns an empty string.
+func (attr Attributes) GetEnvVar() string {
+	return attr.EnvVar
+}
+
+// SetEnvVar sets the name of the environment variable the value of the argument is read from
+// when it is not given on the command line.
+func (attr *Attributes) SetEnvVar(name string) {
+	attr.EnvVar = name
+}
+
+// GetValidators returns the validators of the argument, in the order they were added.
+func (attr Attributes) GetValidators() []Validator {
+	return attr.Validators
+}
+
+// AddValidator adds a validator checking the value of the argument once it has been parsed.
+func (attr *Attributes) AddValidator(validator Validator) {
+	attr.Validators = append(attr.Validators, validator)
+}
+
+// GetVisibility returns where the argument is displayed, one of the VISIBILITY_* constants.
+func (attr Attributes) GetVisibility() int {
+	return attr.Visibility
+}
+
+// SetVisibility sets where the argument is displayed, one of the VISIBILITY_* constants.
+func (attr *Attributes) SetVisibility(visibility int) {
+	attr.Visibility = visibility
+}
+
+// GetAliases returns the aliases of the argument, in the order they were added.
+func (attr Attributes) GetAliases() []Alias {
+	return attr.Aliases
+}
+
+// AddAlias adds an additional name the argument can be given under.
+func (attr *Attributes) AddAlias(alias Alias) {
+	attr.Aliases = append(attr.Aliases, alias)
+}
+
+// CheckChoices verifies the choices of an enum argument and its default values, so that a mistake
+// in the definition of the argument is reported when it is registered rather than when parsing.
+//
+// Parameters:
+//   - choices: The list of values accepted by the argument.
+//   - defaultValues: The default values of the argument. Empty default values are ignored, as
+//     they stand for the absence of a default value.
+//
+// Returns:
+//   - An error if there are no choices, or if a default value is not one of the choices.
+func CheckChoices[T ~string](choices []T, defaultValues ...T) error {
+	if len(choices) == 0 {
+		return fmt.Errorf("the list of choices cannot be empty")
+	}
+
+	for _, defaultValue := range defaultValues {
+		if len(defaultValue) == 0 {
+			continue
+		}
+		if _, ok := utils.MatchChoice(string(defaultValue), choices, false); !ok {
+			return fmt.Errorf("default value \"%s\" is not one of the choices %s", defaultValue, utils.ListOfStrings(utils.ChoicesToStrings(choices)))
+		}
+	}
+
+	return nil
+}
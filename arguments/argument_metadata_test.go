@@ -0,0 +1,112 @@
+package arguments
+
+import (
+	"testing"
+)
+
+func TestBuiltinArguments_ImplementArgumentMetadata(t *testing.T) {
+	var b bool
+	var s string
+	var i int
+	var ls []string
+	var li []int
+	var m map[string]string
+
+	tests := []struct {
+		arg          Argument
+		typeName     string
+		expectsValue bool
+		repeatable   bool
+	}{
+		{&BoolArgument{Value: &b}, "bool", false, false},
+		{&StringArgument{Value: &s}, "string", true, false},
+		{&IntArgument{Value: &i}, "int", true, false},
+		{&IntRangeArgument{Value: &i}, "int", true, false},
+		{&TcpPortArgument{Value: &i}, "tcp port", true, false},
+		{&ListOfStringsArgument{Value: &ls}, "string", true, true},
+		{&ListOfIntsArgument{Value: &li}, "int", true, true},
+		{&MapOfHttpHeadersArgument{Value: &m}, "http header", true, true},
+	}
+
+	for _, tt := range tests {
+		metadata, ok := tt.arg.(ArgumentMetadata)
+		if !ok {
+			t.Fatalf("Expected %T to implement ArgumentMetadata", tt.arg)
+		}
+		if metadata.GetTypeName() != tt.typeName {
+			t.Errorf("Expected type name of %T to be '%s', got '%s'", tt.arg, tt.typeName, metadata.GetTypeName())
+		}
+		if metadata.ExpectsValue() != tt.expectsValue {
+			t.Errorf("Expected ExpectsValue of %T to be %v, got %v", tt.arg, tt.expectsValue, metadata.ExpectsValue())
+		}
+		if metadata.IsRepeatable() != tt.repeatable {
+			t.Errorf("Expected IsRepeatable of %T to be %v, got %v", tt.arg, tt.repeatable, metadata.IsRepeatable())
+		}
+		if metadata.GetChoices() != nil {
+			t.Errorf("Expected no choices for %T, got %v", tt.arg, metadata.GetChoices())
+		}
+	}
+}
+
+func TestAttributes_SetMetavar(t *testing.T) {
+	var value string
+
+	arg := StringArgument{}
+	arg.Init(&value, "o", "output", "", false, "Output file")
+
+	if arg.GetMetavar() != "" {
+		t.Errorf("Expected Metavar to be empty by default, got '%s'", arg.GetMetavar())
+	}
+
+	arg.SetMetavar("FILE")
+	if arg.GetMetavar() != "FILE" {
+		t.Errorf("Expected Metavar to be 'FILE', got '%s'", arg.GetMetavar())
+	}
+}
+
+func TestGetDefaultValueString(t *testing.T) {
+	var s string
+	var li []int
+	var m map[string]string
+
+	tests := []struct {
+		arg      ArgumentMetadata
+		expected string
+	}{
+		{&StringArgument{Value: &s, DefaultValue: "abc"}, "\"abc\""},
+		{&ListOfIntsArgument{Value: &li, DefaultValue: []int{1, 2}}, "[1, 2]"},
+		{&MapOfHttpHeadersArgument{Value: &m, DefaultValue: map[string]string{"X-B": "2", "X-A": "1"}}, "[\"X-A: 1\", \"X-B: 2\"]"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.arg.GetDefaultValueString(); got != tt.expected {
+			t.Errorf("Expected default value string of %T to be '%s', got '%s'", tt.arg, tt.expected, got)
+		}
+	}
+}
+
+func TestVisibilityOf(t *testing.T) {
+	var s string
+	arg := &StringArgument{Value: &s}
+	if VisibilityOf(arg) != VISIBILITY_DEFAULT {
+		t.Errorf("Expected arguments to be displayed by default, got %d", VisibilityOf(arg))
+	}
+
+	arg.SetVisibility(VISIBILITY_HIDDEN)
+	if VisibilityOf(arg) != VISIBILITY_HIDDEN {
+		t.Errorf("Expected the visibility to be VISIBILITY_HIDDEN, got %d", VisibilityOf(arg))
+	}
+}
+
+func TestAttributesAliases(t *testing.T) {
+	var s string
+	arg := &StringArgument{Value: &s}
+	if len(arg.GetAliases()) != 0 {
+		t.Errorf("Expected no alias by default, got %v", arg.GetAliases())
+	}
+
+	arg.AddAlias(Alias{Name: "--dc-ip", Deprecated: true})
+	if aliases := arg.GetAliases(); len(aliases) != 1 || aliases[0].Name != "--dc-ip" || !aliases[0].Deprecated {
+		t.Errorf("Expected the deprecated alias '--dc-ip', got %v", aliases)
+	}
+}
@@ -0,0 +1,69 @@
+package arguments
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MatchesRegexp returns a Validator checking that the value of an argument matches a regular
+// expression. For arguments holding a list of values, such as ListOfStringsArgument, each value
+// has to match. Values that are not strings are matched against their default formatting.
+//
+// Parameters:
+//   - pattern: The regular expression the value has to match.
+//
+// Returns:
+//   - A Validator reporting the first value that does not match the regular expression.
+func MatchesRegexp(pattern *regexp.Regexp) Validator {
+	return func(value any) error {
+		values := []string{}
+		switch typedValue := value.(type) {
+		case string:
+			values = append(values, typedValue)
+		case []string:
+			values = append(values, typedValue...)
+		default:
+			values = append(values, fmt.Sprint(typedValue))
+		}
+
+		for _, v := range values {
+			if !pattern.MatchString(v) {
+				return fmt.Errorf("\"%s\" does not match the pattern \"%s\"", v, pattern.String())
+			}
+		}
+
+		return nil
+	}
+}
+
+// IntInRange returns a Validator checking that the value of an integer argument is between min and
+// max inclusive. For arguments holding a list of integers, such as ListOfIntsArgument, each value
+// has to be in range.
+//
+// Parameters:
+//   - min: The minimum accepted value.
+//   - max: The maximum accepted value.
+//
+// Returns:
+//   - A Validator reporting the first value that is out of range, or that is not an integer.
+func IntInRange(min, max int) Validator {
+	return func(value any) error {
+		values := []int{}
+		switch typedValue := value.(type) {
+		case int:
+			values = append(values, typedValue)
+		case []int:
+			values = append(values, typedValue...)
+		default:
+			return fmt.Errorf("%v is not an integer", value)
+		}
+
+		for _, v := range values {
+			if v < min || v > max {
+				return fmt.Errorf("%d is not in range [%d, %d]", v, min, max)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package arguments
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchesRegexp(t *testing.T) {
+	validator := MatchesRegexp(regexp.MustCompile(`^[a-z]+$`))
+
+	if err := validator("abc"); err != nil {
+		t.Errorf("Expected \"abc\" to match, got %v", err)
+	}
+	if err := validator([]string{"abc", "def"}); err != nil {
+		t.Errorf("Expected all values to match, got %v", err)
+	}
+	if err := validator([]string{"abc", "DEF"}); err == nil {
+		t.Error("Expected an error for \"DEF\", got nil")
+	}
+	if err := validator(12); err == nil {
+		t.Error("Expected an error for 12, got nil")
+	}
+}
+
+func TestIntInRange(t *testing.T) {
+	validator := IntInRange(1, 10)
+
+	if err := validator(5); err != nil {
+		t.Errorf("Expected 5 to be in range, got %v", err)
+	}
+	if err := validator([]int{1, 10}); err != nil {
+		t.Errorf("Expected the bounds to be in range, got %v", err)
+	}
+	if err := validator([]int{1, 11}); err == nil {
+		t.Error("Expected an error for 11, got nil")
+	}
+	if err := validator("5"); err == nil {
+		t.Error("Expected an error for a string value, got nil")
+	}
+}
+
+func TestAttributes_AddValidator(t *testing.T) {
+	arg := StringArgument{}
+	arg.AddValidator(IntInRange(0, 1))
+	arg.AddValidator(MatchesRegexp(regexp.MustCompile(`.`)))
+
+	if len(arg.GetValidators()) != 2 {
+		t.Errorf("Expected 2 validators, got %d", len(arg.GetValidators()))
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	"github.com/TheManticoreProject/goopts/argumentgroup"
 	"github.com/TheManticoreProject/goopts/parser"
 )
 
@@ -34,24 +35,30 @@ func parseArgs() {
 	subparser_groupA := ap.AddSubParser("groupA", "groupA mode.")
 	subparser_groupA.SetupSubParsing("groupA_mode", &groupA_mode, true)
 
+	// The server port is shared by the Server groups of the groupAB and groupAC subparsers
+	serverArguments := parser.ArgumentSet{
+		Name: "Server",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			return group.NewIntArgument(&serverPort, "", "--server-port", 1337, true, "The server port.")
+		},
+	}
+
 	subparser_groupA_groupAB := subparser_groupA.AddSubParser("groupAB", "groupAB mode.")
 	subparser_groupA_groupAB.NewBoolArgument(&enableLogging, "", "--enable-logging", true, "Enable logging during execution.")
-	subparser_groupA_groupAB_server, err := subparser_groupA_groupAB.NewArgumentGroup("Server")
+	subparser_groupA_groupAB_server, err := subparser_groupA_groupAB.AttachArgumentSet(&serverArguments)
 	if err != nil {
 		fmt.Printf("[-] Error creating group: %s\n", err)
 	} else {
 		subparser_groupA_groupAB_server.NewStringArgument(&dbHost, "", "--db-host", "The database host.", true, "The database host.")
-		subparser_groupA_groupAB_server.NewIntArgument(&serverPort, "", "--server-port", 1337, true, "The server port.")
 	}
 
 	subparser_groupA_groupAC := subparser_groupA.AddSubParser("groupAC", "groupAC mode.")
 	subparser_groupA_groupAC.NewStringArgument(&dbHost, "", "--db-host", "The database host.", true, "The database host.")
-	subparser_groupA_groupAC_server, err := subparser_groupA_groupAC.NewArgumentGroup("Server")
+	subparser_groupA_groupAC_server, err := subparser_groupA_groupAC.AttachArgumentSet(&serverArguments)
 	if err != nil {
 		fmt.Printf("[-] Error creating group: %s\n", err)
 	} else {
 		subparser_groupA_groupAC_server.NewStringArgument(&serverIP, "", "--server-ip", "The server IP.", true, "The server IP.")
-		subparser_groupA_groupAC_server.NewIntArgument(&serverPort, "", "--server-port", 1337, true, "The server port.")
 	}
 
 	// Define positional subparsers
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"os"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// stopsParsing reports whether one of the action arguments that were given exits once its action has
+// run, in which case the required arguments and the argument groups are not checked.
+//
+// Parameters:
+//   - actions: The action arguments that were given, in the order they were given.
+//
+// Returns:
+//   - true if one of the actions has the arguments.ACTION_BEHAVIOR_EXIT behavior, false otherwise.
+func stopsParsing(actions []arguments.Argument) bool {
+	for _, arg := range actions {
+		if runner, ok := arg.(arguments.ActionRunner); ok && runner.GetActionBehavior() == arguments.ACTION_BEHAVIOR_EXIT {
+			return true
+		}
+	}
+
+	return false
+}
+
+// runActions runs the actions of the action arguments that were given, in the order they were given.
+// The program exits with the exit code of the first action that has the arguments.ACTION_BEHAVIOR_EXIT
+// behavior, once it has run. An action returning an error stops the other actions from running, and
+// the error is recorded in the parsing state.
+//
+// Parameters:
+//   - actions: The action arguments that were given, in the order they were given.
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) runActions(actions []arguments.Argument, parsingState *ParsingState) {
+	for _, arg := range actions {
+		runner, ok := arg.(arguments.ActionRunner)
+		if !ok {
+			continue
+		}
+
+		if err := runner.RunAction(); err != nil {
+			parsingState.AddError(ParsingError{Kind: ERROR_KIND_ACTION, Argument: argumentDisplayName(arg), Message: ap.message(MESSAGE_ACTION_ERROR, argumentDisplayName(arg), err)})
+			return
+		}
+
+		if runner.GetActionBehavior() == arguments.ACTION_BEHAVIOR_EXIT {
+			os.Exit(runner.GetExitCode())
+		}
+	}
+}
+
+// appendAction records an argument that was given on the command line if it is an action argument
+// that was not recorded yet, so that an action given several times runs once.
+//
+// Parameters:
+//   - actions: The action arguments recorded so far, in the order they were given.
+//   - arg: The argument that was given.
+//
+// Returns:
+//   - The recorded action arguments, including arg if it is an action argument.
+func appendAction(actions []arguments.Argument, arg arguments.Argument) []arguments.Argument {
+	if _, ok := arg.(arguments.ActionRunner); !ok {
+		return actions
+	}
+	for _, action := range actions {
+		if action == arg {
+			return actions
+		}
+	}
+
+	return append(actions, arg)
+}
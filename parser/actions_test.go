@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestActionArgumentsSubprocess is the body of the subprocesses started by TestActionArguments, and
+// does nothing when run directly.
+func TestActionArgumentsSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_ACTION_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	var user, target string
+	ap := NewParser("test")
+	ap.NewStringPositionalArgument(&target, "target", "Target.")
+	ap.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	ap.NewActionArgument("-l", "--list-modules", func() error {
+		fmt.Println("modules: smb, ldap")
+		return nil
+	}, arguments.ACTION_BEHAVIOR_EXIT, 3, "List the modules and exit.")
+	ap.NewActionArgument("", "--dump-config", func() error {
+		fmt.Printf("config: user=%s\n", user)
+		return nil
+	}, arguments.ACTION_BEHAVIOR_CONTINUE, 0, "Dump the configuration.")
+	ap.NewActionArgument("", "--check", func() error {
+		return fmt.Errorf("unreachable")
+	}, arguments.ACTION_BEHAVIOR_EXIT, 0, "Check the setup and exit.")
+
+	switch scenario {
+	case "exit":
+		ap.ParsingState.SetRawArguments([]string{"test", "--list-modules"})
+	case "continue":
+		ap.ParsingState.SetRawArguments([]string{"test", "host", "--dump-config", "-u", "admin"})
+	case "missing":
+		ap.ParsingState.SetRawArguments([]string{"test", "--dump-config"})
+	case "failing":
+		ap.ParsingState.SetRawArguments([]string{"test", "--check"})
+	}
+	ap.ParseFrom(1, &ap.ParsingState)
+	fmt.Println("parsed")
+	os.Exit(0)
+}
+
+// TestActionArguments verifies that an action argument stopping parsing runs and exits with its exit
+// code without reporting the missing arguments, and that an action continuing parsing runs once the
+// arguments have been checked.
+func TestActionArguments(t *testing.T) {
+	for _, tc := range []struct {
+		scenario string
+		exitCode int
+		expected []string
+		absent   []string
+	}{
+		{"exit", 3, []string{"modules: smb, ldap"}, []string{"[!]", "parsed"}},
+		{"continue", 0, []string{"config: user=admin\nparsed"}, []string{"[!]"}},
+		{"missing", 1, []string{"[!] Missing 1 positional argument: <target>.", "[!] Missing required argument \"--user\""}, []string{"config:"}},
+		{"failing", 1, []string{"[!] Error running action \"--check\": unreachable"}, []string{"parsed"}},
+	} {
+		out, exitCode := runTestSubprocess(t, "TestActionArgumentsSubprocess", "GOOPTS_ACTION_SUBPROCESS="+tc.scenario)
+		if exitCode != tc.exitCode {
+			t.Errorf("expected scenario %q to exit with code %d, got %d:\n%s", tc.scenario, tc.exitCode, exitCode, out)
+		}
+		for _, line := range tc.expected {
+			if !strings.Contains(out, line) {
+				t.Errorf("expected output of scenario %q to contain %q, got:\n%s", tc.scenario, line, out)
+			}
+		}
+		for _, line := range tc.absent {
+			if strings.Contains(out, line) {
+				t.Errorf("expected output of scenario %q not to contain %q, got:\n%s", tc.scenario, line, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// AddArgumentAlias adds an additional name to a registered argument, which can then be given under
+// either name, for example "--domain-controller" for an argument named "--dc-host". The alias is
+// displayed with the other names of the argument in the help message.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - alias: The additional name, a short name when it is a single character and a long name
+//     otherwise. The leading dashes are optional.
+//
+// Returns:
+//   - An error if no argument is registered with this name, if the argument does not implement
+//     arguments.AliasedArgument, or if the alias is empty or already used by an argument.
+func (ap *ArgumentsParser) AddArgumentAlias(argumentFlag string, alias string) error {
+	return ap.addArgumentAlias(argumentFlag, arguments.Alias{Name: alias})
+}
+
+// AddDeprecatedArgumentAlias adds a deprecated name to a registered argument, typically its former name
+// once it has been renamed, so that the scripts using it keep working. Using a deprecated alias emits a
+// warning, see SetWarningHandler, and the alias is not displayed by the help message unless
+// HELP_DECORATION_DEPRECATED is among its decorations.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - alias: The deprecated name, a short name when it is a single character and a long name
+//     otherwise. The leading dashes are optional.
+//   - hint: The end of the warning, such as "use \"--dc-host\" instead", which is the default hint,
+//     in the language of the parser, when it is empty.
+//
+// Returns:
+//   - An error if no argument is registered with this name, if the argument does not implement
+//     arguments.AliasedArgument, or if the alias is empty or already used by an argument.
+func (ap *ArgumentsParser) AddDeprecatedArgumentAlias(argumentFlag string, alias string, hint string) error {
+	return ap.addArgumentAlias(argumentFlag, arguments.Alias{Name: alias, Deprecated: true, Hint: hint})
+}
+
+// addArgumentAlias adds an alias to a registered argument, once its name has been normalized and
+// checked against the names of all the arguments of the parser.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - alias: The alias to add.
+//
+// Returns:
+//   - An error if the alias cannot be added to the argument.
+func (ap *ArgumentsParser) addArgumentAlias(argumentFlag string, alias arguments.Alias) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
+	}
+
+	aliasedArgument, ok := arg.(arguments.AliasedArgument)
+	if !ok {
+		return fmt.Errorf("argument '%s' does not support aliases", argumentFlag)
+	}
+
+	alias, err := ap.prepareAlias(arg, alias)
+	if err != nil {
+		return fmt.Errorf("argument '%s': %w", argumentFlag, err)
+	}
+	aliasedArgument.AddAlias(alias)
+
+	return nil
+}
+
+// prepareAlias normalizes the name of an alias, adding the leading dashes of a short name when it is a
+// single character and of a long name otherwise, and sets the default hint of a deprecated alias.
+//
+// Parameters:
+//   - arg: The argument the alias is added to.
+//   - alias: The alias to prepare.
+//
+// Returns:
+//   - The prepared alias, and an error if it is empty or already used by an argument of the parser.
+func (ap *ArgumentsParser) prepareAlias(arg arguments.Argument, alias arguments.Alias) (arguments.Alias, error) {
+	stripped := utils.StripLeftDashes(alias.Name)
+	if len(stripped) == 0 {
+		return alias, fmt.Errorf("an alias cannot be empty")
+	}
+	if len([]rune(stripped)) == 1 {
+		alias.Name = "-" + stripped
+	} else {
+		alias.Name = "--" + stripped
+	}
+	if ap.findArgument(alias.Name) != nil || argumentHasName(arg, alias.Name) {
+		return alias, fmt.Errorf("alias '%s' is already used by an argument", alias.Name)
+	}
+
+	return alias, nil
+}
+
+// argumentAliases returns the aliases of an argument, or nil when it does not implement
+// arguments.AliasedArgument.
+//
+// Parameters:
+//   - arg: The argument to inspect.
+//
+// Returns:
+//   - The aliases of the argument, in the order they were added.
+func argumentAliases(arg arguments.Argument) []arguments.Alias {
+	if aliasedArgument, ok := arg.(arguments.AliasedArgument); ok {
+		return aliasedArgument.GetAliases()
+	}
+
+	return nil
+}
+
+// displayedAliases returns the names of the aliases of an argument displayed by the help message,
+// which are the ones that are not deprecated.
+//
+// Parameters:
+//   - arg: The argument to inspect.
+//
+// Returns:
+//   - The names of the aliases that are not deprecated, in the order they were added.
+func displayedAliases(arg arguments.Argument) []string {
+	names := []string{}
+	for _, alias := range argumentAliases(arg) {
+		if !alias.Deprecated {
+			names = append(names, alias.Name)
+		}
+	}
+
+	return names
+}
+
+// argumentHasName reports whether an argument can be given under a name, which is its short name,
+// its long name or one of its aliases.
+//
+// Parameters:
+//   - arg: The argument to inspect.
+//   - name: The name to look for, with its leading dashes.
+//
+// Returns:
+//   - true if the argument can be given under the name, false otherwise.
+func argumentHasName(arg arguments.Argument, name string) bool {
+	if arg.GetShortName() == name || arg.GetLongName() == name {
+		return true
+	}
+
+	return slices.ContainsFunc(argumentAliases(arg), func(alias arguments.Alias) bool { return alias.Name == name })
+}
+
+// canonicalArgumentName returns the name an argument is referred to by, its long name or its short
+// name when it has no long name.
+//
+// Parameters:
+//   - arg: The argument to name.
+//
+// Returns:
+//   - The long name of the argument, or its short name when it has no long name.
+func canonicalArgumentName(arg arguments.Argument) string {
+	if longName := arg.GetLongName(); len(longName) != 0 {
+		return longName
+	}
+
+	return arg.GetShortName()
+}
+
+// resolveAlias replaces the alias an argument was given under with the name of the argument, which is
+// the name the argument consumes its tokens under, and records a warning when the alias is deprecated,
+// in the language of the parser.
+//
+// Parameters:
+//   - arg: The argument the first token was matched to.
+//   - tokens: The tokens starting with the name the argument was given under.
+//   - parsingState: The parsing state that records the warnings.
+//
+// Returns:
+//   - The tokens, starting with a name of the argument itself.
+func (ap *ArgumentsParser) resolveAlias(arg arguments.Argument, tokens []string, parsingState *ParsingState) []string {
+	for _, alias := range argumentAliases(arg) {
+		if alias.Name != tokens[0] {
+			continue
+		}
+		if alias.Deprecated {
+			hint := alias.Hint
+			if len(hint) == 0 {
+				hint = ap.message(MESSAGE_DEPRECATED_ALIAS_HINT, canonicalArgumentName(arg))
+			}
+			parsingState.AddWarningMessage(ap.message(MESSAGE_DEPRECATED_ALIAS, alias.Name, hint))
+		}
+		return append([]string{canonicalArgumentName(arg)}, tokens[1:]...)
+	}
+
+	return tokens
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestArgumentAliases verifies that an argument can be given and looked up under its aliases, and
+// that using a deprecated alias emits a warning without making parsing fail.
+func TestArgumentAliases(t *testing.T) {
+	var host string
+	var verbose bool
+	ap := NewParser("test")
+	ap.NewStringArgument(&host, "", "--dc-host", "", false, "Domain controller.")
+	ap.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose mode.")
+	if err := ap.AddArgumentAlias("--dc-host", "domain-controller"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := ap.AddDeprecatedArgumentAlias("--dc-host", "--dc-ip", ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := ap.AddDeprecatedArgumentAlias("--verbose", "V", "it will be removed in 2.0"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	warnings := []string{}
+	ap.SetWarningHandler(func(message string) { warnings = append(warnings, message) })
+
+	ap.ParsingState.SetRawArguments([]string{"test", "--domain-controller", "dc01"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if host != "dc01" || len(warnings) != 0 {
+		t.Errorf("expected the alias to set the argument without warning, got %q and %v", host, warnings)
+	}
+
+	ap.ParsingState = ParsingState{}
+	ap.ParsingState.SetRawArguments([]string{"test", "--dc-ip=dc02", "-V"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	expected := []string{
+		"Argument \"--dc-ip\" is deprecated, use \"--dc-host\" instead.",
+		"Argument \"-V\" is deprecated, it will be removed in 2.0.",
+	}
+	if host != "dc02" || !verbose || strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected the deprecated aliases to set the arguments with warnings, got %q, %v and %v", host, verbose, warnings)
+	}
+	if len(ap.ParsingState.GetErrorMessages()) != 0 {
+		t.Errorf("expected no error, got %v", ap.ParsingState.GetErrorMessages())
+	}
+	for _, name := range []string{"--dc-host", "--domain-controller", "--dc-ip", "-v", "-V"} {
+		if !ap.ArgumentIsPresent(name) {
+			t.Errorf("expected the argument to be present under %q", name)
+		}
+	}
+}
+
+// TestArgumentAliasesInHelp verifies that the aliases are displayed with the names of the argument,
+// and that the deprecated ones are only displayed with HELP_DECORATION_DEPRECATED.
+func TestArgumentAliasesInHelp(t *testing.T) {
+	var host string
+	ap := NewParser("test")
+	ap.Flag("--dc-host").Help("Domain controller.").Alias("--domain-controller").DeprecatedAlias("--dc-ip", "").String(&host)
+
+	line := ap.Groups[""].Arguments[0]
+	if got := ap.generateArgumentLineInHelp(line, 2, 50, 100, &Theme{}, ap.helpDecorations()); !strings.HasPrefix(got, "  --dc-host, --domain-controller <string>") || strings.Contains(got, "--dc-ip") {
+		t.Errorf("expected the alias to be displayed but not the deprecated one, got %q", got)
+	}
+
+	ap.SetHelpDecorations(HELP_DECORATION_DEPRECATED)
+	if got := ap.decoratedHelp(line, ap.helpDecorations()); got != "Domain controller. (deprecated: --dc-ip)" {
+		t.Errorf("expected the deprecated alias to be displayed, got %q", got)
+	}
+}
+
+// TestArgumentAliasErrors verifies that an alias cannot be empty nor reuse the name of an argument.
+func TestArgumentAliasErrors(t *testing.T) {
+	var host, user string
+	ap := NewParser("test")
+	ap.NewStringArgument(&host, "-H", "--host", "", false, "Host.")
+	ap.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+	ap.AddArgumentAlias("--host", "--target")
+
+	for _, alias := range []string{"", "--", "--user", "-u", "--target", "--host"} {
+		if err := ap.AddArgumentAlias("--host", alias); err == nil {
+			t.Errorf("expected an error for alias %q", alias)
+		}
+	}
+	if err := ap.AddArgumentAlias("--missing", "--other"); err == nil {
+		t.Error("expected an error for an unknown argument")
+	}
+
+	var port int
+	if err := ap.Flag("--port").Alias("--target").Int(&port); err == nil {
+		t.Error("expected the builder to reject an alias already used")
+	}
+	if ap.findArgument("--port") != nil {
+		t.Error("expected the argument not to be registered when one of its aliases is rejected")
+	}
+	if ap.findArgument("--target") == nil || ap.findArgument("--target").GetLongName() != "--host" {
+		t.Error("expected the alias to be found")
+	}
+}
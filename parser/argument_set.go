@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+)
+
+// ArgumentSet is a reusable definition of an argument group, such as an "Authentication" group with
+// domain, user, password and hashes arguments, that is defined once and attached to several parsers,
+// typically the subparsers of a command.
+//
+// Define is called once per attachment, with the new group of the parser the set is attached to. When
+// it registers arguments with the same pointers every time, every parser stores the values in the same
+// variables. When it allocates new variables, each parser gets its own copy of the values, which can be
+// read with the Get method of the parser that was selected.
+type ArgumentSet struct {
+	// Name is the name of the argument group created in each parser the set is attached to.
+	Name string
+
+	// Description is the description of the argument group created in each parser.
+	Description string
+
+	// Type is the type of the argument group, one of the argumentgroup.ARGUMENT_GROUP_TYPE_* constants.
+	Type int
+
+	// Count is the number of arguments used by the constraint of the exactly-N and at-most-N group types.
+	Count int
+
+	// Define registers the arguments of the set in the group created for one attachment.
+	Define func(group *argumentgroup.ArgumentGroup) error
+}
+
+// AttachTo attaches the argument set to each of the given parsers.
+//
+// Parameters:
+//   - parsers: The parsers the argument set is attached to.
+//
+// Returns:
+//   - An error joining the errors of the attachments that failed, or nil if all of them succeeded.
+func (set *ArgumentSet) AttachTo(parsers ...*ArgumentsParser) error {
+	var errs []error
+	for _, ap := range parsers {
+		if _, err := ap.AttachArgumentSet(set); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AttachArgumentSet creates a new argument group from an argument set and adds it to the parser.
+//
+// The arguments of the set are defined in a group that is only added to the parser once they are all
+// registered and none of their names is already used by an argument of the parser, so that a failed
+// attachment leaves the parser unchanged.
+//
+// Parameters:
+//   - set: The argument set to attach.
+//
+// Returns:
+//   - A pointer to the new argument group, which can be used to add arguments specific to this parser,
+//     or an error if the set is invalid, if a group with the same name already exists, or if one of its
+//     arguments has the same short name, long name or alias as an argument of the parser.
+func (ap *ArgumentsParser) AttachArgumentSet(set *ArgumentSet) (*argumentgroup.ArgumentGroup, error) {
+	if len(set.Name) == 0 {
+		return nil, fmt.Errorf("name of argument set cannot be empty, this is reserved for the default group")
+	}
+	if set.Define == nil {
+		return nil, fmt.Errorf("argument set \"%s\": Define cannot be nil", set.Name)
+	}
+	if _, exists := ap.Groups[set.Name]; exists {
+		return nil, fmt.Errorf("argument set \"%s\": an argument group with name \"%s\" already exists", set.Name, set.Name)
+	}
+	if (set.Type == argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N || set.Type == argumentgroup.ARGUMENT_GROUP_TYPE_AT_MOST_N) && set.Count < 1 {
+		return nil, fmt.Errorf("argument set \"%s\": the number of arguments of the group needs to be at least 1, got %d", set.Name, set.Count)
+	}
+
+	group := &argumentgroup.ArgumentGroup{
+		Name:        set.Name,
+		Description: set.Description,
+		Type:        set.Type,
+		Count:       set.Count,
+	}
+	if err := set.Define(group); err != nil {
+		return nil, fmt.Errorf("argument set \"%s\": %w", set.Name, err)
+	}
+
+	// findArgument matches the aliases of the arguments of the parser, and the aliases of the arguments
+	// of the set are matched like their names
+	for _, arg := range group.AllArguments() {
+		names := []string{arg.GetShortName(), arg.GetLongName()}
+		for _, alias := range argumentAliases(arg) {
+			names = append(names, alias.Name)
+		}
+		for _, name := range names {
+			if len(name) != 0 && ap.findArgument(name) != nil {
+				return nil, fmt.Errorf("argument set \"%s\": argument with name %s already exists in the parser", set.Name, name)
+			}
+		}
+	}
+
+	if ap.Groups == nil {
+		ap.Groups = make(map[string]*argumentgroup.ArgumentGroup)
+	}
+	ap.Groups[group.Name] = group
+	ap.groupOrder = append(ap.groupOrder, group.Name)
+
+	return group, nil
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestArgumentSetSharedPointers verifies that an argument set attached to several subparsers stores
+// the values in the same variables whichever subparser is selected.
+func TestArgumentSetSharedPointers(t *testing.T) {
+	var mode, user string
+	set := ArgumentSet{
+		Name: "Authentication",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			return group.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+		},
+	}
+
+	ap := NewParser("test")
+	ap.SetupSubParsing("mode", &mode, false)
+	list := ap.AddSubParser("list", "List.")
+	add := ap.AddSubParser("add", "Add.")
+	if err := set.AttachTo(list, add); err != nil {
+		t.Fatalf("AttachTo failed: %v", err)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "add", "-u", "alice"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if mode != "add" || user != "alice" {
+		t.Errorf("expected mode \"add\" and user \"alice\", got %q and %q", mode, user)
+	}
+	if list.Groups["Authentication"] == add.Groups["Authentication"] {
+		t.Errorf("expected each subparser to get its own group")
+	}
+}
+
+// TestArgumentSetPerParserCopies verifies that an argument set allocating its variables in Define
+// gives each parser its own copy of the values.
+func TestArgumentSetPerParserCopies(t *testing.T) {
+	set := ArgumentSet{
+		Name: "Connection",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			port := new(int)
+			return group.NewIntArgument(port, "", "--port", 80, false, "Port.")
+		},
+	}
+
+	first := NewParser("first")
+	second := NewParser("second")
+	if err := set.AttachTo(first, second); err != nil {
+		t.Fatalf("AttachTo failed: %v", err)
+	}
+
+	first.ParsingState.SetRawArguments([]string{"first", "--port", "8080"})
+	first.ParseFrom(1, &first.ParsingState)
+	second.ParsingState.SetRawArguments([]string{"second"})
+	second.ParseFrom(1, &second.ParsingState)
+
+	if value, _ := first.Get("--port"); value != 8080 {
+		t.Errorf("expected port 8080 in the first parser, got %v", value)
+	}
+	if value, _ := second.Get("--port"); value != 80 {
+		t.Errorf("expected port 80 in the second parser, got %v", value)
+	}
+}
+
+// TestArgumentSetDuplicateNames verifies that attaching a set whose arguments have the same names as
+// arguments of the parser fails without modifying the parser.
+func TestArgumentSetDuplicateNames(t *testing.T) {
+	var user, other string
+	set := ArgumentSet{
+		Name: "Authentication",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			return group.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+		},
+	}
+
+	ap := NewParser("test")
+	ap.NewStringArgument(&other, "-u", "--username", "", false, "Other.")
+	if _, err := ap.AttachArgumentSet(&set); err == nil {
+		t.Fatalf("expected an error for a short name already used in the parser")
+	}
+	if _, exists := ap.Groups["Authentication"]; exists {
+		t.Errorf("expected the group not to be added after a failed attachment")
+	}
+
+	secondParser := NewParser("other")
+	if _, err := secondParser.AttachArgumentSet(&set); err != nil {
+		t.Fatalf("AttachArgumentSet failed: %v", err)
+	}
+	if _, err := secondParser.AttachArgumentSet(&set); err == nil {
+		t.Errorf("expected an error when attaching the same set twice to a parser")
+	}
+}
+
+// TestArgumentSetDuplicateAliases verifies that attaching a set fails when one of its arguments has a
+// name used as an alias in the parser, or an alias used as a name in the parser.
+func TestArgumentSetDuplicateAliases(t *testing.T) {
+	var user, host, other string
+	names := ArgumentSet{
+		Name: "Authentication",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			return group.NewStringArgument(&user, "", "--user", "", false, "User.")
+		},
+	}
+	aliases := ArgumentSet{
+		Name: "Connection",
+		Define: func(group *argumentgroup.ArgumentGroup) error {
+			if err := group.NewStringArgument(&host, "", "--host", "", false, "Host."); err != nil {
+				return err
+			}
+			group.Arguments[0].(*arguments.StringArgument).AddAlias(arguments.Alias{Name: "--target"})
+			return nil
+		},
+	}
+
+	ap := NewParser("test")
+	ap.NewStringArgument(&other, "", "--username", "", false, "Other.")
+	if err := ap.AddArgumentAlias("--username", "--user"); err != nil {
+		t.Fatalf("AddArgumentAlias failed: %v", err)
+	}
+	if _, err := ap.AttachArgumentSet(&names); err == nil {
+		t.Errorf("expected an error for a name used as an alias in the parser")
+	}
+
+	ap = NewParser("test")
+	ap.NewStringArgument(&other, "", "--target", "", false, "Other.")
+	if _, err := ap.AttachArgumentSet(&aliases); err == nil {
+		t.Errorf("expected an error for an alias used as a name in the parser")
+	}
+	if _, exists := ap.Groups["Connection"]; exists {
+		t.Errorf("expected the group not to be added after a failed attachment")
+	}
+}
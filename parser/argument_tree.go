@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+)
+
+// argumentTreeNode is a node of the tree describing a parser, rendered by ArgumentTree.
+type argumentTreeNode struct {
+	// Label is the text of the node.
+	Label string
+	// Children are the nodes under this one.
+	Children []*argumentTreeNode
+}
+
+// PrintArgumentTree prints the tree describing the ArgumentsParser, as returned by ArgumentTree.
+func (ap *ArgumentsParser) PrintArgumentTree() {
+	fmt.Print(ap.ArgumentTree())
+}
+
+// ArgumentTree returns a tree describing the ArgumentsParser, to inspect how it was defined: its
+// banner, its positional arguments, its arguments with their type and details, its argument groups and
+// their subgroups, and its subparsers with their aliases, each one described the same way. Each level
+// of the tree is indented under its parent with "│  ". The hidden arguments and groups are left out.
+//
+// Returns:
+// - The tree describing the parser, each node on its own line.
+func (ap *ArgumentsParser) ArgumentTree() string {
+	root := ap.argumentTreeNode("<ArgumentsParser>")
+
+	output := &strings.Builder{}
+	output.WriteString(root.Label + "\n")
+	writeArgumentTreeChildren(output, root.Children, "")
+
+	return output.String()
+}
+
+// writeArgumentTreeChildren writes the nodes under a node of the tree, and their own children.
+//
+// Parameters:
+// - output: The builder the tree is written to.
+// - children: The nodes to write.
+// - prefix: The characters written before the nodes, continuing the branches of their ancestors.
+func writeArgumentTreeChildren(output *strings.Builder, children []*argumentTreeNode, prefix string) {
+	for k, child := range children {
+		branch, continuation := "├─ ", "│  "
+		if k == len(children)-1 {
+			branch, continuation = "└─ ", "   "
+		}
+		output.WriteString(prefix + branch + child.Label + "\n")
+		writeArgumentTreeChildren(output, child.Children, prefix+continuation)
+	}
+}
+
+// argumentTreeNode builds the node of the tree describing the parser.
+//
+// Parameters:
+// - label: The label of the node.
+//
+// Returns:
+// - The node describing the parser, along with its groups and subparsers.
+func (ap *ArgumentsParser) argumentTreeNode(label string) *argumentTreeNode {
+	node := &argumentTreeNode{Label: label}
+	node.Children = append(node.Children, &argumentTreeNode{Label: fmt.Sprintf("Banner: \"%s\"", ap.Banner)})
+
+	if len(ap.PositionalArguments) != 0 {
+		positionalsNode := &argumentTreeNode{Label: fmt.Sprintf("Positionals (%d)", len(ap.PositionalArguments))}
+		for _, posarg := range ap.PositionalArguments {
+			positionalsNode.Children = append(positionalsNode.Children, &argumentTreeNode{Label: positionalTreeLabel(posarg)})
+		}
+		node.Children = append(node.Children, positionalsNode)
+	}
+
+	groups := ap.visibleGroups(arguments.VISIBILITY_ADVANCED)
+	if defaultGroup := groups[""]; len(defaultGroup.Arguments) != 0 {
+		node.Children = append(node.Children, ap.argumentsTreeNode(defaultGroup.Arguments))
+	}
+
+	groupsNode := &argumentTreeNode{}
+	for _, groupName := range ap.orderedGroupNames() {
+		if group := groups[groupName]; group != nil {
+			groupsNode.Children = append(groupsNode.Children, ap.groupTreeNode(group))
+		}
+	}
+	if len(groupsNode.Children) != 0 {
+		groupsNode.Label = fmt.Sprintf("Groups (%d)", len(groupsNode.Children))
+		node.Children = append(node.Children, groupsNode)
+	}
+
+	if ap.SubParsers.Enabled && len(ap.SubParsers.Parsers) != 0 {
+		names := []string{}
+		for name := range ap.SubParsers.Parsers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		subparsersNode := &argumentTreeNode{Label: fmt.Sprintf("SubParsers (%d)", len(names))}
+		for _, name := range names {
+			subparser := ap.SubParsers.Parsers[name]
+			subparser.parent = ap
+			label := fmt.Sprintf("<SubParser name=\"%s\">", name)
+			if aliases := ap.SubParsers.AliasesOf(name); len(aliases) != 0 {
+				label = fmt.Sprintf("<SubParser name=\"%s\" aliases=\"%s\">", name, strings.Join(aliases, ","))
+			}
+			subparsersNode.Children = append(subparsersNode.Children, subparser.argumentTreeNode(label))
+		}
+		node.Children = append(node.Children, subparsersNode)
+	}
+
+	return node
+}
+
+// groupTreeNode builds the node of the tree describing an argument group.
+//
+// Parameters:
+// - group: The argument group, holding only its arguments and subgroups that are not hidden.
+//
+// Returns:
+// - The node describing the group, along with its subgroups.
+func (ap *ArgumentsParser) groupTreeNode(group *argumentgroup.ArgumentGroup) *argumentTreeNode {
+	node := &argumentTreeNode{Label: fmt.Sprintf("<Group name=\"%s\">", group.Name)}
+	if len(group.Description) != 0 {
+		node.Children = append(node.Children, &argumentTreeNode{Label: fmt.Sprintf("Description: \"%s\"", group.Description)})
+	}
+	if len(group.Positionals) != 0 {
+		names := []string{}
+		for _, posarg := range group.Positionals {
+			names = append(names, "<"+posarg.GetName()+">")
+		}
+		node.Children = append(node.Children, &argumentTreeNode{Label: "Positionals: " + strings.Join(names, ", ")})
+	}
+	if len(group.Arguments) != 0 {
+		node.Children = append(node.Children, ap.argumentsTreeNode(group.Arguments))
+	}
+	if len(group.SubGroups) != 0 {
+		subgroupsNode := &argumentTreeNode{Label: fmt.Sprintf("SubGroups (%d)", len(group.SubGroups))}
+		for _, subgroup := range group.SubGroups {
+			subgroupsNode.Children = append(subgroupsNode.Children, ap.groupTreeNode(subgroup))
+		}
+		node.Children = append(node.Children, subgroupsNode)
+	}
+
+	return node
+}
+
+// argumentsTreeNode builds the node of the tree listing arguments.
+//
+// Parameters:
+// - args: The arguments to list.
+//
+// Returns:
+// - The node listing the arguments, one child per argument.
+func (ap *ArgumentsParser) argumentsTreeNode(args []arguments.Argument) *argumentTreeNode {
+	node := &argumentTreeNode{Label: fmt.Sprintf("Arguments (%d)", len(args))}
+	for _, arg := range args {
+		node.Children = append(node.Children, &argumentTreeNode{Label: ap.argumentTreeLabel(arg)})
+	}
+
+	return node
+}
+
+// argumentTreeLabel describes an argument in the tree with its names, the type of its value, its help
+// message and its details, such as ("-p","--port") [tcp port] "Port." (default: 445). The type of the
+// arguments that do not implement arguments.ArgumentMetadata is their Go type.
+//
+// Parameters:
+// - arg: The argument to describe.
+//
+// Returns:
+// - The description of the argument.
+func (ap *ArgumentsParser) argumentTreeLabel(arg arguments.Argument) string {
+	typeName := fmt.Sprintf("%T", arg)
+	if metadata, ok := arg.(arguments.ArgumentMetadata); ok {
+		typeName = metadata.GetTypeName()
+	}
+
+	details := []string{}
+	for _, alias := range displayedAliases(arg) {
+		details = append(details, "alias: "+alias)
+	}
+	if arguments.VisibilityOf(arg) == arguments.VISIBILITY_ADVANCED {
+		details = append(details, "advanced")
+	}
+	details = append(details, ap.argumentDecorations(arg, append(defaultHelpDecorations, HELP_DECORATION_DEPRECATED))...)
+
+	label := fmt.Sprintf("(\"%s\",\"%s\") [%s] \"%s\"", arg.GetShortName(), arg.GetLongName(), typeName, arg.GetHelp())
+	if len(details) != 0 {
+		label += " (" + strings.Join(details, ", ") + ")"
+	}
+
+	return label
+}
+
+// positionalTreeLabel describes a positional argument in the tree with its name, the type of its value
+// and its help message, such as <target> [string] "Target.". The type of the positional arguments
+// that do not implement positionals.TypedArgument is their Go type.
+//
+// Parameters:
+// - posarg: The positional argument to describe.
+//
+// Returns:
+// - The description of the positional argument.
+func positionalTreeLabel(posarg positionals.PositionalArgument) string {
+	typeName := fmt.Sprintf("%T", posarg)
+	if typedArgument, ok := posarg.(positionals.TypedArgument); ok {
+		typeName = typedArgument.GetTypeName()
+	}
+
+	label := fmt.Sprintf("<%s> [%s] \"%s\"", posarg.GetName(), typeName, posarg.GetHelp())
+	if !posarg.IsRequired() {
+		label += " (optional)"
+	}
+
+	return label
+}
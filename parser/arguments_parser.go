@@ -15,6 +15,19 @@ type ArgumentsParser struct {
 	// This is typically used to display the program name or purpose.
 	Banner string
 
+	// Summary is the one-line description of the parser, displayed next to its name in the usage
+	// message of its parent. When empty, the banner is displayed instead.
+	Summary string
+
+	// Description is the long description of the parser, displayed under the usage line.
+	Description string
+
+	// Examples are examples of invocations of the program, displayed after the arguments.
+	Examples []Example
+
+	// Epilog is the text displayed at the end of the usage message, such as links or exit codes.
+	Epilog string
+
 	// Options holds various configuration options for the ArgumentsParser.
 	Options ArgumentsParserOptions
 
@@ -29,9 +42,17 @@ type ArgumentsParser struct {
 	// into logical categories for better structure and readability.
 	Groups map[string]*argumentgroup.ArgumentGroup
 
+	// groupOrder is a slice of the names of the argument groups in the order they were created,
+	// used to display them in that order in the help message.
+	groupOrder []string
+
 	// SubParsers holds the subparsers for handling subcommands within the main parser.
 	SubParsers SubParsers
 
+	// parent is the parser this parser is a subparser of, or nil for the top-level parser.
+	// Options that a subparser does not set, such as its help flags, are taken from it.
+	parent *ArgumentsParser
+
 	// shortNameToArgument is a map that associates short flag names (e.g., "-v")
 	// with their corresponding argument structures.
 	shortNameToArgument map[string]arguments.Argument
@@ -47,4 +68,16 @@ type ArgumentsParser struct {
 	// allArguments is a slice containing all arguments (both positional and named) that
 	// the parser manages.
 	allArguments []arguments.Argument
+
+	// rules is a slice of relations between two arguments, such as conflicts or conditional
+	// requirements, checked after the argument groups in the order they were added.
+	rules []rule
+
+	// constraints is a slice of boolean expressions over the arguments, checked after the
+	// rules between arguments in the order they were added.
+	constraints []constraint
+
+	// validationHooks is a slice of functions checking the parsed arguments as a whole,
+	// run after the argument group constraints have been checked.
+	validationHooks []ValidationHook
 }
@@ -19,13 +19,13 @@ func NewParser(banner string) *ArgumentsParser {
 //
 // Parameters:
 //   - argumentName: The name of the argument to check. It should start with a dash ('-')
-//     for short arguments or two dashes ('--') for long arguments.
+//     for short arguments or two dashes ('--') for long arguments, and can be one of its aliases.
 //
 // Returns:
 // - bool: true if the argument is present; false otherwise.
 //
 // The function first verifies that the argument name has a valid length and starts
-// with a dash. It then checks if the argument is in the `longNameToArgument` map
+// with a dash, and resolves an alias to the name of its argument. It then checks if the argument is in the `longNameToArgument` map
 // for long arguments or `shortNameToArgument` map for short arguments, returning
 // true if found and false if not.
 func (ap *ArgumentsParser) ArgumentIsPresent(argumentName string) bool {
@@ -33,6 +33,11 @@ func (ap *ArgumentsParser) ArgumentIsPresent(argumentName string) bool {
 		return false
 	}
 
+	// The parsed arguments are recorded under their names only, not under their aliases
+	if arg := ap.findArgument(argumentName); arg != nil {
+		argumentName = canonicalArgumentName(arg)
+	}
+
 	if argumentName[1] == '-' {
 		// Long argument flag (e.g., --example)
 		if argument, exists := ap.ParsingState.ParsedArguments.LongNameToArgument[argumentName]; exists {
@@ -82,37 +87,50 @@ func (ap *ArgumentsParser) Get(argumentFlag string) (interface{}, error) {
 	return arg.GetValue(), nil
 }
 
-// PrintArgumentTree prints the argument tree for the ArgumentsParser.
+// findArgument looks up a registered argument by its short or long name, or one of its aliases,
+// across all the argument groups of the parser, including the default group.
+//
+// Unlike Get, it does not rely on the lookup maps built when parsing, so it can be used while the
+// parser is still being defined.
+//
+// Parameters:
+//   - argumentFlag: The short (e.g., "-o") or long (e.g., "--output") name of the argument.
 //
-// The function prints the banner, the list of arguments, and the subgroups in a tree-like structure.
-// Each level of indentation is represented by "  │ ". The output includes the banner, the names of the arguments,
-// and the names of the subgroups within the ArgumentsParser.
-func (ap *ArgumentsParser) PrintArgumentTree() {
-	indent := 0
-	//
-	fmt.Printf("<ArgumentsParser>\n")
-
-	fmt.Printf("  ├─ Banner: \"%s\"\n", ap.Banner)
-
-	fmt.Printf("  ├─ Arguments (%d): \n", len(ap.Groups[""].Arguments))
-	for _, argument := range ap.Groups[""].Arguments {
-		argtype := ""
-		if _, ok := argument.(*arguments.BoolArgument); ok {
-			argtype = "bool"
-		} else if _, ok := argument.(*arguments.StringArgument); ok {
-			argtype = "string"
-		} else if _, ok := argument.(*arguments.IntArgument); ok {
-			argtype = "int"
+// Returns:
+//   - The argument, or nil if no argument is registered with this name.
+func (ap *ArgumentsParser) findArgument(argumentFlag string) arguments.Argument {
+	for _, groupName := range ap.sortedGroupNames() {
+		for _, arg := range ap.Groups[groupName].AllArguments() {
+			if argumentHasName(arg, argumentFlag) {
+				return arg
+			}
 		}
-		fmt.Printf("  │   │   ├─ (\"%s\",\"%s\") [%s] \"%s\"\n", argument.GetShortName(), argument.GetLongName(), argtype, argument.GetHelp())
 	}
-	fmt.Printf("  │   │   └──\n")
-	fmt.Printf("  │   └──\n")
 
-	// Call subgroups
-	fmt.Printf("  ├─ SubGroups: \n")
-	for _, subgroup := range ap.Groups {
-		subgroup.PrintArgumentTree(indent + 1)
+	return nil
+}
+
+// SetArgumentMetavar sets the name displayed for the value of a registered argument in the usage
+// and help messages, for example "FILE" to display "--output <FILE>" instead of "--output <string>".
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - metavar: The name to display for the value of the argument, without angle brackets.
+//
+// Returns:
+//   - An error if no argument is registered with this name, or if the argument does not
+//     implement arguments.ArgumentMetadata.
+func (ap *ArgumentsParser) SetArgumentMetavar(argumentFlag string, metavar string) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
 	}
-	fmt.Printf("  └──\n")
+
+	metadata, ok := arg.(arguments.ArgumentMetadata)
+	if !ok {
+		return fmt.Errorf("argument '%s' does not support setting a metavar", argumentFlag)
+	}
+	metadata.SetMetavar(metavar)
+
+	return nil
 }
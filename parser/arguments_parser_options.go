@@ -1,11 +1,93 @@
 package parser
 
+import (
+	"os"
+	"slices"
+	"strconv"
+)
+
 type ArgumentsParserOptions struct {
 	ShowBannerOnHelp bool
 
 	ShowBannerOnRun bool
+
+	// HelpFlags are the flags displaying the help message. When nil, a subparser uses the help flags
+	// of its parent parser, and the top-level parser uses "-h" and "--help". When empty but not nil,
+	// there are no help flags.
+	HelpFlags []string
+
+	// HelpAllFlags are the flags displaying the full help message, which includes the advanced
+	// arguments. When nil, a subparser uses the help-all flags of its parent parser, and the top-level
+	// parser uses "-hh" and "--help-all". When empty but not nil, there are no help-all flags.
+	HelpAllFlags []string
+
+	// ShowCommandTree lists all the subparsers in the usage message of a parser with subparsers,
+	// recursively, instead of its own subparsers only. The subparsers of a parser with this option
+	// list their own subparsers recursively as well.
+	ShowCommandTree bool
+
+	// DisableHelpCommand disables the "help <subcommand>" command of a parser with subparsers.
+	DisableHelpCommand bool
+
+	// VersionEnabled enables the version flags, which print the version and exit.
+	VersionEnabled bool
+
+	// Version is the version printed by the version flags. When empty, it is read from the build
+	// information of the binary.
+	Version string
+
+	// VersionFlags are the flags printing the version. When nil, "--version" is used.
+	VersionFlags []string
+
+	// HelpWidth is the width the usage and help messages are wrapped to. When 0, a subparser uses the
+	// width of its parent parser, and the top-level parser uses the COLUMNS environment variable, or
+	// 80 columns when it is not set.
+	HelpWidth int
+
+	// ColorMode is when the usage and error messages are colored, one of COLOR_MODE_AUTO,
+	// COLOR_MODE_ALWAYS and COLOR_MODE_NEVER.
+	ColorMode int
+
+	// OutputFormat is the format of the usage and error messages, one of OUTPUT_FORMAT_AUTO,
+	// OUTPUT_FORMAT_TEXT and OUTPUT_FORMAT_JSON.
+	OutputFormat int
+
+	// Theme holds the styles of the usage and error messages when they are colored. When nil, a
+	// subparser uses the theme of its parent parser, and the top-level parser uses DefaultTheme.
+	Theme *Theme
+
+	// HelpTemplate is the text/template the help message is rendered with. When empty, a subparser
+	// uses the template of its parent parser, and the top-level parser uses DefaultHelpTemplate.
+	HelpTemplate string
+
+	// HelpDecorations are the details displayed after the help message of the arguments, such as their
+	// default value, as HELP_DECORATION_* constants. When nil, a subparser uses the decorations of its
+	// parent parser, and the top-level parser displays all of them but the deprecated aliases. When
+	// empty but not nil, none is.
+	HelpDecorations []int
+
+	// WarningHandler receives the warnings of the parser, such as the use of a deprecated alias. When
+	// nil, a subparser uses the handler of its parent parser, and the top-level parser prints the
+	// warnings to the standard error.
+	WarningHandler func(message string)
+
+	// Language is the language of the messages of the parser, such as "fr". When empty, a subparser uses
+	// the language of its parent parser, and the top-level parser reads it from the LC_ALL, LC_MESSAGES
+	// and LANG environment variables.
+	Language string
+
+	// MessageCatalogs are the catalogs of messages registered with AddMessageCatalog, which the
+	// subparsers use as well.
+	MessageCatalogs []*MessageCatalog
 }
 
+// defaultHelpFlags are the help flags of a parser that did not configure its own.
+var defaultHelpFlags = []string{"-h", "--help"}
+
+// defaultHelpWidth is the width of the usage and help messages when neither the HelpWidth option nor
+// the COLUMNS environment variable sets it.
+const defaultHelpWidth = 80
+
 // SetOptShowBannerOnHelp sets the option to show the banner on help.
 //
 // Parameters:
@@ -21,3 +103,110 @@ func (ap *ArgumentsParser) SetOptShowBannerOnHelp(showBannerOnHelp bool) {
 func (ap *ArgumentsParser) SetOptShowBannerOnRun(showBannerOnRun bool) {
 	ap.Options.ShowBannerOnRun = showBannerOnRun
 }
+
+// SetHelpFlags sets the flags displaying the help message, for example to keep "-h" for a "--host"
+// argument with SetHelpFlags("--help"). Calling it without flags disables the help flags. The
+// subparsers that did not set their own help flags use the ones of their parent parser.
+//
+// Parameters:
+// - flags: The short or long names of the help flags.
+func (ap *ArgumentsParser) SetHelpFlags(flags ...string) {
+	ap.Options.HelpFlags = append([]string{}, flags...)
+}
+
+// DisableHelpFlags disables the help and help-all flags of the parser, and of the subparsers that did
+// not set their own.
+func (ap *ArgumentsParser) DisableHelpFlags() {
+	ap.SetHelpFlags()
+	ap.SetHelpAllFlags()
+}
+
+// SetOptDisableHelpCommand sets the option to disable the "help <subcommand>" command.
+//
+// Parameters:
+// - disableHelpCommand: A boolean indicating whether to disable the help command.
+func (ap *ArgumentsParser) SetOptDisableHelpCommand(disableHelpCommand bool) {
+	ap.Options.DisableHelpCommand = disableHelpCommand
+}
+
+// SetOptShowCommandTree sets the option to list all the subparsers in the usage message, recursively,
+// so that the subcommands of the subcommands can be discovered from the top-level usage message.
+//
+// Parameters:
+// - showCommandTree: A boolean indicating whether to list the subparsers recursively.
+func (ap *ArgumentsParser) SetOptShowCommandTree(showCommandTree bool) {
+	ap.Options.ShowCommandTree = showCommandTree
+}
+
+// showCommandTree reports whether the usage message of the parser lists its subparsers recursively,
+// which is the case when the parser or one of its parent parsers set the option.
+//
+// Returns:
+// - true if the subparsers are listed recursively, false otherwise.
+func (ap *ArgumentsParser) showCommandTree() bool {
+	if ap.Options.ShowCommandTree {
+		return true
+	}
+	if ap.parent != nil {
+		return ap.parent.showCommandTree()
+	}
+
+	return false
+}
+
+// helpFlags returns the flags displaying the help message of the parser, which are its own if it
+// set them, or else the ones of its parent parser, or else "-h" and "--help".
+//
+// Returns:
+// - The help flags of the parser, empty when they are disabled.
+func (ap *ArgumentsParser) helpFlags() []string {
+	if ap.Options.HelpFlags != nil {
+		return ap.Options.HelpFlags
+	}
+	if ap.parent != nil {
+		return ap.parent.helpFlags()
+	}
+
+	return defaultHelpFlags
+}
+
+// isHelpFlag reports whether a token is one of the help flags of the parser.
+//
+// Parameters:
+// - token: The command line token to check.
+//
+// Returns:
+// - true if the token is a help flag, false otherwise.
+func (ap *ArgumentsParser) isHelpFlag(token string) bool {
+	return slices.Contains(ap.helpFlags(), token)
+}
+
+// SetOptHelpWidth sets the width the usage and help messages are wrapped to, overriding the
+// COLUMNS environment variable. A width of 0 restores the detection of the width.
+//
+// Parameters:
+// - helpWidth: The width of the usage and help messages, in columns.
+func (ap *ArgumentsParser) SetOptHelpWidth(helpWidth int) {
+	ap.Options.HelpWidth = helpWidth
+}
+
+// helpWidth returns the width the usage and help messages of the parser are wrapped to, which is
+// its own if it set one, or else the one of its parent parser, or else the value of the COLUMNS
+// environment variable, or else 80 columns.
+//
+// Returns:
+// - The width of the usage and help messages, in columns.
+func (ap *ArgumentsParser) helpWidth() int {
+	if ap.Options.HelpWidth > 0 {
+		return ap.Options.HelpWidth
+	}
+	if ap.parent != nil {
+		return ap.parent.helpWidth()
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return defaultHelpWidth
+}
@@ -0,0 +1,741 @@
+package parser
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// ArgumentBuilder declares an argument through chained calls, as an alternative to the New*Argument
+// methods and their positional parameters. It is created by ArgumentsParser.Flag, configured with
+// its setters, and the argument is registered by the method naming its type, such as Int or String:
+//
+//	err := ap.Flag("--port").Short("-p").Help("Port to listen on.").Default(80).Env("PORT").Group("Server").TcpPort(&port)
+//
+// The declaration is validated when the argument is registered, and every problem found is reported
+// in the single error returned by the registering method.
+type ArgumentBuilder struct {
+	parser *ArgumentsParser
+
+	shortName    string
+	longName     string
+	help         string
+	defaultValue any
+	hasDefault   bool
+	required     bool
+	envVar       string
+	groupName    string
+	metavar      string
+	visibility   int
+	aliases      []arguments.Alias
+
+	choices         []string
+	caseInsensitive bool
+	validators      []arguments.Validator
+}
+
+// Flag starts the declaration of an argument with the given long name (e.g., "--port").
+// The leading dashes are optional. An argument with only a short name can be declared by passing
+// an empty long name and calling Short.
+//
+// Parameters:
+// - longName: The long flag of the argument.
+//
+// Returns:
+// - An ArgumentBuilder to configure and register the argument.
+func (ap *ArgumentsParser) Flag(longName string) *ArgumentBuilder {
+	return &ArgumentBuilder{
+		parser:   ap,
+		longName: longName,
+	}
+}
+
+// Short sets the short flag of the argument (e.g., "-p"). The leading dash is optional.
+func (ab *ArgumentBuilder) Short(shortName string) *ArgumentBuilder {
+	ab.shortName = shortName
+	return ab
+}
+
+// Help sets the description of the argument displayed in the help message.
+func (ab *ArgumentBuilder) Help(help string) *ArgumentBuilder {
+	ab.help = help
+	return ab
+}
+
+// Default sets the value of the argument when it is not given. Its type has to match the type of
+// the argument, for example an int for Int or a []string for ListOfStrings, and a string holding
+// the textual form of the value for FlagValue and TextUnmarshaler.
+func (ab *ArgumentBuilder) Default(value any) *ArgumentBuilder {
+	ab.defaultValue = value
+	ab.hasDefault = true
+	return ab
+}
+
+// Required marks the argument as mandatory.
+func (ab *ArgumentBuilder) Required() *ArgumentBuilder {
+	ab.required = true
+	return ab
+}
+
+// Env sets the environment variable the argument reads its value from when it is not given on the
+// command line.
+func (ab *ArgumentBuilder) Env(name string) *ArgumentBuilder {
+	ab.envVar = name
+	return ab
+}
+
+// Group sets the name of the argument group the argument is registered in. The group is created
+// as a normal argument group if it does not exist yet. Without a group, the argument is registered
+// in the default group.
+func (ab *ArgumentBuilder) Group(name string) *ArgumentBuilder {
+	ab.groupName = name
+	return ab
+}
+
+// Metavar sets the name displayed for the value of the argument in the usage and help messages,
+// for example "FILE" to display "--output <FILE>".
+func (ab *ArgumentBuilder) Metavar(metavar string) *ArgumentBuilder {
+	ab.metavar = metavar
+	return ab
+}
+
+// Alias adds an additional name the argument can be given under, displayed with its other names. It
+// can be called several times.
+func (ab *ArgumentBuilder) Alias(name string) *ArgumentBuilder {
+	ab.aliases = append(ab.aliases, arguments.Alias{Name: name})
+	return ab
+}
+
+// DeprecatedAlias adds a deprecated name the argument can be given under, which emits a warning when
+// used and is not displayed by the help message, see AddDeprecatedArgumentAlias.
+func (ab *ArgumentBuilder) DeprecatedAlias(name string, hint string) *ArgumentBuilder {
+	ab.aliases = append(ab.aliases, arguments.Alias{Name: name, Deprecated: true, Hint: hint})
+	return ab
+}
+
+// Advanced only displays the argument in the full help message, requested with the help-all flags.
+func (ab *ArgumentBuilder) Advanced() *ArgumentBuilder {
+	ab.visibility = arguments.VISIBILITY_ADVANCED
+	return ab
+}
+
+// Hidden never displays the argument in the help message nor in the generated documentation. It is
+// still parsed.
+func (ab *ArgumentBuilder) Hidden() *ArgumentBuilder {
+	ab.visibility = arguments.VISIBILITY_HIDDEN
+	return ab
+}
+
+// Validate adds a validator checking the value of the argument once it has been parsed. It can be
+// called several times, the validators being run in the order they were added.
+func (ab *ArgumentBuilder) Validate(validator arguments.Validator) *ArgumentBuilder {
+	ab.validators = append(ab.validators, validator)
+	return ab
+}
+
+// Choices sets the list of values accepted by the argument, for Enum and ListOfEnums.
+func (ab *ArgumentBuilder) Choices(choices ...string) *ArgumentBuilder {
+	ab.choices = choices
+	return ab
+}
+
+// CaseInsensitive makes the value given by the user match a choice regardless of its case, for Enum
+// and ListOfEnums.
+func (ab *ArgumentBuilder) CaseInsensitive() *ArgumentBuilder {
+	ab.caseInsensitive = true
+	return ab
+}
+
+// Bool registers the argument as a BoolArgument storing its value in ptr.
+// A boolean argument is a flag and cannot be required.
+func (ab *ArgumentBuilder) Bool(ptr *bool) error {
+	errs := ab.validate(ptr == nil)
+	if ab.required {
+		errs = append(errs, fmt.Errorf("a boolean argument cannot be required"))
+	}
+	defaultValue, err := builderDefault(ab, false)
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.BoolArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// Action registers the argument as an ActionArgument running action when it is given, then continuing
+// parsing or exiting with exitCode depending on behavior, one of the arguments.ACTION_BEHAVIOR_*
+// constants. An action argument holds no value, so it cannot be required nor have a default value.
+func (ab *ArgumentBuilder) Action(action func() error, behavior int, exitCode int) error {
+	errs := ab.validate(false)
+	if action == nil {
+		errs = append(errs, fmt.Errorf("the action of an action argument cannot be nil"))
+	}
+	if ab.required {
+		errs = append(errs, fmt.Errorf("an action argument cannot be required"))
+	}
+	if ab.hasDefault {
+		errs = append(errs, fmt.Errorf("an action argument cannot have a default value"))
+	}
+	if behavior != arguments.ACTION_BEHAVIOR_CONTINUE && behavior != arguments.ACTION_BEHAVIOR_EXIT {
+		errs = append(errs, fmt.Errorf("unknown action behavior %d", behavior))
+	}
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.ActionArgument{}
+	arg.Init(ab.shortName, ab.longName, action, behavior, exitCode, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// String registers the argument as a StringArgument storing its value in ptr.
+func (ab *ArgumentBuilder) String(ptr *string) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault(ab, "")
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.StringArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// Int registers the argument as an IntArgument storing its value in ptr.
+func (ab *ArgumentBuilder) Int(ptr *int) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault(ab, 0)
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.IntArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// IntRange registers the argument as an IntRangeArgument storing its value in ptr, accepting values
+// between rangeStart and rangeStop inclusive. The default value has to be within that range unless
+// the argument is required.
+func (ab *ArgumentBuilder) IntRange(ptr *int, rangeStart, rangeStop int) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault(ab, 0)
+	errs = appendIfError(errs, err)
+	if rangeStart > rangeStop {
+		errs = append(errs, fmt.Errorf("range start %d is greater than range stop %d", rangeStart, rangeStop))
+	} else if err == nil && !ab.required && (defaultValue < rangeStart || defaultValue > rangeStop) {
+		errs = append(errs, fmt.Errorf("default value %d is not in range [%d, %d]", defaultValue, rangeStart, rangeStop))
+	}
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.IntRangeArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, rangeStart, rangeStop, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// TcpPort registers the argument as a TcpPortArgument storing its value in ptr. The default value
+// has to be a valid TCP port unless the argument is required.
+func (ab *ArgumentBuilder) TcpPort(ptr *int) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault(ab, 0)
+	errs = appendIfError(errs, err)
+	if err == nil && !ab.required && (defaultValue < 0 || defaultValue > 65535) {
+		errs = append(errs, fmt.Errorf("default value %d is not a TCP port in range 0-65535", defaultValue))
+	}
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.TcpPortArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// ListOfInts registers the argument as a ListOfIntsArgument storing its values in ptr.
+func (ab *ArgumentBuilder) ListOfInts(ptr *[]int) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault[[]int](ab, nil)
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.ListOfIntsArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// ListOfStrings registers the argument as a ListOfStringsArgument storing its values in ptr.
+func (ab *ArgumentBuilder) ListOfStrings(ptr *[]string) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault[[]string](ab, nil)
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.ListOfStringsArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// MapOfHttpHeaders registers the argument as a MapOfHttpHeadersArgument storing its values in ptr.
+func (ab *ArgumentBuilder) MapOfHttpHeaders(ptr *map[string]string) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault[map[string]string](ab, nil)
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.MapOfHttpHeadersArgument{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// Enum registers the argument as an EnumArgument storing in ptr one of the values set with Choices.
+func (ab *ArgumentBuilder) Enum(ptr *string) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault(ab, "")
+	errs = appendIfError(errs, err)
+	errs = appendIfError(errs, arguments.CheckChoices(ab.choices, defaultValue))
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.EnumArgument[string]{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.choices, ab.caseInsensitive, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// ListOfEnums registers the argument as a ListOfEnumsArgument storing in ptr the values, out of the
+// ones set with Choices, given on the command line.
+func (ab *ArgumentBuilder) ListOfEnums(ptr *[]string) error {
+	errs := ab.validate(ptr == nil)
+	defaultValue, err := builderDefault[[]string](ab, nil)
+	errs = appendIfError(errs, err)
+	errs = appendIfError(errs, arguments.CheckChoices(ab.choices, defaultValue...))
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.ListOfEnumsArgument[string]{}
+	arg.Init(ptr, ab.shortName, ab.longName, defaultValue, ab.choices, ab.caseInsensitive, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// FlagValue registers the argument as a FlagValueArgument handled by value.
+// The default value, if any, is the textual form given to the Set method of value.
+func (ab *ArgumentBuilder) FlagValue(value flag.Value) error {
+	errs := ab.validate(value == nil)
+	defaultValue, err := builderDefault(ab, "")
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.FlagValueArgument{}
+	arg.Init(value, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// TextUnmarshaler registers the argument as a TextUnmarshalerArgument parsed by value.
+// The default value, if any, is the textual form given to the UnmarshalText method of value.
+func (ab *ArgumentBuilder) TextUnmarshaler(value encoding.TextUnmarshaler) error {
+	errs := ab.validate(value == nil)
+	defaultValue, err := builderDefault(ab, "")
+	errs = appendIfError(errs, err)
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+
+	arg := &arguments.TextUnmarshalerArgument{}
+	arg.Init(value, ab.shortName, ab.longName, defaultValue, ab.required, ab.help)
+	return ab.register(arg, &arg.Attributes)
+}
+
+// validate checks the settings shared by all argument types.
+//
+// Parameters:
+//   - missingValue: Whether the pointer or value given to the registering method is nil.
+//
+// Returns:
+//   - The problems found, or an empty slice when there are none.
+func (ab *ArgumentBuilder) validate(missingValue bool) []error {
+	errs := []error{}
+
+	if len(utils.StripLeftDashes(ab.shortName)) == 0 && len(utils.StripLeftDashes(ab.longName)) == 0 {
+		errs = append(errs, fmt.Errorf("an argument needs a short or a long name"))
+	}
+	if len(ab.shortName) != 0 && len([]rune(utils.StripLeftDashes(ab.shortName))) != 1 {
+		errs = append(errs, fmt.Errorf("short name \"%s\" has to be a single character", ab.shortName))
+	}
+	if missingValue {
+		errs = append(errs, fmt.Errorf("the value to store the argument in cannot be nil"))
+	}
+	for _, validator := range ab.validators {
+		if validator == nil {
+			errs = append(errs, fmt.Errorf("a validator cannot be nil"))
+			break
+		}
+	}
+	errs = appendIfError(errs, checkVisibility(ab.visibility, ab.required))
+
+	return errs
+}
+
+// register applies the settings that do not depend on the type of the argument and registers it in
+// its group, creating the group if needed.
+//
+// Parameters:
+//   - arg: The argument to register.
+//   - attributes: The attributes of the argument, which receive its metavar, environment variable,
+//     validators, visibility and aliases.
+//
+// Returns:
+//   - An error if the argument could not be registered, for example because its name is already used.
+func (ab *ArgumentBuilder) register(arg arguments.Argument, attributes *arguments.Attributes) error {
+	attributes.SetMetavar(ab.metavar)
+	attributes.SetEnvVar(ab.envVar)
+	attributes.SetVisibility(ab.visibility)
+	// The aliases are checked before the argument is registered, so that it is not registered when
+	// one of them cannot be added
+	errs := []error{}
+	for _, alias := range ab.aliases {
+		alias, err := ab.parser.prepareAlias(arg, alias)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		attributes.AddAlias(alias)
+	}
+	if len(errs) != 0 {
+		return ab.wrapErrors(errs)
+	}
+	for _, validator := range ab.validators {
+		attributes.AddValidator(validator)
+	}
+
+	var err error
+	if len(ab.groupName) == 0 {
+		err = ab.parser.Register(arg)
+	} else {
+		var group *argumentgroup.ArgumentGroup
+		if group = ab.parser.Groups[ab.groupName]; group == nil {
+			group, err = ab.parser.NewArgumentGroup(ab.groupName)
+		}
+		if err == nil {
+			err = group.Register(arg)
+		}
+	}
+	if err != nil {
+		return ab.wrapErrors([]error{err})
+	}
+
+	return nil
+}
+
+// wrapErrors combines the problems found in the declaration into a single error naming the argument.
+func (ab *ArgumentBuilder) wrapErrors(errs []error) error {
+	name := ab.longName
+	if len(name) == 0 {
+		name = ab.shortName
+	}
+
+	return fmt.Errorf("argument \"%s\": %w", name, errors.Join(errs...))
+}
+
+// builderDefault returns the default value of the argument declared by the builder converted to the
+// type of the argument, or fallback when no default value was set.
+//
+// Parameters:
+//   - ab: The builder holding the default value.
+//   - fallback: The value returned when no default value was set.
+//
+// Returns:
+//   - The default value, and an error if it does not have the type of the argument.
+func builderDefault[T any](ab *ArgumentBuilder, fallback T) (T, error) {
+	if !ab.hasDefault {
+		return fallback, nil
+	}
+
+	value, ok := ab.defaultValue.(T)
+	if !ok {
+		return fallback, fmt.Errorf("default value %#v has type %T, expected %T", ab.defaultValue, ab.defaultValue, fallback)
+	}
+
+	return value, nil
+}
+
+// appendIfError appends err to errs if it is not nil.
+func appendIfError(errs []error, err error) []error {
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// PositionalBuilder declares a positional argument through chained calls. It is created by
+// ArgumentsParser.Positional and the positional argument is registered by the method naming its type:
+//
+//	err := ap.Positional("target").Help("Target host.").String(&target)
+type PositionalBuilder struct {
+	parser *ArgumentsParser
+
+	name string
+	help string
+
+	choices         []string
+	caseInsensitive bool
+}
+
+// Positional starts the declaration of a positional argument with the given name.
+//
+// Parameters:
+// - name: The name of the positional argument, displayed in the usage message.
+//
+// Returns:
+// - A PositionalBuilder to configure and register the positional argument.
+func (ap *ArgumentsParser) Positional(name string) *PositionalBuilder {
+	return &PositionalBuilder{
+		parser: ap,
+		name:   name,
+	}
+}
+
+// Help sets the description of the positional argument displayed in the help message.
+func (pb *PositionalBuilder) Help(help string) *PositionalBuilder {
+	pb.help = help
+	return pb
+}
+
+// Choices sets the list of values accepted by the positional argument, for Enum.
+func (pb *PositionalBuilder) Choices(choices ...string) *PositionalBuilder {
+	pb.choices = choices
+	return pb
+}
+
+// CaseInsensitive makes the value given by the user match a choice regardless of its case, for Enum.
+func (pb *PositionalBuilder) CaseInsensitive() *PositionalBuilder {
+	pb.caseInsensitive = true
+	return pb
+}
+
+// Enum registers the positional argument as an EnumPositionalArgument storing in ptr one of the
+// values set with Choices.
+func (pb *PositionalBuilder) Enum(ptr *string) error {
+	if err := arguments.CheckChoices(pb.choices); err != nil {
+		return fmt.Errorf("positional argument <%s>: %w", pb.name, err)
+	}
+
+	arg := &positionals.EnumPositionalArgument[string]{}
+	arg.Init(ptr, pb.name, pb.choices, pb.caseInsensitive, pb.help)
+	return pb.register(arg, ptr == nil)
+}
+
+// String registers the positional argument as a StringPositionalArgument storing its value in ptr.
+func (pb *PositionalBuilder) String(ptr *string) error {
+	arg := &positionals.StringPositionalArgument{}
+	arg.Init(ptr, pb.name, pb.help)
+	return pb.register(arg, ptr == nil)
+}
+
+// Int registers the positional argument as an IntPositionalArgument storing its value in ptr.
+func (pb *PositionalBuilder) Int(ptr *int) error {
+	arg := &positionals.IntPositionalArgument{}
+	arg.Init(ptr, pb.name, pb.help)
+	return pb.register(arg, ptr == nil)
+}
+
+// Bool registers the positional argument as a BoolPositionalArgument storing its value in ptr.
+func (pb *PositionalBuilder) Bool(ptr *bool) error {
+	arg := &positionals.BoolPositionalArgument{}
+	arg.Init(ptr, pb.name, pb.help)
+	return pb.register(arg, ptr == nil)
+}
+
+// FlagValue registers the positional argument as a FlagValuePositionalArgument handled by value.
+func (pb *PositionalBuilder) FlagValue(value flag.Value) error {
+	arg := &positionals.FlagValuePositionalArgument{}
+	arg.Init(value, pb.name, pb.help)
+	return pb.register(arg, value == nil)
+}
+
+// TextUnmarshaler registers the positional argument as a TextUnmarshalerPositionalArgument parsed by value.
+func (pb *PositionalBuilder) TextUnmarshaler(value encoding.TextUnmarshaler) error {
+	arg := &positionals.TextUnmarshalerPositionalArgument{}
+	arg.Init(value, pb.name, pb.help)
+	return pb.register(arg, value == nil)
+}
+
+// register validates the declaration and registers the positional argument with the parser.
+//
+// Parameters:
+//   - arg: The positional argument to register.
+//   - missingValue: Whether the pointer or value given to the registering method is nil.
+//
+// Returns:
+//   - A single error naming the positional argument and listing every problem found, or nil.
+func (pb *PositionalBuilder) register(arg positionals.PositionalArgument, missingValue bool) error {
+	errs := []error{}
+
+	if len(pb.name) == 0 {
+		errs = append(errs, fmt.Errorf("a positional argument needs a name"))
+	}
+	if missingValue {
+		errs = append(errs, fmt.Errorf("the value to store the positional argument in cannot be nil"))
+	}
+	if pb.parser.SubParsers.Enabled {
+		errs = append(errs, fmt.Errorf("positional arguments are not parsed on a parser with subparsers"))
+	}
+	if len(errs) == 0 {
+		errs = appendIfError(errs, pb.parser.RegisterPositional(arg))
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("positional argument <%s>: %w", pb.name, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// SubParserBuilder declares a subparser through chained calls. It is created by
+// ArgumentsParser.SubParser and the subparser is registered by Build:
+//
+//	add, err := ap.SubParser("add").Banner("Add a new entry.").Bind(&mode).Build()
+type SubParserBuilder struct {
+	parser *ArgumentsParser
+
+	name            string
+	banner          string
+	summary         string
+	description     string
+	aliases         []string
+	value           *string
+	caseInsensitive bool
+}
+
+// SubParser starts the declaration of a subparser with the given name.
+//
+// Parameters:
+// - name: The name of the subparser, given on the command line to select it.
+//
+// Returns:
+// - A SubParserBuilder to configure and register the subparser.
+func (ap *ArgumentsParser) SubParser(name string) *SubParserBuilder {
+	return &SubParserBuilder{
+		parser: ap,
+		name:   name,
+	}
+}
+
+// Banner sets the banner of the subparser, also displayed next to its name in the usage message of
+// its parent.
+func (sb *SubParserBuilder) Banner(banner string) *SubParserBuilder {
+	sb.banner = banner
+	return sb
+}
+
+// Summary sets the one-line description of the subparser, displayed next to its name in the usage
+// message of its parent instead of its banner.
+func (sb *SubParserBuilder) Summary(summary string) *SubParserBuilder {
+	sb.summary = summary
+	return sb
+}
+
+// Description sets the long description of the subparser, displayed under its usage line.
+func (sb *SubParserBuilder) Description(description string) *SubParserBuilder {
+	sb.description = description
+	return sb
+}
+
+// Alias adds an additional name selecting the subparser, such as "ls" for "list". It can be called
+// several times.
+func (sb *SubParserBuilder) Alias(alias string) *SubParserBuilder {
+	sb.aliases = append(sb.aliases, alias)
+	return sb
+}
+
+// Bind sets the pointer receiving the name of the subparser selected on the command line. It is
+// shared by all the subparsers of a parser.
+func (sb *SubParserBuilder) Bind(value *string) *SubParserBuilder {
+	sb.value = value
+	return sb
+}
+
+// CaseInsensitive makes the names of the subparsers of the parser match regardless of their case.
+// It has to be set before the first subparser of the parser is registered.
+func (sb *SubParserBuilder) CaseInsensitive() *SubParserBuilder {
+	sb.caseInsensitive = true
+	return sb
+}
+
+// Build validates the declaration and registers the subparser.
+//
+// Returns:
+// - The new subparser, on which its own arguments can then be declared.
+// - A single error naming the subparser and listing every problem found, or nil.
+func (sb *SubParserBuilder) Build() (*ArgumentsParser, error) {
+	subParsers := &sb.parser.SubParsers
+	errs := []error{}
+
+	if len(sb.name) == 0 {
+		errs = append(errs, fmt.Errorf("a subparser needs a name"))
+	}
+	if sb.caseInsensitive && !subParsers.CaseInsensitive && len(subParsers.Parsers) != 0 {
+		errs = append(errs, fmt.Errorf("case insensitivity has to be set before the first subparser is registered"))
+	}
+	if sb.value != nil && subParsers.Value != nil && subParsers.Value != sb.value {
+		errs = append(errs, fmt.Errorf("the subparsers of a parser are already bound to another value"))
+	}
+	if len(sb.parser.PositionalArguments) != 0 {
+		errs = append(errs, fmt.Errorf("positional arguments of the parent parser would not be parsed"))
+	}
+	if sb.caseInsensitive || subParsers.CaseInsensitive {
+		if subParsers.GetSubParser(sb.name) != nil {
+			errs = append(errs, fmt.Errorf("a subparser with this name already exists"))
+		}
+	} else if _, exists := subParsers.Parsers[sb.name]; exists {
+		errs = append(errs, fmt.Errorf("a subparser with this name already exists"))
+	}
+	for _, alias := range sb.aliases {
+		if len(alias) == 0 {
+			errs = append(errs, fmt.Errorf("an alias cannot be empty"))
+		} else if subParsers.GetSubParser(alias) != nil || alias == sb.name || (sb.caseInsensitive || subParsers.CaseInsensitive) && strings.EqualFold(alias, sb.name) {
+			errs = append(errs, fmt.Errorf("alias \"%s\" already selects a subparser", alias))
+		}
+	}
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("subparser \"%s\": %w", sb.name, errors.Join(errs...))
+	}
+
+	if sb.caseInsensitive {
+		subParsers.CaseInsensitive = true
+	}
+	if sb.value != nil {
+		subParsers.Value = sb.value
+	}
+
+	subparser := sb.parser.AddSubParser(sb.name, sb.banner)
+	subparser.SetSummary(sb.summary)
+	subparser.SetDescription(sb.description)
+	for _, alias := range sb.aliases {
+		if err := sb.parser.AddSubParserAlias(sb.name, alias); err != nil {
+			return nil, fmt.Errorf("subparser \"%s\": %w", sb.name, err)
+		}
+	}
+
+	return subparser, nil
+}
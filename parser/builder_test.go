@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBuilderRegistersArgumentInGroup verifies that a chained declaration registers an argument with
+// all its settings, creating the group it names, and that the value is parsed into the pointer.
+func TestBuilderRegistersArgumentInGroup(t *testing.T) {
+	var port int
+	ap := NewParser("test")
+
+	err := ap.Flag("--port").Short("-p").Help("Port to listen on.").Default(8080).Metavar("PORT").Group("Server").TcpPort(&port)
+	if err != nil {
+		t.Fatalf("TcpPort failed: %v", err)
+	}
+
+	group, exists := ap.Groups["Server"]
+	if !exists || len(group.Arguments) != 1 {
+		t.Fatalf("expected the argument to be registered in the \"Server\" group")
+	}
+	arg := group.Arguments[0]
+	if arg.GetShortName() != "-p" || arg.GetLongName() != "--port" {
+		t.Errorf("expected names \"-p\" and \"--port\", got %q and %q", arg.GetShortName(), arg.GetLongName())
+	}
+	if got := generateArgumentForUsageLine(arg); got != "[--port <PORT>]" {
+		t.Errorf("expected usage line entry \"[--port <PORT>]\", got %q", got)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "-p", "443"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if port != 443 {
+		t.Errorf("expected port to be 443, got %d", port)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if port != 8080 {
+		t.Errorf("expected port to be reset to its default value 8080, got %d", port)
+	}
+}
+
+// TestBuilderReadsEnvironmentVariable verifies that an argument declared with Env takes its value
+// from the environment variable when it is not given, and that the command line takes precedence.
+func TestBuilderReadsEnvironmentVariable(t *testing.T) {
+	var user string
+	var debug bool
+	ap := NewParser("test")
+
+	if err := ap.Flag("--user").Env("GOOPTS_TEST_USER").Required().String(&user); err != nil {
+		t.Fatalf("String failed: %v", err)
+	}
+	if err := ap.Flag("--debug").Env("GOOPTS_TEST_DEBUG").Bool(&debug); err != nil {
+		t.Fatalf("Bool failed: %v", err)
+	}
+	t.Setenv("GOOPTS_TEST_USER", "alice")
+	t.Setenv("GOOPTS_TEST_DEBUG", "true")
+
+	ap.ParsingState.SetRawArguments([]string{"test"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if user != "alice" {
+		t.Errorf("expected user to be read from the environment, got %q", user)
+	}
+	if !debug {
+		t.Errorf("expected debug to be enabled from the environment")
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "--user", "bob"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if user != "bob" {
+		t.Errorf("expected the command line to take precedence over the environment, got %q", user)
+	}
+}
+
+// TestBuilderReportsAllProblemsInOneError verifies that every problem of a declaration is reported
+// in the single error returned, and that nothing is registered.
+func TestBuilderReportsAllProblemsInOneError(t *testing.T) {
+	var level int
+	ap := NewParser("test")
+
+	err := ap.Flag("--level").Short("-lv").Default("high").Required().IntRange(&level, 1, 5)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid declaration")
+	}
+	for _, expected := range []string{"--level", "single character", "expected int"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error to mention %q, got %q", expected, err)
+		}
+	}
+	if ap.findArgument("--level") != nil {
+		t.Errorf("expected the invalid argument not to be registered")
+	}
+
+	if err := ap.Flag("--level").Default(9).IntRange(&level, 1, 5); err == nil {
+		t.Errorf("expected an error for a default value out of range")
+	}
+	if err := ap.Flag("--level").Default(3).IntRange(&level, 1, 5); err != nil {
+		t.Errorf("expected a valid declaration to succeed, got %v", err)
+	}
+	if err := ap.Flag("--level").Int(&level); err == nil {
+		t.Errorf("expected an error for a duplicate argument")
+	}
+	if err := ap.Flag("--retries").Required().Default(3).IntRange(&level, 1, 5); err != nil {
+		t.Errorf("expected a required argument with a default value to be accepted, got %v", err)
+	}
+}
+
+// TestBuilderPositionalsAndSubParsers verifies the declaration of positional arguments and
+// subparsers, including the detection of duplicate subparser names.
+func TestBuilderPositionalsAndSubParsers(t *testing.T) {
+	var mode, target string
+	ap := NewParser("test")
+
+	add, err := ap.SubParser("add").Banner("Add mode.").Bind(&mode).CaseInsensitive().Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if err := add.Positional("target").Help("Target host.").String(&target); err != nil {
+		t.Fatalf("String failed: %v", err)
+	}
+	if _, err := ap.SubParser("ADD").Build(); err == nil {
+		t.Errorf("expected an error for a duplicate subparser name")
+	}
+	if err := ap.Positional("extra").String(&target); err == nil {
+		t.Errorf("expected an error for a positional argument on a parser with subparsers")
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "Add", "example.com"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if mode != "add" || target != "example.com" {
+		t.Errorf("expected mode \"add\" and target \"example.com\", got %q and %q", mode, target)
+	}
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// newCommandTreeParser returns a parser with nested subparsers, "remote add" and "remote remove",
+// "remove" being also selected by "rm".
+func newCommandTreeParser(mode, remoteMode *string) *ArgumentsParser {
+	var name, url string
+	var verbose bool
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetupSubParsing("mode", mode, false)
+	ap.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose output.")
+	remote := ap.AddSubParser("remote", "Manage the remotes")
+	remote.SetupSubParsing("remoteMode", remoteMode, false)
+	add := remote.AddSubParser("add", "Add a remote")
+	add.NewStringPositionalArgument(&url, "url", "URL of the remote.")
+	add.NewStringArgument(&name, "-n", "--name", "origin", false, "Name of the remote.")
+	remote.AddSubParser("remove", "Remove a remote")
+	remote.AddSubParserAlias("remove", "rm")
+	ap.AddSubParser("status", "Show the status")
+	return ap
+}
+
+// TestSubParserAlias verifies that an alias selects its subparser and stores the name of the subparser.
+func TestSubParserAlias(t *testing.T) {
+	var mode, remoteMode string
+	ap := newCommandTreeParser(&mode, &remoteMode)
+	ap.ParsingState.SetRawArguments([]string{"tool", "remote", "rm"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if mode != "remote" || remoteMode != "remove" {
+		t.Errorf("expected the subparsers \"remote\" and \"remove\" to be selected, got %q and %q", mode, remoteMode)
+	}
+
+	remote := ap.SubParsers.GetSubParser("remote")
+	if err := remote.AddSubParserAlias("add", "rm"); err == nil {
+		t.Errorf("expected an error when an alias already selects a subparser")
+	}
+	if err := remote.AddSubParserAlias("missing", "m"); err == nil {
+		t.Errorf("expected an error when adding an alias to a missing subparser")
+	}
+	if _, err := ap.SubParser("config").Alias("status").Build(); err == nil {
+		t.Errorf("expected an error when the alias of a new subparser already selects a subparser")
+	}
+	if _, err := ap.SubParser("config").Alias("cfg").Build(); err != nil || ap.SubParsers.GetSubParser("cfg") == nil {
+		t.Errorf("expected the alias of the new subparser to select it, got %v", err)
+	}
+}
+
+// TestHelpSubcommands verifies that the subparsers of the whole tree are listed with the command tree option.
+func TestHelpSubcommands(t *testing.T) {
+	var mode, remoteMode string
+	ap := newCommandTreeParser(&mode, &remoteMode)
+
+	format := func(subcommands []HelpSubcommand) []string {
+		lines := []string{}
+		for _, subcommand := range subcommands {
+			line := strings.Repeat("  ", subcommand.Depth) + strings.Join(append([]string{subcommand.Name}, subcommand.Aliases...), ", ")
+			lines = append(lines, line)
+		}
+		return lines
+	}
+
+	if got := strings.Join(format(ap.helpSubcommands([]string{}, false)), "\n"); got != "remote\nstatus" {
+		t.Errorf("expected the subparsers of the parser only, got:\n%s", got)
+	}
+	if got := strings.Join(format(ap.helpSubcommands([]string{}, true)), "\n"); got != "remote\n  add\n  remove, rm\nstatus" {
+		t.Errorf("expected the whole tree of subparsers, got:\n%s", got)
+	}
+}
+
+// TestArgumentTree verifies that the argument tree describes the positionals, arguments and subparsers.
+func TestArgumentTree(t *testing.T) {
+	var mode, remoteMode string
+	ap := newCommandTreeParser(&mode, &remoteMode)
+
+	tree := ap.ArgumentTree()
+	for _, expected := range []string{
+		"<ArgumentsParser>\n├─ Banner: \"Tool\"\n",
+		"│  └─ (\"-v\",\"--verbose\") [bool] \"Verbose output.\" (default: false)\n",
+		"└─ SubParsers (2)\n",
+		"<SubParser name=\"remove\" aliases=\"rm\">",
+		"│     │  ├─ Positionals (1)\n",
+		"<url> [string] \"URL of the remote.\"",
+		"(\"-n\",\"--name\") [string] \"Name of the remote.\" (default: \"origin\")",
+	} {
+		if !strings.Contains(tree, expected) {
+			t.Errorf("expected the argument tree to contain %q, got:\n%s", expected, tree)
+		}
+	}
+}
+
+// TestCommandTreeSubprocess is the body of the subprocesses started by TestCommandTree, and does
+// nothing when run directly.
+func TestCommandTreeSubprocess(t *testing.T) {
+	if len(os.Getenv("GOOPTS_COMMAND_TREE_SUBPROCESS")) == 0 {
+		return
+	}
+
+	var mode, remoteMode string
+	ap := newCommandTreeParser(&mode, &remoteMode)
+	ap.SetOptShowCommandTree(true)
+	ap.SetOptHelpWidth(80)
+	ap.ParsingState.SetRawArguments([]string{"tool", "--help"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// TestCommandTree verifies the usage message of a parser listing its subparsers recursively.
+func TestCommandTree(t *testing.T) {
+	out, code := runTestSubprocess(t, "TestCommandTreeSubprocess", "GOOPTS_COMMAND_TREE_SUBPROCESS=1")
+	if code != 0 {
+		t.Fatalf("expected the help to exit with code 0, got %d:\n%s", code, out)
+	}
+
+	for _, expected := range []string{
+		"   remote        Manage the remotes\n",
+		"     add         Add a remote\n",
+		"     remove, rm  Remove a remote\n",
+		"   status        Show the status\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected the usage message to contain %q, got:\n%s", expected, out)
+		}
+	}
+}
+
+// TestCommandTreeInMarkdown verifies that the Markdown documentation lists the nested subparsers of a
+// parser displaying its command tree with their full path and aliases.
+func TestCommandTreeInMarkdown(t *testing.T) {
+	var mode, remoteMode string
+	ap := newCommandTreeParser(&mode, &remoteMode)
+	ap.SetOptShowCommandTree(true)
+
+	got := ap.GenerateMarkdown("tool")
+	expected := "| `remote` | Manage the remotes |\n" +
+		"| `remote add` | Add a remote |\n" +
+		"| `remote remove`, `remote rm` | Remove a remote |\n" +
+		"| `status` | Show the status |\n"
+	if !strings.Contains(got, expected) {
+		t.Errorf("expected the Markdown documentation to contain:\n%s\ngot:\n%s", expected, got)
+	}
+}
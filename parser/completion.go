@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+)
+
+// Complete returns the candidates completing the last word of a command line, to be given to the
+// completion of a shell. The words before it select the subparsers and decide what is completed:
+//   - The value of an argument, after a flag expecting one or in "--flag=value", is completed with its
+//     choices, see arguments.ArgumentMetadata.
+//   - A word starting with a dash is completed with the flags of the arguments that are not hidden,
+//     their aliases that are not deprecated, and the help, help-all and version flags.
+//   - The first word given to a parser with subparsers is completed with their names and aliases.
+//   - Any other word before the first flag is completed with the choices of the positional argument it
+//     is given to, see positionals.ChoicesArgument. Like when parsing, no positional argument is
+//     completed after a flag.
+//
+// Parameters:
+//   - words: The words of the command line after the name of the program, the last one being the word
+//     completed, which is empty when a new word is started.
+//
+// Returns:
+//   - The candidates starting with the word completed, sorted alphabetically, or an empty slice when
+//     there are none.
+func (ap *ArgumentsParser) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+
+	// The subparsers selected are kept in a chain instead of being linked to their parent parser,
+	// which only happens when they parse, so completing leaves the tree of parsers untouched
+	chain := []*ArgumentsParser{ap}
+	positionalIndex := 0
+	sawFlag := false
+	var pending arguments.Argument
+	for _, word := range words[:len(words)-1] {
+		parser := chain[len(chain)-1]
+		if pending != nil {
+			pending = nil
+			continue
+		}
+		if parser.SubParsers.Enabled {
+			if subparser := parser.SubParsers.GetSubParser(word); subparser != nil {
+				chain = append(chain, subparser)
+				positionalIndex = 0
+				sawFlag = false
+			}
+			continue
+		}
+		if strings.HasPrefix(word, "-") {
+			sawFlag = true
+			if arg := parser.findArgument(word); !strings.Contains(word, "=") && completionExpectsValue(arg) {
+				pending = arg
+			}
+			continue
+		}
+		if !sawFlag {
+			positionalIndex++
+		}
+	}
+	parser := chain[len(chain)-1]
+
+	candidates := []string{}
+	switch {
+	case pending != nil:
+		candidates = completionChoices(pending)
+	case strings.HasPrefix(current, "-") && strings.Contains(current, "="):
+		flag, _, _ := strings.Cut(current, "=")
+		if arg := parser.findArgument(flag); completionExpectsValue(arg) {
+			for _, choice := range completionChoices(arg) {
+				candidates = append(candidates, flag+"="+choice)
+			}
+		}
+	case strings.HasPrefix(current, "-"):
+		candidates = completionFlags(chain)
+	case parser.SubParsers.Enabled:
+		for name := range parser.SubParsers.Parsers {
+			candidates = append(candidates, name)
+			candidates = append(candidates, parser.SubParsers.AliasesOf(name)...)
+		}
+	case !sawFlag && positionalIndex < len(parser.PositionalArguments):
+		if choicesArgument, ok := parser.PositionalArguments[positionalIndex].(positionals.ChoicesArgument); ok {
+			candidates = append(candidates, choicesArgument.GetChoices()...)
+		}
+	}
+
+	completions := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, current) {
+			completions = append(completions, candidate)
+		}
+	}
+	slices.Sort(completions)
+
+	return slices.Compact(completions)
+}
+
+// completionFlags returns the flags completing a word starting with a dash: the short and long names of
+// the arguments of the last parser of a chain, their aliases that are not deprecated, and the help,
+// help-all and version flags. Like in the generated documentation, the advanced arguments are completed
+// but the hidden ones are not, even though they are still parsed.
+//
+// Parameters:
+//   - chain: The parsers selected by the command line, from the parser completing it to the last
+//     subparser selected.
+//
+// Returns:
+//   - The flags of the parser, in no particular order.
+func completionFlags(chain []*ArgumentsParser) []string {
+	ap := chain[len(chain)-1]
+
+	flags := []string{}
+	groups := ap.visibleGroups(arguments.VISIBILITY_ADVANCED)
+	for _, groupName := range ap.sortedGroupNames() {
+		if groups[groupName] == nil {
+			continue
+		}
+		for _, arg := range groups[groupName].AllArguments() {
+			for _, name := range append([]string{arg.GetShortName(), arg.GetLongName()}, displayedAliases(arg)...) {
+				if len(name) != 0 {
+					flags = append(flags, name)
+				}
+			}
+		}
+	}
+	flags = append(flags, completionInheritedFlags(chain, (*ArgumentsParser).helpFlags, func(parser *ArgumentsParser) bool {
+		return parser.Options.HelpFlags != nil
+	})...)
+	flags = append(flags, completionInheritedFlags(chain, (*ArgumentsParser).helpAllFlags, func(parser *ArgumentsParser) bool {
+		return parser.Options.HelpAllFlags != nil
+	})...)
+	flags = append(flags, completionInheritedFlags(chain, (*ArgumentsParser).versionFlags, func(parser *ArgumentsParser) bool {
+		return parser.Options.VersionEnabled
+	})...)
+
+	return flags
+}
+
+// completionInheritedFlags returns flags that a subparser inherits from its parent parsers, resolved
+// along a chain of parsers instead of through the parent parsers, which are only linked when parsing.
+//
+// Parameters:
+//   - chain: The parsers selected by the command line, from the parser completing it to the last
+//     subparser selected.
+//   - flagsOf: The method returning the flags of a parser.
+//   - setsFlags: Reports whether a parser sets the flags itself rather than inheriting them.
+//
+// Returns:
+//   - The flags of the closest parser of the chain setting them, or else the ones of the first parser.
+func completionInheritedFlags(chain []*ArgumentsParser, flagsOf func(*ArgumentsParser) []string, setsFlags func(*ArgumentsParser) bool) []string {
+	for index := len(chain) - 1; index > 0; index-- {
+		if setsFlags(chain[index]) {
+			return flagsOf(chain[index])
+		}
+	}
+
+	return flagsOf(chain[0])
+}
+
+// completionExpectsValue reports whether an argument is followed by a value, which is completed with
+// its choices.
+//
+// Parameters:
+//   - arg: The argument, or nil when the flag does not name one.
+//
+// Returns:
+//   - true if the argument expects a value, false otherwise.
+func completionExpectsValue(arg arguments.Argument) bool {
+	metadata, ok := arg.(arguments.ArgumentMetadata)
+	return ok && metadata.ExpectsValue()
+}
+
+// completionChoices returns the values completing the value of an argument.
+//
+// Parameters:
+//   - arg: The argument whose value is completed.
+//
+// Returns:
+//   - The choices of the argument, or nil when it accepts any value.
+func completionChoices(arg arguments.Argument) []string {
+	if metadata, ok := arg.(arguments.ArgumentMetadata); ok {
+		return metadata.GetChoices()
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+// newCompletionParser returns a parser with subparsers, enum arguments and an enum positional argument.
+func newCompletionParser() *ArgumentsParser {
+	var mode, format, target, action, host string
+	var verbose bool
+	ap := NewParser("Tool")
+	ap.SetupSubParsing("mode", &mode, false)
+	scan := ap.AddSubParser("scan", "Scan a target")
+	ap.AddSubParserAlias("scan", "sc")
+	ap.AddSubParser("status", "Show the status")
+	scan.NewEnumPositionalArgument(&target, "target", []string{"local", "remote"}, false, "Target.")
+	scan.NewEnumPositionalArgument(&action, "action", []string{"ping", "probe"}, false, "Action.")
+	scan.NewEnumArgument(&format, "-f", "--format", "json", []string{"json", "table", "text"}, false, false, "Output format.")
+	scan.NewStringArgument(&host, "", "--host", "", false, "Host.")
+	scan.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose output.")
+	scan.AddArgumentAlias("--verbose", "--loud")
+	scan.AddDeprecatedArgumentAlias("--host", "--server", "use --host instead")
+	scan.Flag("--debug").Hidden().Bool(&verbose)
+	scan.Flag("--trace").Advanced().Bool(&verbose)
+	return ap
+}
+
+// TestComplete verifies the candidates completing the subparsers, the flags that are not hidden, the
+// choices of the arguments and the choices of the positional arguments.
+func TestComplete(t *testing.T) {
+	ap := newCompletionParser()
+
+	tests := []struct {
+		words    []string
+		expected []string
+	}{
+		{[]string{}, []string{"sc", "scan", "status"}},
+		{[]string{"s"}, []string{"sc", "scan", "status"}},
+		{[]string{"st"}, []string{"status"}},
+		{[]string{"scan", "--"}, []string{"--format", "--help", "--help-all", "--host", "--loud", "--trace", "--verbose"}},
+		{[]string{"scan", "--d"}, []string{}},
+		{[]string{"scan", "--format", ""}, []string{"json", "table", "text"}},
+		{[]string{"scan", "-f", "t"}, []string{"table", "text"}},
+		{[]string{"scan", "--format=t"}, []string{"--format=table", "--format=text"}},
+		{[]string{"scan", "--host", ""}, []string{}},
+		{[]string{"sc", ""}, []string{"local", "remote"}},
+		{[]string{"scan", "local", "p"}, []string{"ping", "probe"}},
+		{[]string{"scan", "-v", "--format", "json", "remote", "p"}, []string{}},
+		{[]string{"scan", "local", "ping", ""}, []string{}},
+	}
+	for _, test := range tests {
+		if got := ap.Complete(test.words); !slices.Equal(got, test.expected) {
+			t.Errorf("expected the completions of %q to be %q, got %q", test.words, test.expected, got)
+		}
+	}
+}
+
+// TestCompleteInheritedFlags verifies that the subparsers are completed with the help and version flags
+// of their parent parsers, without being linked to them.
+func TestCompleteInheritedFlags(t *testing.T) {
+	var mode, submode string
+	ap := NewParser("Tool")
+	ap.SetHelpFlags("-?", "--usage")
+	ap.SetVersion("1.0.0")
+	ap.SetupSubParsing("mode", &mode, false)
+	remote := ap.AddSubParser("remote", "Manage the remotes")
+	remote.SetupSubParsing("submode", &submode, false)
+	add := remote.SubParsers.AddSubParser("add", "Add a remote")
+
+	expected := []string{"--help-all", "--usage", "--version"}
+	if got := ap.Complete([]string{"remote", "add", "--"}); !slices.Equal(got, expected) {
+		t.Errorf("expected the completions to be %q, got %q", expected, got)
+	}
+	if add.parent != nil {
+		t.Errorf("expected the subparser not to be linked to its parent parser by the completion")
+	}
+}
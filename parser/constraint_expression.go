@@ -0,0 +1,336 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// constraint is a boolean expression over the arguments of a parser, checked by ParseFrom after the
+// rules between arguments.
+//
+// Fields:
+//   - Expression: The expression as written by the author of the parser, displayed when it fails.
+//   - Message: The message explaining the constraint, displayed when it fails.
+//   - Root: The parsed expression.
+type constraint struct {
+	Expression string
+	Message    string
+	Root       constraintNode
+}
+
+// constraintNode is a node of a parsed constraint expression.
+type constraintNode interface {
+	// evaluate returns the value of the node for the arguments as they were parsed.
+	evaluate() bool
+}
+
+// presenceNode is true when its argument was set.
+type presenceNode struct {
+	argument arguments.Argument
+}
+
+func (n presenceNode) evaluate() bool {
+	return n.argument.IsPresent()
+}
+
+// comparisonNode compares the value of an argument, with its default formatting, to a value. The
+// default value of the argument is compared as well when it was not set.
+type comparisonNode struct {
+	argument arguments.Argument
+	value    string
+	equal    bool
+}
+
+func (n comparisonNode) evaluate() bool {
+	return (fmt.Sprint(n.argument.GetValue()) == n.value) == n.equal
+}
+
+// notNode negates its operand.
+type notNode struct {
+	operand constraintNode
+}
+
+func (n notNode) evaluate() bool {
+	return !n.operand.evaluate()
+}
+
+// andNode is true when both of its operands are true.
+type andNode struct {
+	left, right constraintNode
+}
+
+func (n andNode) evaluate() bool {
+	return n.left.evaluate() && n.right.evaluate()
+}
+
+// orNode is true when at least one of its operands is true.
+type orNode struct {
+	left, right constraintNode
+}
+
+func (n orNode) evaluate() bool {
+	return n.left.evaluate() || n.right.evaluate()
+}
+
+// impliesNode is true when its condition is false, or when both its condition and its consequence are true.
+type impliesNode struct {
+	condition, consequence constraintNode
+}
+
+func (n impliesNode) evaluate() bool {
+	return !n.condition.evaluate() || n.consequence.evaluate()
+}
+
+// AddConstraint adds a boolean expression over the arguments of the parser that has to be true once
+// the arguments are parsed, such as "--kerberos && !--password -> --aes-key || --ccache".
+//
+// An argument is referred to by its short or long name, and is true when it was set. It can also be
+// compared to a value with "==" and "!=", as in "--proto == tcp" or "--mode != 'read only'", in which
+// case its value is compared with its default formatting. From the highest to the lowest precedence,
+// the operators are "!", "&&", "||" and "->", the latter being right associative, and parentheses
+// group sub-expressions.
+//
+// The expression is parsed when it is added, so the arguments it refers to need to be registered first.
+//
+// Parameters:
+//   - expression: The constraint expression.
+//   - message: The message reported, followed by the expression, when the constraint is not satisfied.
+//
+// Returns:
+//   - An error if the expression is malformed or refers to an argument that is not registered.
+func (ap *ArgumentsParser) AddConstraint(expression string, message string) error {
+	tokens, err := tokenizeConstraint(expression)
+	if err != nil {
+		return fmt.Errorf("invalid constraint \"%s\": %w", expression, err)
+	}
+
+	cp := constraintParser{parser: ap, tokens: tokens}
+	root, err := cp.parseImplication()
+	if err == nil && cp.position < len(cp.tokens) {
+		err = fmt.Errorf("unexpected \"%s\"", cp.tokens[cp.position].text)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid constraint \"%s\": %w", expression, err)
+	}
+
+	ap.constraints = append(ap.constraints, constraint{Expression: expression, Message: message, Root: root})
+
+	return nil
+}
+
+// checkConstraints evaluates the constraints of the parser in the order they were added, and records
+// an error message for each constraint that is not satisfied.
+//
+// Parameters:
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) checkConstraints(parsingState *ParsingState) {
+	for _, c := range ap.constraints {
+		if !c.Root.evaluate() {
+			parsingState.AddError(ParsingError{Kind: ERROR_KIND_CONSTRAINT, Message: ap.message(MESSAGE_FAILED_CONSTRAINT, c.Message, c.Expression)})
+		}
+	}
+}
+
+// constraintTokenKind identifies the kind of a token of a constraint expression.
+type constraintTokenKind int
+
+const (
+	tokenOperator constraintTokenKind = iota
+	tokenArgument
+	tokenValue
+)
+
+// constraintToken is a token of a constraint expression.
+type constraintToken struct {
+	kind constraintTokenKind
+	text string
+}
+
+// tokenizeConstraint splits a constraint expression into operators, argument names and values.
+//
+// Parameters:
+//   - expression: The constraint expression.
+//
+// Returns:
+//   - The tokens of the expression, or an error if it contains an unterminated quoted value or an
+//     unexpected character.
+func tokenizeConstraint(expression string) ([]constraintToken, error) {
+	tokens := []constraintToken{}
+	runes := []rune(expression)
+
+	for k := 0; k < len(runes); {
+		r := runes[k]
+		switch {
+		case unicode.IsSpace(r):
+			k++
+		case strings.HasPrefix(string(runes[k:]), "->"),
+			strings.HasPrefix(string(runes[k:]), "&&"),
+			strings.HasPrefix(string(runes[k:]), "||"),
+			strings.HasPrefix(string(runes[k:]), "=="),
+			strings.HasPrefix(string(runes[k:]), "!="):
+			tokens = append(tokens, constraintToken{kind: tokenOperator, text: string(runes[k : k+2])})
+			k += 2
+		case r == '!' || r == '(' || r == ')':
+			tokens = append(tokens, constraintToken{kind: tokenOperator, text: string(r)})
+			k++
+		case r == '"' || r == '\'':
+			end := k + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value starting at offset %d", k)
+			}
+			tokens = append(tokens, constraintToken{kind: tokenValue, text: string(runes[k+1 : end])})
+			k = end + 1
+		case isConstraintWordRune(r):
+			end := k
+			for end < len(runes) && isConstraintWordRune(runes[end]) && !strings.HasPrefix(string(runes[end:]), "->") {
+				end++
+			}
+			if end == k {
+				return nil, fmt.Errorf("unexpected character '%c' at offset %d", r, k)
+			}
+			kind := tokenValue
+			if r == '-' {
+				kind = tokenArgument
+			}
+			tokens = append(tokens, constraintToken{kind: kind, text: string(runes[k:end])})
+			k = end
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' at offset %d", r, k)
+		}
+	}
+
+	return tokens, nil
+}
+
+// isConstraintWordRune reports whether a rune can be part of an argument name or of an unquoted value.
+func isConstraintWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./:@", r)
+}
+
+// constraintParser is a recursive descent parser of constraint expressions, resolving the argument
+// names against the arguments registered in a parser.
+type constraintParser struct {
+	parser   *ArgumentsParser
+	tokens   []constraintToken
+	position int
+}
+
+// accept consumes the next token if it is the given operator, and reports whether it did.
+func (cp *constraintParser) accept(operator string) bool {
+	if cp.position < len(cp.tokens) && cp.tokens[cp.position].kind == tokenOperator && cp.tokens[cp.position].text == operator {
+		cp.position++
+		return true
+	}
+
+	return false
+}
+
+// parseImplication parses "or ( -> implication )?".
+func (cp *constraintParser) parseImplication() (constraintNode, error) {
+	condition, err := cp.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if cp.accept("->") {
+		consequence, err := cp.parseImplication()
+		if err != nil {
+			return nil, err
+		}
+		return impliesNode{condition: condition, consequence: consequence}, nil
+	}
+
+	return condition, nil
+}
+
+// parseOr parses "and ( || and )*".
+func (cp *constraintParser) parseOr() (constraintNode, error) {
+	left, err := cp.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for cp.accept("||") {
+		right, err := cp.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses "unary ( && unary )*".
+func (cp *constraintParser) parseAnd() (constraintNode, error) {
+	left, err := cp.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for cp.accept("&&") {
+		right, err := cp.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses "! unary", "( implication )" or an argument, optionally compared to a value.
+func (cp *constraintParser) parseUnary() (constraintNode, error) {
+	if cp.accept("!") {
+		operand, err := cp.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+
+	if cp.accept("(") {
+		node, err := cp.parseImplication()
+		if err != nil {
+			return nil, err
+		}
+		if !cp.accept(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return node, nil
+	}
+
+	if cp.position >= len(cp.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	token := cp.tokens[cp.position]
+	if token.kind != tokenArgument {
+		return nil, fmt.Errorf("expected an argument, got \"%s\"", token.text)
+	}
+	cp.position++
+
+	arg := cp.parser.findArgument(token.text)
+	if arg == nil {
+		return nil, fmt.Errorf("argument '%s' not found", token.text)
+	}
+
+	for _, operator := range []string{"==", "!="} {
+		if cp.accept(operator) {
+			if cp.position >= len(cp.tokens) || cp.tokens[cp.position].kind == tokenOperator {
+				return nil, fmt.Errorf("expected a value after \"%s %s\"", token.text, operator)
+			}
+			value := cp.tokens[cp.position].text
+			cp.position++
+			return comparisonNode{argument: arg, value: value, equal: operator == "=="}, nil
+		}
+	}
+
+	return presenceNode{argument: arg}, nil
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// newConstraintParser returns a parser with the arguments used by the tests of this file.
+func newConstraintParser() *ArgumentsParser {
+	var password, aesKey, ccache, proto string
+	var kerberos bool
+	var port int
+	ap := NewParser("test")
+	ap.NewBoolArgument(&kerberos, "-k", "--kerberos", false, "Use Kerberos.")
+	ap.NewStringArgument(&password, "-p", "--password", "", false, "Password.")
+	ap.NewStringArgument(&aesKey, "", "--aes-key", "", false, "AES key.")
+	ap.NewStringArgument(&ccache, "", "--ccache", "", false, "Credential cache.")
+	ap.NewStringArgument(&proto, "", "--proto", "udp", false, "Protocol.")
+	ap.NewIntArgument(&port, "", "--port", 0, false, "Port.")
+
+	return ap
+}
+
+// TestConstraintExpressionEvaluation verifies the precedence and the evaluation of the operators of
+// constraint expressions.
+func TestConstraintExpressionEvaluation(t *testing.T) {
+	testCases := []struct {
+		expression   string
+		rawArguments []string
+		expected     bool
+	}{
+		{"--kerberos && !--password -> --aes-key || --ccache", []string{"test", "-k"}, false},
+		{"--kerberos && !--password -> --aes-key || --ccache", []string{"test", "-k", "--ccache", "a.ccache"}, true},
+		{"--kerberos && !--password -> --aes-key || --ccache", []string{"test", "-k", "-p", "secret"}, true},
+		{"!(--kerberos || --password)", []string{"test", "-p", "secret"}, false},
+		{"--proto == udp", []string{"test"}, true},
+		{"--proto == tcp -> --port != 0", []string{"test", "--proto", "tcp"}, false},
+		{"--proto=='tcp' -> --port != 0", []string{"test", "--proto", "tcp", "--port", "88"}, true},
+		{"--port == -1", []string{"test", "--port", "-1"}, true},
+		{"-k -> -p -> --ccache", []string{"test", "-k", "-p", "secret"}, false},
+	}
+
+	for _, tc := range testCases {
+		// The constraint is added after parsing, so that ParseFrom does not exit when it fails
+		ap := newConstraintParser()
+		ap.ParsingState.SetRawArguments(tc.rawArguments)
+		ap.ParseFrom(1, &ap.ParsingState)
+		if err := ap.AddConstraint(tc.expression, "Constraint failed."); err != nil {
+			t.Fatalf("AddConstraint(%q) failed: %v", tc.expression, err)
+		}
+
+		if got := ap.constraints[0].Root.evaluate(); got != tc.expected {
+			t.Errorf("expected %q to be %v for %v, got %v", tc.expression, tc.expected, tc.rawArguments, got)
+		}
+	}
+}
+
+// TestConstraintExpressionErrors verifies that malformed expressions, and expressions referring to
+// arguments that are not registered, are rejected when they are added.
+func TestConstraintExpressionErrors(t *testing.T) {
+	for _, expression := range []string{
+		"--kerberos &&",
+		"--kerberos --password",
+		"(--kerberos || --password",
+		"--unknown -> --kerberos",
+		"--proto == ",
+		"--proto == 'tcp",
+		"tcp == --proto",
+		"--kerberos & --password",
+	} {
+		ap := newConstraintParser()
+		if err := ap.AddConstraint(expression, "Constraint failed."); err == nil {
+			t.Errorf("expected an error for %q", expression)
+		}
+	}
+}
+
+// TestConstraintErrorsAreReported verifies that a constraint that is not satisfied is reported with
+// its message and its expression.
+func TestConstraintErrorsAreReported(t *testing.T) {
+	if os.Getenv("GOOPTS_CONSTRAINT_SUBPROCESS") == "1" {
+		ap := newConstraintParser()
+		if err := ap.AddConstraint("--kerberos && !--password -> --aes-key || --ccache", "Kerberos without a password needs an AES key or a credential cache."); err != nil {
+			os.Exit(2)
+		}
+		ap.ParsingState.SetRawArguments([]string{"test", "--kerberos"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestConstraintErrorsAreReported", "GOOPTS_CONSTRAINT_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d", code)
+	}
+
+	expected := "[!] Kerberos without a password needs an AES key or a credential cache. (failed constraint: --kerberos && !--password -> --aes-key || --ccache)"
+	if !strings.Contains(out, expected) {
+		t.Fatalf("expected output to contain %q, got:\n%s", expected, out)
+	}
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// Example is an example of invocation of the program, displayed in the usage message and in the
+// generated documentation.
+type Example struct {
+	// Command is the command line of the example, starting with the name of the program, such as
+	// "tool scan --port 445 10.0.0.1". Values containing spaces are quoted as in a shell.
+	Command string `json:"command"`
+	// Explanation describes what the command of the example does.
+	Explanation string `json:"explanation"`
+}
+
+// SetSummary sets the one-line description of the parser, displayed next to its name in the usage
+// message of its parent instead of its banner.
+//
+// Parameters:
+// - summary: The one-line description of the parser.
+func (ap *ArgumentsParser) SetSummary(summary string) {
+	ap.Summary = summary
+}
+
+// SetDescription sets the long description of the parser, displayed under the usage line.
+//
+// Parameters:
+// - description: The long description of the parser. Its line breaks are kept when it is wrapped.
+func (ap *ArgumentsParser) SetDescription(description string) {
+	ap.Description = description
+}
+
+// AddExample adds an example of invocation of the program, displayed after the arguments in the usage
+// message. The examples can be checked by parsing them with parsertest.ValidateExamples.
+//
+// Parameters:
+// - command: The command line of the example, starting with the name of the program.
+// - explanation: A description of what the command does.
+func (ap *ArgumentsParser) AddExample(command string, explanation string) {
+	ap.Examples = append(ap.Examples, Example{Command: command, Explanation: explanation})
+}
+
+// SetEpilog sets the text displayed at the end of the usage message, such as links or exit codes.
+//
+// Parameters:
+// - epilog: The text displayed at the end of the usage message. Its line breaks are kept when it is wrapped.
+func (ap *ArgumentsParser) SetEpilog(epilog string) {
+	ap.Epilog = epilog
+}
+
+// summary returns the one-line description of the parser, displayed next to its name in the usage
+// message of its parent.
+//
+// Returns:
+// - The summary of the parser, or its banner when it has no summary.
+func (ap *ArgumentsParser) summary() string {
+	if len(ap.Summary) != 0 {
+		return ap.Summary
+	}
+
+	return ap.Banner
+}
+
+// documentationWidth is the help width the data model of the generated documentation is built with,
+// wide enough for the usage lines to be neither wrapped nor collapsed into "[options]".
+const documentationWidth = 1 << 16
+
+// documentationPage is the documentation of a parser or of one of its subparsers.
+type documentationPage struct {
+	// Path is the name of the program followed by the names of the subparsers leading to the parser.
+	Path []string
+	// Parser is the documented parser.
+	Parser *ArgumentsParser
+	// Data is the data model of the help message of the parser, without colors nor wrapping.
+	Data HelpData
+}
+
+// documentationPages returns the documentation of the parser followed by the one of its subparsers,
+// depth first and sorted by name. The documentation is a reference, so it includes the advanced
+// arguments, but not the hidden ones.
+//
+// Parameters:
+// - path: The name of the program followed by the names of the subparsers leading to the parser.
+//
+// Returns:
+// - The documentation of the parser and of all its subparsers.
+func (ap *ArgumentsParser) documentationPages(path []string) []documentationPage {
+	parsingState := &ParsingState{RawArguments: path}
+	ap.populateMaps(parsingState)
+
+	pages := []documentationPage{{
+		Path:   path,
+		Parser: ap,
+		Data:   ap.buildHelpData(len(path), parsingState, documentationWidth, &Theme{}, arguments.VISIBILITY_ADVANCED),
+	}}
+
+	if ap.SubParsers.Enabled {
+		names := []string{}
+		for name := range ap.SubParsers.Parsers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			subparser := ap.SubParsers.Parsers[name]
+			subparser.parent = ap
+			pages = append(pages, subparser.documentationPages(append(slices.Clone(path), name))...)
+		}
+	}
+
+	return pages
+}
+
+// argumentDocumentationFlags returns the flags of an argument of the data model of the help message,
+// followed by the placeholder of its value, such as "-o, --output <FILE>".
+//
+// Parameters:
+// - arg: The argument of the data model of the help message.
+//
+// Returns:
+// - The flags of the argument, followed by a space and its value placeholder if it has one.
+func argumentDocumentationFlags(arg HelpArgument) string {
+	names := []string{}
+	for _, name := range append([]string{arg.ShortName, arg.LongName}, arg.Aliases...) {
+		if len(name) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	flags := strings.Join(names, ", ")
+	if len(arg.Placeholder) != 0 {
+		flags += " " + arg.Placeholder
+	}
+
+	return flags
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// newDocumentationParser returns a parser with a documented subparser.
+func newDocumentationParser() (*ArgumentsParser, *ArgumentsParser) {
+	var mode, target, output string
+	var verbose bool
+	ap := NewParser("Tool v1.0 by the team")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetOptHelpWidth(60)
+	ap.SetSummary("Scan and report.")
+	ap.SetDescription("Tool scans targets and writes reports about what it found.")
+	ap.SetEpilog("Exit codes: 0 on success, 1 on invalid arguments.")
+	ap.SetupSubParsing("mode", &mode, false)
+	scan := ap.AddSubParser("scan", "Tool scan mode")
+	scan.SetSummary("Scan a target.")
+	scan.NewStringPositionalArgument(&target, "target", "Target.")
+	scan.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose mode.")
+	group, _ := scan.NewArgumentGroup("Output")
+	group.NewStringArgument(&output, "-o", "--output", "", false, "Output file | path.")
+	scan.AddExample("tool scan 10.0.0.1 -o report.txt", "Scan 10.0.0.1 and write the results to report.txt.")
+
+	return ap, scan
+}
+
+// TestHelpSections verifies that the description, the examples and the epilog are displayed in the
+// usage message, and that subparsers are listed with their summary.
+func TestHelpSections(t *testing.T) {
+	ap, scan := newDocumentationParser()
+
+	got := ap.renderHelp(ap.helpData(1, &ParsingState{RawArguments: []string{"tool"}}, arguments.VISIBILITY_DEFAULT))
+	expected := "Usage: tool <scan>\n" +
+		"\n" +
+		"  Tool scans targets and writes reports about what it found.\n" +
+		"\n" +
+		"   scan  Scan a target.\n" +
+		"\n" +
+		"  Exit codes: 0 on success, 1 on invalid arguments.\n" +
+		"\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	got = scan.renderHelp(scan.helpData(2, &ParsingState{RawArguments: []string{"tool", "scan"}}, arguments.VISIBILITY_DEFAULT))
+	expected = "  Examples:\n" +
+		"    tool scan 10.0.0.1 -o report.txt\n" +
+		"      Scan 10.0.0.1 and write the results to report.txt.\n"
+	if !strings.Contains(got, expected) {
+		t.Errorf("expected the examples:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// TestGenerateManPage verifies the sections of the generated manual page.
+func TestGenerateManPage(t *testing.T) {
+	ap, _ := newDocumentationParser()
+	got := ap.GenerateManPage("tool", 1)
+
+	for _, expected := range []string{
+		".TH \"TOOL\" \"1\"\n.SH NAME\ntool \\- Scan and report.\n.SH SYNOPSIS\n.B tool\n<scan>\n",
+		".SH DESCRIPTION\nTool scans targets",
+		".SS \"tool scan\"\nScan a target.\n.PP\n.B tool scan\n<target> [\\-\\-verbose] [\\-\\-output <string>]\n",
+		".TP\n\\fB\\-o\\fR, \\fB\\-\\-output\\fR \\fI<string>\\fR\n",
+		".SH EXAMPLES\n.PP\n.nf\n.RS\ntool scan 10.0.0.1 \\-o report.txt\n.RE\n.fi\n",
+		".SH NOTES\nExit codes: 0 on success, 1 on invalid arguments.\n",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("expected the manual page to contain:\n%s\ngot:\n%s", expected, got)
+		}
+	}
+}
+
+// TestGenerateMarkdown verifies the sections of the generated Markdown documentation.
+func TestGenerateMarkdown(t *testing.T) {
+	ap, _ := newDocumentationParser()
+	got := ap.GenerateMarkdown("tool")
+
+	for _, expected := range []string{
+		"# tool\n\nScan and report.\n\n```\ntool <scan>\n```\n",
+		"| `scan` | Scan a target. |\n",
+		"## tool scan\n\nScan a target.\n\n```\ntool scan <target> [--verbose] [--output <string>]\n```\n",
+		"### Output\n\n| Option | Description |\n| --- | --- |\n| `-o, --output <string>` | Output file \\| path. (default: \"\") |\n",
+		"**Examples:**\n\n```\ntool scan 10.0.0.1 -o report.txt\n```\n",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("expected the Markdown documentation to contain:\n%s\ngot:\n%s", expected, got)
+		}
+	}
+	if !strings.HasSuffix(got, "Exit codes: 0 on success, 1 on invalid arguments.\n") {
+		t.Errorf("expected the documentation to end with the epilog, got:\n%s", got)
+	}
+}
+
+// TestDocumentationVersionFlags verifies that the version flags are documented with their help
+// message in the generated manual page and Markdown documentation.
+func TestDocumentationVersionFlags(t *testing.T) {
+	ap, _ := newDocumentationParser()
+	ap.SetVersion("1.0")
+
+	man := ap.GenerateManPage("tool", 1)
+	expected := ".TP\n\\fB\\-\\-version\\fR\nShow the version and exit.\n"
+	if !strings.Contains(man, expected) {
+		t.Errorf("expected the manual page to contain:\n%s\ngot:\n%s", expected, man)
+	}
+
+	markdown := ap.GenerateMarkdown("tool")
+	expected = "| `--version` | Show the version and exit. |\n"
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("expected the Markdown documentation to contain:\n%s\ngot:\n%s", expected, markdown)
+	}
+}
+
+// TestDocumentationSynopsisInAnotherLanguage verifies that the synopsis of the generated manual page
+// and Markdown documentation leaves out the title of the usage line in the language of the parser.
+func TestDocumentationSynopsisInAnotherLanguage(t *testing.T) {
+	ap, _ := newDocumentationParser()
+	ap.AddMessageCatalog(&MessageCatalog{
+		Language: "fr",
+		Messages: map[string]Message{MESSAGE_USAGE: {One: "Utilisation :"}},
+	})
+	ap.SetLanguage("fr")
+
+	man := ap.GenerateManPage("tool", 1)
+	expected := ".SH SYNOPSIS\n.B tool\n<scan>\n"
+	if !strings.Contains(man, expected) || strings.Contains(man, "Utilisation") {
+		t.Errorf("expected the manual page to contain:\n%s\ngot:\n%s", expected, man)
+	}
+
+	markdown := ap.GenerateMarkdown("tool")
+	expected = "```\ntool scan <target> [--verbose] [--output <string>]\n```\n"
+	if !strings.Contains(markdown, expected) || strings.Contains(markdown, "Utilisation") {
+		t.Errorf("expected the Markdown documentation to contain:\n%s\ngot:\n%s", expected, markdown)
+	}
+}
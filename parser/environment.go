@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// SetArgumentEnvVar sets the environment variable a registered argument reads its value from when
+// it is not given on the command line.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - name: The name of the environment variable (e.g., "PORT").
+//
+// Returns:
+//   - An error if no argument is registered with this name, or if the argument does not
+//     implement arguments.EnvVarArgument.
+func (ap *ArgumentsParser) SetArgumentEnvVar(argumentFlag string, name string) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
+	}
+
+	envVarArgument, ok := arg.(arguments.EnvVarArgument)
+	if !ok {
+		return fmt.Errorf("argument '%s' does not support environment variables", argumentFlag)
+	}
+	envVarArgument.SetEnvVar(name)
+
+	return nil
+}
+
+// applyEnvironmentVariables sets the arguments that were not given on the command line from their
+// environment variable, when they have one and it is defined.
+//
+// The value of the environment variable is consumed exactly as if it had followed the flag of the
+// argument on the command line, so it goes through the same parsing and checks. Arguments that are
+// not followed by a value, such as boolean flags, are set when the environment variable holds a
+// true value as understood by strconv.ParseBool, and left to their default value when it holds a
+// false one.
+//
+// Parameters:
+//   - parsingState: The parsing state that records the parsed arguments and the error messages.
+func (ap *ArgumentsParser) applyEnvironmentVariables(parsingState *ParsingState) {
+	for _, arg := range ap.allArguments {
+		if arg.IsPresent() {
+			continue
+		}
+
+		envVarArgument, ok := arg.(arguments.EnvVarArgument)
+		if !ok || len(envVarArgument.GetEnvVar()) == 0 {
+			continue
+		}
+		envVar := envVarArgument.GetEnvVar()
+		value, defined := os.LookupEnv(envVar)
+		if !defined {
+			continue
+		}
+
+		flagName := arg.GetLongName()
+		if len(flagName) == 0 {
+			flagName = arg.GetShortName()
+		}
+
+		tokens := []string{flagName, value}
+		if metadata, ok := arg.(arguments.ArgumentMetadata); ok && !metadata.ExpectsValue() {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_ENVIRONMENT, Argument: flagName, Token: value, Message: ap.message(MESSAGE_ENV_NOT_BOOLEAN, envVar, value)})
+				continue
+			}
+			if !enabled {
+				continue
+			}
+			tokens = tokens[:1]
+		}
+
+		if _, err := arg.Consume(tokens); err != nil {
+			parsingState.AddError(ParsingError{Kind: ERROR_KIND_ENVIRONMENT, Argument: flagName, Token: value, Message: ap.message(MESSAGE_ENV_INVALID, envVar, err)})
+		} else if arg.IsPresent() {
+			parsingState.ParsedArguments.AddArgument(&arg)
+		}
+	}
+}
@@ -114,7 +114,7 @@ func TestFormatGroupErrorMessage(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		if got := formatGroupErrorMessage(test.groupName, test.message); got != test.expected {
+		if got := NewParser("").formatGroupErrorMessage(test.groupName, test.message); got != test.expected {
 			t.Fatalf("formatGroupErrorMessage(%q, %q) = %q, expected %q", test.groupName, test.message, got, test.expected)
 		}
 	}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestNewCountedArgumentGroupsRejectInvalidCount verifies that exactly-N and at-most-N groups need a
+// count of at least 1.
+func TestNewCountedArgumentGroupsRejectInvalidCount(t *testing.T) {
+	ap := NewParser("test")
+	if _, err := ap.NewExactlyNArgumentGroup("Pick", 0); err == nil {
+		t.Errorf("expected an error for an exactly-N group with a count of 0")
+	}
+	if _, err := ap.NewAtMostNArgumentGroup("Limit", -1); err == nil {
+		t.Errorf("expected an error for an at-most-N group with a negative count")
+	}
+	if _, err := ap.NewAtLeastOneArgumentGroup(""); err == nil {
+		t.Errorf("expected an error for a group with an empty name")
+	}
+}
+
+// newGroupTypesParser returns a parser with an at-least-one group holding a positional argument, an
+// exactly-2 group and an at-most-1 group.
+func newGroupTypesParser(t *testing.T) *ArgumentsParser {
+	var file, user, userList, usersFile, a, b, c string
+	var verbose, debug bool
+	ap := NewParser("test")
+	ap.NewStringPositionalArgument(&file, "file", "Input file.")
+
+	targets, _ := ap.NewAtLeastOneArgumentGroup("Targets")
+	targets.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+	targets.NewStringArgument(&userList, "", "--user-list", "", false, "User list.")
+	targets.NewStringArgument(&usersFile, "", "--users-file", "", false, "Users file.")
+	if err := ap.AddPositionalToGroup("file", "Targets"); err != nil {
+		t.Fatalf("AddPositionalToGroup failed: %v", err)
+	}
+
+	pick, _ := ap.NewExactlyNArgumentGroup("Pick", 2)
+	pick.NewStringArgument(&a, "-a", "--alpha", "", false, "Alpha.")
+	pick.NewStringArgument(&b, "-b", "--beta", "", false, "Beta.")
+	pick.NewStringArgument(&c, "-c", "--gamma", "", false, "Gamma.")
+
+	limit, _ := ap.NewAtMostNArgumentGroup("Output", 1)
+	limit.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose.")
+	limit.NewBoolArgument(&debug, "-d", "--debug", false, "Debug.")
+
+	return ap
+}
+
+// TestGroupTypesAcceptValidCombinations verifies that the at-least-one, exactly-N and at-most-N
+// groups accept the combinations they allow, including a positional argument in a group.
+func TestGroupTypesAcceptValidCombinations(t *testing.T) {
+	for _, rawArguments := range [][]string{
+		{"test", "--user", "alice", "--user-list", "a,b", "-a", "1", "-c", "3"},
+		{"test", "input.txt", "-a", "1", "-b", "2", "-v"},
+	} {
+		ap := newGroupTypesParser(t)
+		ap.ParsingState.SetRawArguments(rawArguments)
+		ap.ParseFrom(1, &ap.ParsingState)
+		if len(ap.ParsingState.ErrorMessages) != 0 {
+			t.Errorf("expected no error messages for %v, got %v", rawArguments, ap.ParsingState.ErrorMessages)
+		}
+	}
+}
+
+// TestGroupsInUsageLine verifies that the constraint of mutually exclusive and at-least-one groups
+// is displayed in the usage line.
+func TestGroupsInUsageLine(t *testing.T) {
+	var file, user, password, hashes string
+	var stdin, kerberos bool
+	ap := NewParser("test")
+	ap.NewStringPositionalArgument(&file, "file", "Input file.")
+
+	input, _ := ap.NewRequiredMutuallyExclusiveArgumentGroup("Input")
+	input.NewBoolArgument(&stdin, "", "--stdin", false, "Read from stdin.")
+	ap.AddPositionalToGroup("file", "Input")
+
+	auth, _ := ap.NewNotRequiredMutuallyExclusiveArgumentGroup("Auth")
+	auth.NewStringArgument(&password, "-p", "--password", "", false, "Password.")
+	auth.NewStringArgument(&hashes, "-H", "--hashes", "", false, "Hashes.")
+
+	targets, _ := ap.NewAtLeastOneArgumentGroup("Targets")
+	targets.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+	targets.NewBoolArgument(&kerberos, "-k", "--kerberos", false, "Kerberos.")
+
+	expected := map[string]string{
+		"Input":   "(<file> | --stdin)",
+		"Auth":    "[--password <string> | --hashes <string>]",
+		"Targets": "{--user <string>,--kerberos}+",
+	}
+	for name, want := range expected {
+		if got := generateGroupForUsageLine(ap.Groups[name]); got != want {
+			t.Errorf("expected usage line entry %q for group %q, got %q", want, name, got)
+		}
+	}
+}
+
+// TestGroupTypesErrorsAreReported verifies the error messages of the at-least-one, exactly-N and
+// at-most-N groups, and that a missing positional argument in a group is only reported by its group.
+func TestGroupTypesErrorsAreReported(t *testing.T) {
+	if os.Getenv("GOOPTS_GROUP_TYPES_SUBPROCESS") == "1" {
+		ap := newGroupTypesParser(t)
+		ap.ParsingState.SetRawArguments([]string{"test", "--gamma", "3", "-v", "-d"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestGroupTypesErrorsAreReported", "GOOPTS_GROUP_TYPES_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d", code)
+	}
+
+	output := out
+	expected := "[!] Output: at most 1 of the arguments \"--verbose\", \"--debug\" can be set together.\n" +
+		"[!] Pick: exactly 2 of the arguments \"--alpha\", \"--beta\", \"--gamma\" need to be set, got 1.\n" +
+		"[!] Targets: at least one of the arguments \"<file>\", \"--user\", \"--user-list\", \"--users-file\" needs to be set.\n"
+	if !strings.Contains(output, expected) {
+		t.Fatalf("expected output to contain:\n%s\ngot:\n%s", expected, output)
+	}
+	if strings.Contains(output, "Missing 1 positional argument") {
+		t.Errorf("expected the positional argument in a group not to be reported as missing, got:\n%s", output)
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/TheManticoreProject/goopts/argumentgroup"
 )
@@ -35,6 +36,7 @@ func (ap *ArgumentsParser) NewArgumentGroup(name string) (*argumentgroup.Argumen
 	// Add it to the Groups
 	if _, exists := ap.Groups[group.Name]; !exists {
 		ap.Groups[group.Name] = &group
+		ap.groupOrder = append(ap.groupOrder, group.Name)
 	} else {
 		return nil, fmt.Errorf("an argument group with name \"%s\" already exists", group.Name)
 	}
@@ -71,6 +73,7 @@ func (ap *ArgumentsParser) NewNotRequiredMutuallyExclusiveArgumentGroup(name str
 	// Add it to the Groups
 	if _, exists := ap.Groups[group.Name]; !exists {
 		ap.Groups[group.Name] = &group
+		ap.groupOrder = append(ap.groupOrder, group.Name)
 	} else {
 		return nil, fmt.Errorf("an argument group with name \"%s\" already exists", group.Name)
 	}
@@ -107,6 +110,7 @@ func (ap *ArgumentsParser) NewRequiredMutuallyExclusiveArgumentGroup(name string
 	// Add it to the Groups
 	if _, exists := ap.Groups[group.Name]; !exists {
 		ap.Groups[group.Name] = &group
+		ap.groupOrder = append(ap.groupOrder, group.Name)
 	} else {
 		return nil, fmt.Errorf("an argument group with name \"%s\" already exists", group.Name)
 	}
@@ -143,9 +147,162 @@ func (ap *ArgumentsParser) NewDependentArgumentGroup(name string) (*argumentgrou
 	// Add it to the Groups
 	if _, exists := ap.Groups[group.Name]; !exists {
 		ap.Groups[group.Name] = &group
+		ap.groupOrder = append(ap.groupOrder, group.Name)
 	} else {
 		return nil, fmt.Errorf("an argument group with name \"%s\" already exists", group.Name)
 	}
 
 	return &group, nil
 }
+
+// NewAtLeastOneArgumentGroup creates a new argument group with the specified name and adds it to the
+// list of child groups. This group enforces that at least one of the arguments within it is set, any
+// combination of them being allowed.
+//
+// Parameters:
+// - name: The name of the new argument group.
+//
+// Returns:
+// A pointer to the newly created ArgumentGroup struct, which can be used to add arguments to the group,
+// or an error if a group with the same name already exists.
+func (ap *ArgumentsParser) NewAtLeastOneArgumentGroup(name string) (*argumentgroup.ArgumentGroup, error) {
+	return ap.addConstraintGroup(name, argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE, 0)
+}
+
+// NewExactlyNArgumentGroup creates a new argument group with the specified name and adds it to the
+// list of child groups. This group enforces that exactly n of the arguments within it are set.
+//
+// Parameters:
+// - name: The name of the new argument group.
+// - n: The number of arguments of the group that have to be set.
+//
+// Returns:
+// A pointer to the newly created ArgumentGroup struct, which can be used to add arguments to the group,
+// or an error if n is lower than 1 or if a group with the same name already exists.
+func (ap *ArgumentsParser) NewExactlyNArgumentGroup(name string, n int) (*argumentgroup.ArgumentGroup, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("the number of arguments of group \"%s\" needs to be at least 1, got %d", name, n)
+	}
+
+	return ap.addConstraintGroup(name, argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N, n)
+}
+
+// NewAtMostNArgumentGroup creates a new argument group with the specified name and adds it to the
+// list of child groups. This group allows at most n of the arguments within it to be set, but it is
+// not mandatory to provide any.
+//
+// Parameters:
+// - name: The name of the new argument group.
+// - n: The maximum number of arguments of the group that can be set.
+//
+// Returns:
+// A pointer to the newly created ArgumentGroup struct, which can be used to add arguments to the group,
+// or an error if n is lower than 1 or if a group with the same name already exists.
+func (ap *ArgumentsParser) NewAtMostNArgumentGroup(name string, n int) (*argumentgroup.ArgumentGroup, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("the number of arguments of group \"%s\" needs to be at least 1, got %d", name, n)
+	}
+
+	return ap.addConstraintGroup(name, argumentgroup.ARGUMENT_GROUP_TYPE_AT_MOST_N, n)
+}
+
+// AddPositionalToGroup adds a registered positional argument to the constraint of an argument group,
+// so that a command line can give either the positional argument or one of the arguments of the
+// group, such as "<file>" or "--stdin". The positional argument keeps its place among the other
+// positional arguments; as its presence is then decided by the group, it is not reported as missing
+// on its own, which is why it has to come after the positional arguments that are always required.
+//
+// Parameters:
+//   - positionalName: The name of the positional argument.
+//   - groupName: The name of the argument group.
+//
+// Returns:
+//   - An error if the positional argument or the group does not exist, or if the positional argument
+//     is already in the group.
+func (ap *ArgumentsParser) AddPositionalToGroup(positionalName, groupName string) error {
+	group, exists := ap.Groups[groupName]
+	if !exists || len(groupName) == 0 {
+		return fmt.Errorf("argument group \"%s\" not found", groupName)
+	}
+
+	for _, posarg := range ap.PositionalArguments {
+		if posarg.GetName() == positionalName {
+			return group.RegisterPositional(posarg)
+		}
+	}
+
+	return fmt.Errorf("positional argument <%s> not found", positionalName)
+}
+
+// addConstraintGroup creates an argument group of the given type and adds it to the list of child groups.
+//
+// Parameters:
+// - name: The name of the new argument group.
+// - groupType: The type of the new argument group.
+// - count: The number of arguments used by the constraint of the group, if any.
+//
+// Returns:
+// A pointer to the newly created ArgumentGroup struct, or an error if a group with the same name already exists.
+func (ap *ArgumentsParser) addConstraintGroup(name string, groupType int, count int) (*argumentgroup.ArgumentGroup, error) {
+	// Initiate the map if it was not initialized yet
+	if ap.Groups == nil {
+		ap.Groups = make(map[string]*argumentgroup.ArgumentGroup)
+	}
+
+	if len(name) == 0 {
+		return nil, fmt.Errorf("name of group cannot be empty, this is reserved for the default group")
+	}
+
+	group := argumentgroup.ArgumentGroup{
+		Name:  name,
+		Type:  groupType,
+		Count: count,
+	}
+
+	// Add it to the Groups
+	if _, exists := ap.Groups[group.Name]; !exists {
+		ap.Groups[group.Name] = &group
+		ap.groupOrder = append(ap.groupOrder, group.Name)
+	} else {
+		return nil, fmt.Errorf("an argument group with name \"%s\" already exists", group.Name)
+	}
+
+	return &group, nil
+}
+
+// orderedGroupNames returns the names of the named argument groups in the order they are displayed in
+// the help message: by increasing Order, then in the order they were created. Groups that were added
+// to the Groups map directly come last, sorted alphabetically. The default group is not included.
+//
+// Returns:
+//   - The names of the named argument groups in display order.
+func (ap *ArgumentsParser) orderedGroupNames() []string {
+	position := make(map[string]int)
+	for k, name := range ap.groupOrder {
+		if _, exists := position[name]; !exists {
+			position[name] = k
+		}
+	}
+
+	names := []string{}
+	for _, name := range ap.sortedGroupNames() {
+		if len(name) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		groupI, groupJ := ap.Groups[names[i]], ap.Groups[names[j]]
+		if groupI.Order != groupJ.Order {
+			return groupI.Order < groupJ.Order
+		}
+		positionI, registeredI := position[names[i]]
+		positionJ, registeredJ := position[names[j]]
+		if registeredI != registeredJ {
+			return registeredI
+		}
+		return positionI < positionJ
+	})
+
+	return names
+}
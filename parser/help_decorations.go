@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+const (
+	// HELP_DECORATION_DEFAULT displays the default value of the arguments that are not required.
+	HELP_DECORATION_DEFAULT = iota
+	// HELP_DECORATION_REQUIRED marks the arguments that are required with "required".
+	HELP_DECORATION_REQUIRED
+	// HELP_DECORATION_RANGE displays the range of the values accepted by the arguments, such as the
+	// ones of an IntRangeArgument.
+	HELP_DECORATION_RANGE
+	// HELP_DECORATION_REPEATABLE marks the arguments that can be given several times, such as lists
+	// and maps, with "repeatable".
+	HELP_DECORATION_REPEATABLE
+	// HELP_DECORATION_ENV_VAR displays the environment variable the value of the arguments is read from.
+	HELP_DECORATION_ENV_VAR
+	// HELP_DECORATION_DEPRECATED displays the deprecated aliases of the arguments, which are not
+	// displayed by default.
+	HELP_DECORATION_DEPRECATED
+)
+
+// defaultHelpDecorations are the details displayed after the help message of the arguments by a
+// parser that did not configure its own, which are all of them but the deprecated aliases.
+var defaultHelpDecorations = []int{
+	HELP_DECORATION_DEFAULT,
+	HELP_DECORATION_REQUIRED,
+	HELP_DECORATION_RANGE,
+	HELP_DECORATION_REPEATABLE,
+	HELP_DECORATION_ENV_VAR,
+}
+
+// SetHelpDecorations sets the details displayed between parentheses after the help message of the
+// arguments, for example SetHelpDecorations(HELP_DECORATION_REQUIRED) to only mark the required
+// arguments. Calling it without decorations displays the help messages alone. The subparsers that did
+// not set their own decorations use the ones of their parent parser.
+//
+// Parameters:
+// - decorations: The HELP_DECORATION_* constants of the details to display.
+func (ap *ArgumentsParser) SetHelpDecorations(decorations ...int) {
+	ap.Options.HelpDecorations = append([]int{}, decorations...)
+}
+
+// helpDecorations returns the details displayed after the help message of the arguments of the
+// parser, which are its own if it set them, or else the ones of its parent parser, or else all of them
+// but the deprecated aliases.
+//
+// Returns:
+// - The HELP_DECORATION_* constants of the details to display.
+func (ap *ArgumentsParser) helpDecorations() []int {
+	if ap.Options.HelpDecorations != nil {
+		return ap.Options.HelpDecorations
+	}
+	if ap.parent != nil {
+		return ap.parent.helpDecorations()
+	}
+
+	return defaultHelpDecorations
+}
+
+// argumentDecorations returns the details of an argument displayed after its help message, in the
+// same order for every type of argument: whether it is required or else its default value, the range
+// of its values, whether it is repeatable, its environment variable and its deprecated aliases. The
+// details are taken from the message catalog of the parser.
+//
+// Parameters:
+//   - arg: The argument to describe.
+//   - decorations: The HELP_DECORATION_* constants of the details to display.
+//
+// Returns:
+//   - The details of the argument, such as "default: 445" or "env: TOOL_PORT".
+func (ap *ArgumentsParser) argumentDecorations(arg arguments.Argument, decorations []int) []string {
+	details := []string{}
+	metadata, hasMetadata := arg.(arguments.ArgumentMetadata)
+
+	if arg.IsRequired() {
+		if slices.Contains(decorations, HELP_DECORATION_REQUIRED) {
+			details = append(details, ap.message(MESSAGE_DECORATION_REQUIRED))
+		}
+	} else if hasMetadata && slices.Contains(decorations, HELP_DECORATION_DEFAULT) {
+		// An empty list is the default of every repeatable argument, which is not worth displaying
+		defaultValue := metadata.GetDefaultValueString()
+		if len(defaultValue) != 0 && !(metadata.IsRepeatable() && defaultValue == "[]") {
+			details = append(details, ap.message(MESSAGE_DECORATION_DEFAULT, defaultValue))
+		}
+	}
+
+	if rangeArgument, ok := arg.(arguments.RangeArgument); ok && slices.Contains(decorations, HELP_DECORATION_RANGE) {
+		rangeStart, rangeStop := rangeArgument.GetRange()
+		details = append(details, ap.message(MESSAGE_DECORATION_RANGE, rangeStart, rangeStop))
+	}
+
+	if hasMetadata && metadata.IsRepeatable() && slices.Contains(decorations, HELP_DECORATION_REPEATABLE) {
+		details = append(details, ap.message(MESSAGE_DECORATION_REPEATABLE))
+	}
+
+	if envVarArgument, ok := arg.(arguments.EnvVarArgument); ok && slices.Contains(decorations, HELP_DECORATION_ENV_VAR) {
+		if envVar := envVarArgument.GetEnvVar(); len(envVar) != 0 {
+			details = append(details, ap.message(MESSAGE_DECORATION_ENV_VAR, envVar))
+		}
+	}
+
+	if slices.Contains(decorations, HELP_DECORATION_DEPRECATED) {
+		for _, alias := range argumentAliases(arg) {
+			if alias.Deprecated {
+				details = append(details, ap.message(MESSAGE_DECORATION_DEPRECATED, alias.Name))
+			}
+		}
+	}
+
+	return details
+}
+
+// decoratedHelp returns the help message of an argument followed by its details between parentheses,
+// such as "Port to connect to. (default: 445, env: TOOL_PORT)".
+//
+// Parameters:
+//   - arg: The argument to describe.
+//   - decorations: The HELP_DECORATION_* constants of the details to display.
+//
+// Returns:
+//   - The help message of the argument, followed by its details when it has some.
+func (ap *ArgumentsParser) decoratedHelp(arg arguments.Argument, decorations []int) string {
+	help := arg.GetHelp()
+
+	details := ap.argumentDecorations(arg, decorations)
+	if len(details) == 0 {
+		return help
+	}
+	if len(help) == 0 {
+		return "(" + strings.Join(details, ", ") + ")"
+	}
+
+	return help + " (" + strings.Join(details, ", ") + ")"
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestArgumentDecorations verifies that the details of the arguments are displayed the same way for
+// every type of argument.
+func TestArgumentDecorations(t *testing.T) {
+	var (
+		verbose bool
+		user    string
+		port    int
+		threads int
+		headers map[string]string
+		hosts   []string
+	)
+	ap := NewParser("test")
+	ap.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose mode.")
+	ap.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	ap.NewTcpPortArgument(&port, "-P", "--port", 445, false, "Port.")
+	ap.NewIntRangeArgument(&threads, "-t", "--threads", 4, 1, 64, false, "Threads.")
+	ap.NewMapOfHttpHeadersArgument(&headers, "-H", "--header", map[string]string{}, false, "Header.")
+	ap.NewListOfStringsArgument(&hosts, "", "--host", []string{"a"}, false, "Host.")
+	if err := ap.SetArgumentEnvVar("--user", "TOOL_USER"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"Verbose mode. (default: false)",
+		"User. (required, env: TOOL_USER)",
+		"Port. (default: 445)",
+		"Threads. (default: 4, range: [1, 64])",
+		"Header. (repeatable)",
+		"Host. (default: [\"a\"], repeatable)",
+	}
+	for index, argument := range ap.Groups[""].Arguments {
+		if got := ap.decoratedHelp(argument, ap.helpDecorations()); got != expected[index] {
+			t.Errorf("expected %q, got %q", expected[index], got)
+		}
+	}
+
+	if got := ap.decoratedHelp(ap.Groups[""].Arguments[1], []int{HELP_DECORATION_ENV_VAR}); got != "User. (env: TOOL_USER)" {
+		t.Errorf("expected only the environment variable, got %q", got)
+	}
+}
+
+// TestHelpDecorationsOption verifies that the decorations are inherited by the subparsers, and that
+// they can be disabled.
+func TestHelpDecorationsOption(t *testing.T) {
+	var mode string
+	var port int
+	ap := NewParser("test")
+	ap.SetHelpDecorations(HELP_DECORATION_REQUIRED)
+	ap.SetupSubParsing("mode", &mode, false)
+	sub := ap.AddSubParser("scan", "Scan.")
+	sub.NewIntArgument(&port, "-p", "--port", 445, false, "Port.")
+
+	if got := sub.helpDecorations(); len(got) != 1 || got[0] != HELP_DECORATION_REQUIRED {
+		t.Errorf("expected the subparser to use the decorations of its parent, got %v", got)
+	}
+
+	sub.SetHelpDecorations()
+	data := sub.buildHelpData(1, &ParsingState{RawArguments: []string{"test", "scan"}}, 80, &Theme{}, arguments.VISIBILITY_DEFAULT)
+	if len(data.Arguments) != 1 || !strings.HasSuffix(data.Arguments[0].Line, "Port.\n") {
+		t.Errorf("expected the help message without decorations, got %+v", data.Arguments)
+	}
+}
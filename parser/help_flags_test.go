@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestHelpFlagConsumedAsValue verifies that a value equal to a help flag is consumed as the value of
+// the argument before it instead of displaying the help message.
+func TestHelpFlagConsumedAsValue(t *testing.T) {
+	var pattern string
+	ap := NewParser("test")
+	ap.NewStringArgument(&pattern, "-g", "--grep", "", false, "Pattern.")
+
+	ap.ParsingState.SetRawArguments([]string{"test", "--grep", "-h"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if pattern != "-h" {
+		t.Errorf("expected pattern \"-h\", got %q", pattern)
+	}
+}
+
+// TestHelpFlagsCanBeReplaced verifies that "-h" can be used by an argument once the help flags are
+// configured without it, including in a subparser inheriting the help flags of its parent.
+func TestHelpFlagsCanBeReplaced(t *testing.T) {
+	var mode, host string
+	ap := NewParser("test")
+	ap.SetHelpFlags("--help")
+	ap.SetupSubParsing("mode", &mode, false)
+	connect := ap.AddSubParser("connect", "Connect.")
+	connect.NewStringArgument(&host, "-h", "--host", "", true, "Host.")
+
+	if errs := ap.Validate(); len(errs) != 0 {
+		t.Errorf("expected no mistakes, got %v", errs)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "connect", "-h", "dc01"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if host != "dc01" {
+		t.Errorf("expected host \"dc01\", got %q", host)
+	}
+}
+
+// runHelpSubprocess runs the given scenario of TestHelpFlagsSubprocess in a subprocess and returns
+// its output and exit code.
+func runHelpSubprocess(t *testing.T, scenario string) (string, int) {
+	t.Helper()
+
+	return runTestSubprocess(t, "TestHelpFlagsSubprocess", "GOOPTS_HELP_SUBPROCESS="+scenario)
+}
+
+// TestHelpFlagsSubprocess is the body of the subprocesses started by the tests of this file, and does
+// nothing when run directly.
+func TestHelpFlagsSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_HELP_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	var mode, host, user string
+	ap := NewParser("test")
+	switch scenario {
+	case "disabled":
+		ap.DisableHelpFlags()
+		ap.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+		ap.ParsingState.SetRawArguments([]string{"test", "--help"})
+	case "required":
+		ap.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+		ap.ParsingState.SetRawArguments([]string{"test", "extra", "--help"})
+	case "command":
+		ap.SetupSubParsing("mode", &mode, false)
+		connect := ap.AddSubParser("connect", "Connect.")
+		connect.NewStringArgument(&host, "", "--host", "", true, "Host.")
+		ap.ParsingState.SetRawArguments([]string{"test", "help", "connect"})
+	case "unknown-command":
+		ap.SetupSubParsing("mode", &mode, false)
+		ap.AddSubParser("connect", "Connect.")
+		ap.ParsingState.SetRawArguments([]string{"test", "help", "upload"})
+	}
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// TestHelpFlagsDisabled verifies that a disabled help flag is reported as an unknown argument.
+func TestHelpFlagsDisabled(t *testing.T) {
+	out, code := runHelpSubprocess(t, "disabled")
+	if code != 1 || !strings.Contains(out, "[!] Unknown argument \"--help\".") {
+		t.Errorf("expected \"--help\" to be an unknown argument, got exit code %d and:\n%s", code, out)
+	}
+}
+
+// TestHelpFlagWithErrors verifies that the help message is displayed even when the other arguments
+// would be reported as errors.
+func TestHelpFlagWithErrors(t *testing.T) {
+	out, code := runHelpSubprocess(t, "required")
+	if code != 0 || strings.Contains(out, "[!]") || !strings.Contains(out, "Usage: test --user <string>") {
+		t.Errorf("expected the help message without errors, got exit code %d and:\n%s", code, out)
+	}
+}
+
+// TestHelpCommand verifies that "help <subcommand>" displays the usage of the subcommand.
+func TestHelpCommand(t *testing.T) {
+	out, code := runHelpSubprocess(t, "command")
+	if code != 0 || !strings.Contains(out, "Usage: test connect --host <string>") {
+		t.Errorf("expected the usage of the subcommand, got exit code %d and:\n%s", code, out)
+	}
+
+	out, code = runHelpSubprocess(t, "unknown-command")
+	if code != 1 || !strings.Contains(out, "[!] No subparser with name \"upload\" was found.") {
+		t.Errorf("expected an unknown subcommand to be reported, got exit code %d and:\n%s", code, out)
+	}
+}
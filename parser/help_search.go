@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// helpSearchResult is an entry of the results of a search in the help messages of a parser and of its
+// subparsers: an argument, or a subparser.
+type helpSearchResult struct {
+	// Path is the name of the program followed by the names of the subparsers leading to the entry,
+	// the name of the subparser itself included for a subparser.
+	Path []string `json:"path"`
+	// Flags are the flags of the argument followed by the placeholder of its value, or an empty string
+	// for a subparser.
+	Flags string `json:"flags"`
+	// Help is the help message of the argument, or the summary of the subparser.
+	Help string `json:"help"`
+}
+
+// rootParser returns the top-level parser of a parser, following its parent parsers.
+//
+// Returns:
+// - The top-level parser, which is the parser itself when it has no parent.
+func (ap *ArgumentsParser) rootParser() *ArgumentsParser {
+	root := ap
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	return root
+}
+
+// searchHelp searches a term in the help messages of the parser and of its subparsers, recursively.
+// An argument matches when the term is found in one of its names or aliases, in its help message, or
+// in the name of one of the groups it belongs to. A subparser matches when the term is found in its
+// name, its banner or its summary. The search is case-insensitive, and the hidden arguments and groups
+// are left out.
+//
+// Parameters:
+// - path: The name of the program followed by the names of the subparsers leading to the parser.
+// - term: The term to search.
+//
+// Returns:
+// - The matching arguments and subparsers, in the order of the help messages, subparsers by name.
+func (ap *ArgumentsParser) searchHelp(path []string, term string) []helpSearchResult {
+	term = strings.ToLower(term)
+	results := []helpSearchResult{}
+
+	groups := ap.visibleGroups(arguments.VISIBILITY_ADVANCED)
+	results = append(results, searchGroup(groups[""], path, nil, term)...)
+	for _, groupName := range ap.orderedGroupNames() {
+		if group := groups[groupName]; group != nil {
+			results = append(results, searchGroup(group, path, nil, term)...)
+		}
+	}
+
+	if ap.SubParsers.Enabled {
+		names := []string{}
+		for name := range ap.SubParsers.Parsers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			subparser := ap.SubParsers.Parsers[name]
+			subparser.parent = ap
+			subpath := append(append([]string{}, path...), name)
+			summary := subparser.summary()
+			texts := append([]string{name, subparser.Banner, summary}, ap.SubParsers.AliasesOf(name)...)
+			if containsTerm(term, texts...) {
+				results = append(results, helpSearchResult{Path: subpath, Help: summary})
+			}
+			results = append(results, subparser.searchHelp(subpath, term)...)
+		}
+	}
+
+	return results
+}
+
+// searchGroup searches a term in the arguments of a group and of its subgroups.
+//
+// Parameters:
+// - group: The argument group to search, along with its subgroups.
+// - path: The name of the program followed by the names of the subparsers leading to the parser.
+// - groupNames: The names of the groups the group is nested in.
+// - term: The term to search, in lower case.
+//
+// Returns:
+// - The matching arguments of the group and of its subgroups.
+func searchGroup(group *argumentgroup.ArgumentGroup, path []string, groupNames []string, term string) []helpSearchResult {
+	results := []helpSearchResult{}
+	if len(group.Name) != 0 {
+		groupNames = append(append([]string{}, groupNames...), group.Name)
+	}
+
+	for _, arg := range group.Arguments {
+		texts := append([]string{arg.GetShortName(), arg.GetLongName(), arg.GetHelp()}, groupNames...)
+		for _, alias := range argumentAliases(arg) {
+			texts = append(texts, alias.Name)
+		}
+		if containsTerm(term, texts...) {
+			results = append(results, helpSearchResult{Path: path, Flags: argumentFlagsWithPlaceholder(arg), Help: arg.GetHelp()})
+		}
+	}
+	for _, subgroup := range group.SubGroups {
+		results = append(results, searchGroup(subgroup, path, groupNames, term)...)
+	}
+
+	return results
+}
+
+// containsTerm reports whether a term is found in one of the texts, regardless of case.
+//
+// Parameters:
+// - term: The term to search, in lower case.
+// - texts: The texts to search the term in.
+//
+// Returns:
+// - true if one of the texts contains the term, false otherwise.
+func containsTerm(term string, texts ...string) bool {
+	for _, text := range texts {
+		if strings.Contains(strings.ToLower(text), term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printHelpSearch prints the arguments and subparsers of the whole tree of parsers matching a term,
+// each one with the full command line leading to it, such as "tool ldap query --filter <string>",
+// then exits with EXIT_CODE_SUCCESS, or EXIT_CODE_USAGE_ERROR when nothing matches. In the JSON output
+// format, the term and the results are written as a JSON document instead.
+//
+// Parameters:
+// - term: The term to search.
+// - parsingState: The parsing state holding the raw arguments, the first of them being the program name.
+func (ap *ArgumentsParser) printHelpSearch(term string, parsingState *ParsingState) {
+	theme := ap.theme()
+	width := ap.helpWidth()
+
+	results := ap.rootParser().searchHelp([]string{programName(parsingState)}, term)
+	if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+		writeJSON(os.Stdout, map[string]any{"term": term, "results": append([]helpSearchResult{}, results...)})
+		if len(results) == 0 {
+			os.Exit(EXIT_CODE_USAGE_ERROR)
+		}
+		os.Exit(EXIT_CODE_SUCCESS)
+	}
+	if len(results) == 0 {
+		fmt.Printf("%s\n", theme.apply(theme.Error, "[!] "+ap.message(MESSAGE_SEARCH_NO_RESULTS, term)))
+		os.Exit(EXIT_CODE_USAGE_ERROR)
+	}
+
+	fmt.Printf("%s\n", theme.apply(theme.Title, ap.message(MESSAGE_SEARCH_RESULTS, term)))
+	for _, result := range results {
+		command := strings.Join(result.Path, " ")
+		if len(result.Flags) != 0 {
+			command += " " + theme.apply(theme.Flag, result.Flags)
+		} else {
+			command = theme.apply(theme.Subcommand, command)
+		}
+		fmt.Printf("\n  %s\n", command)
+		for _, line := range utils.WrapText(result.Help, max(width-6, minimumHelpTextWidth)) {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+	os.Exit(EXIT_CODE_SUCCESS)
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// newSearchParser returns a parser with nested subparsers, "ldap query" and "smb".
+func newSearchParser() *ArgumentsParser {
+	var mode, ldapMode, filter, share, user string
+	var trace bool
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetupSubParsing("mode", &mode, false)
+	ldap := ap.AddSubParser("ldap", "LDAP operations")
+	ldap.SetupSubParsing("ldapMode", &ldapMode, false)
+	query := ldap.AddSubParser("query", "Query the directory")
+	query.NewStringArgument(&filter, "-f", "--filter", "", false, "LDAP filter of the query.")
+	query.Flag("--trace-filter").Help("Trace the filter.").Hidden().Bool(&trace)
+	smb := ap.AddSubParser("smb", "SMB operations")
+	smb.NewStringArgument(&share, "", "--share", "", false, "Share to connect to.")
+	group, _ := smb.NewArgumentGroup("Authentication")
+	group.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+	return ap
+}
+
+// TestSearchHelp verifies that the arguments and subparsers of the whole tree of parsers are searched.
+func TestSearchHelp(t *testing.T) {
+	ap := newSearchParser()
+
+	format := func(results []helpSearchResult) []string {
+		lines := []string{}
+		for _, result := range results {
+			lines = append(lines, strings.TrimSpace(strings.Join(result.Path, " ")+" "+result.Flags))
+		}
+		return lines
+	}
+
+	for term, expected := range map[string][]string{
+		"FILTER":         {"tool ldap query -f, --filter <string>"},
+		"ldap":           {"tool ldap", "tool ldap query -f, --filter <string>"},
+		"authentication": {"tool smb -u, --user <string>"},
+		"directory":      {"tool ldap query"},
+		"missing":        {},
+	} {
+		if got := format(ap.searchHelp([]string{"tool"}, term)); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("expected the results of %q to be %v, got %v", term, expected, got)
+		}
+	}
+}
+
+// TestHelpSearchSubprocess is the body of the subprocesses started by TestHelpSearch, and does
+// nothing when run directly.
+func TestHelpSearchSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_HELP_SEARCH_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	ap := newSearchParser()
+	ap.ParsingState.SetRawArguments(append([]string{"tool"}, strings.Fields(scenario)...))
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// TestHelpSearch verifies "--help <term>" from any parser of the tree and "help search <term>".
+func TestHelpSearch(t *testing.T) {
+	for scenario, expected := range map[string]string{
+		"--help share":        "Search results for \"share\":\n\n  tool smb --share <string>\n      Share to connect to.\n",
+		"ldap query -h share": "  tool smb --share <string>\n",
+		"help search filter":  "  tool ldap query -f, --filter <string>\n      LDAP filter of the query.\n",
+	} {
+		out, code := runTestSubprocess(t, "TestHelpSearchSubprocess", "GOOPTS_HELP_SEARCH_SUBPROCESS="+scenario)
+		if code != 0 {
+			t.Fatalf("expected scenario %q to exit with code 0, got %d:\n%s", scenario, code, out)
+		}
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output of scenario %q to contain:\n%s\ngot:\n%s", scenario, expected, out)
+		}
+	}
+
+	out, code := runTestSubprocess(t, "TestHelpSearchSubprocess", "GOOPTS_HELP_SEARCH_SUBPROCESS=--help nothing")
+	if code != 1 || !strings.Contains(out, "Nothing matches \"nothing\".") {
+		t.Errorf("expected a search without results to exit with code 1, got %d:\n%s", code, out)
+	}
+}
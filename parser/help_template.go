@@ -0,0 +1,335 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// DefaultHelpTemplate is the text/template the help message is rendered with when no template was set
+// with SetHelpTemplate. It is executed with a HelpData, and defines a "group" template rendering a
+// HelpGroup, which custom templates can use as well.
+//
+// The lines of the arguments and subcommands are prepared by the parser, aligned and wrapped to the
+// help width, so that the default template only lays out the sections.
+const DefaultHelpTemplate = `{{.UsageLine}}
+
+{{range .DescriptionLines}}  {{.}}
+{{end}}{{if .DescriptionLines}}
+{{end}}{{range .Subcommands}}{{.Line}}{{end}}{{range .Arguments}}{{.Line}}{{end}}{{range .Groups}}{{template "group" .}}{{end}}{{if .HelpAllFlag}}
+  {{message "help-all-hint" (style "Flag" .HelpAllFlag)}}
+{{end}}{{if .Examples}}
+  {{style "Title" (message "examples")}}
+{{range .Examples}}    {{.Command}}
+{{range .ExplanationLines}}      {{.}}
+{{end}}{{end}}{{end}}{{if .EpilogLines}}
+{{range .EpilogLines}}  {{.}}
+{{end}}{{end}}
+{{define "group"}}
+{{indent .Indent}}{{.Title}}
+{{range .DescriptionLines}}{{indent (add $.Indent 2)}}{{.}}
+{{end}}{{range .Arguments}}{{.Line}}{{end}}{{range .SubGroups}}{{template "group" .}}{{end}}{{end}}`
+
+// HelpData is the data model the help template is executed with.
+type HelpData struct {
+	// Program is the name of the program, as displayed at the start of the usage line.
+	Program string
+	// Path is the names of the subparsers leading to the parser, after the program name.
+	Path []string
+	// Banner is the banner of the parser.
+	Banner string
+	// Summary is the one-line description of the parser, or its banner when it has none.
+	Summary string
+	// Description is the long description of the parser.
+	Description string
+	// DescriptionLines are the lines of the description wrapped to the help width.
+	DescriptionLines []string
+	// UsageLine is the usage line, such as "Usage: program <target> [--port <int>]", styled when
+	// colors are enabled.
+	UsageLine string
+	// Synopsis is the usage line without its title, such as "program <target> [--port <int>]".
+	Synopsis string
+	// Positionals are the positional arguments of the parser, in the order they are expected.
+	Positionals []HelpPositional
+	// Arguments are the arguments of the default group, followed by the version flags once the
+	// version is enabled.
+	Arguments []HelpArgument
+	// Groups are the named argument groups, by increasing Order and then in the order they were created.
+	Groups []HelpGroup
+	// Subcommands are the subparsers of the parser, sorted by name.
+	Subcommands []HelpSubcommand
+	// Examples are examples of invocations of the program.
+	Examples []HelpExample
+	// Epilog is the text displayed at the end of the help message.
+	Epilog string
+	// EpilogLines are the lines of the epilog wrapped to the help width.
+	EpilogLines []string
+	// HelpAllFlag is the flag displaying the full help message, set when the help message leaves
+	// advanced arguments out, and empty otherwise.
+	HelpAllFlag string
+	// Width is the help width, in columns.
+	Width int
+	// Column is the column the help descriptions start on.
+	Column int
+}
+
+// HelpPositional describes a positional argument in the data model of the help template.
+type HelpPositional struct {
+	// Name is the name of the positional argument.
+	Name string
+	// Placeholder is the placeholder displayed in the usage line, such as "<target>" or "{add|remove}".
+	Placeholder string
+	// Help is the help message of the positional argument.
+	Help string
+	// Grouped indicates whether the positional argument belongs to an argument group, which decides
+	// whether it has to be given.
+	Grouped bool
+}
+
+// HelpArgument describes an argument in the data model of the help template.
+type HelpArgument struct {
+	// ShortName is the short flag of the argument, such as "-o", or an empty string.
+	ShortName string
+	// LongName is the long flag of the argument, such as "--output", or an empty string.
+	LongName string
+	// Aliases are the additional names of the argument displayed with its flags, which are the ones
+	// that are not deprecated.
+	Aliases []string
+	// Placeholder is the placeholder of the value of the argument, such as "<FILE>", or an empty
+	// string for a flag that is not followed by a value.
+	Placeholder string
+	// TypeName is the name of the type of the value of the argument, such as "string".
+	TypeName string
+	// Choices are the values accepted by the argument, or nil when any value is accepted.
+	Choices []string
+	// Help is the help message of the argument.
+	Help string
+	// DefaultValue is the default value of the argument formatted for display.
+	DefaultValue string
+	// Required indicates whether the argument needs to be given.
+	Required bool
+	// Repeatable indicates whether the argument can be given several times.
+	Repeatable bool
+	// EnvVar is the environment variable the value of the argument is read from, or an empty string.
+	EnvVar string
+	// Decorations are the details displayed after the help message of the argument, such as
+	// "default: 445" or "env: TOOL_PORT".
+	Decorations []string
+	// DecoratedHelp is the help message of the argument followed by its decorations between parentheses.
+	DecoratedHelp string
+	// Line is the line of the help message for the argument, aligned on the help column and wrapped
+	// to the help width, ending with a line break.
+	Line string
+}
+
+// HelpGroup describes an argument group in the data model of the help template.
+type HelpGroup struct {
+	// Name is the name of the group.
+	Name string
+	// Title is the title of the section of the group, its name followed by a colon, styled when
+	// colors are enabled.
+	Title string
+	// Description is the description of the group.
+	Description string
+	// DescriptionLines are the lines of the description wrapped to the help width.
+	DescriptionLines []string
+	// Depth is the nesting level of the group, 0 for the groups of the parser.
+	Depth int
+	// Indent is the number of spaces before the title of the section.
+	Indent int
+	// Arguments are the arguments of the group.
+	Arguments []HelpArgument
+	// SubGroups are the nested subgroups of the group.
+	SubGroups []HelpGroup
+}
+
+// HelpSubcommand describes a subparser in the data model of the help template.
+type HelpSubcommand struct {
+	// Name is the name of the subparser.
+	Name string
+	// Path is the names of the subparsers leading to the subparser from the parser whose usage message
+	// lists it, its own name included.
+	Path []string
+	// Aliases are the additional names of the subparser.
+	Aliases []string
+	// Depth is the nesting level of the subparser, 0 for the subparsers of the parser whose usage
+	// message lists it.
+	Depth int
+	// Banner is the banner of the subparser.
+	Banner string
+	// Summary is the one-line description of the subparser, or its banner when it has none.
+	Summary string
+	// Line is the line of the help message for the subparser, ending with a line break.
+	Line string
+}
+
+// HelpExample describes an example of invocation of the program in the data model of the help template.
+type HelpExample struct {
+	// Command is the command line of the example.
+	Command string
+	// Explanation describes what the command of the example does.
+	Explanation string
+	// ExplanationLines are the lines of the explanation wrapped to the help width.
+	ExplanationLines []string
+}
+
+// newHelpArgument builds the data model of an argument for the help template.
+//
+// Parameters:
+//   - arg: The argument to describe.
+//   - indent: The number of spaces before the flags of the argument.
+//   - column: The column the help description starts on.
+//   - width: The help width the description is wrapped to.
+//   - theme: The theme styling the flags, which is empty when they are not colored.
+//   - decorations: The HELP_DECORATION_* constants of the details displayed after the help message.
+//
+// Returns:
+//   - The data model of the argument.
+func (ap *ArgumentsParser) newHelpArgument(arg arguments.Argument, indent int, column int, width int, theme *Theme, decorations []int) HelpArgument {
+	helpArgument := HelpArgument{
+		ShortName:     arg.GetShortName(),
+		LongName:      arg.GetLongName(),
+		Aliases:       displayedAliases(arg),
+		Placeholder:   argumentValuePlaceholder(arg),
+		Help:          arg.GetHelp(),
+		Required:      arg.IsRequired(),
+		Decorations:   ap.argumentDecorations(arg, decorations),
+		DecoratedHelp: ap.decoratedHelp(arg, decorations),
+		Line:          ap.generateArgumentLineInHelp(arg, indent, column, width, theme, decorations),
+	}
+
+	if metadata, ok := arg.(arguments.ArgumentMetadata); ok {
+		helpArgument.TypeName = metadata.GetTypeName()
+		helpArgument.Choices = metadata.GetChoices()
+		helpArgument.DefaultValue = metadata.GetDefaultValueString()
+		helpArgument.Repeatable = metadata.IsRepeatable()
+	}
+	if envVarArgument, ok := arg.(arguments.EnvVarArgument); ok {
+		helpArgument.EnvVar = envVarArgument.GetEnvVar()
+	}
+
+	return helpArgument
+}
+
+// helpTemplateFuncs returns the functions available in the help templates:
+//   - indent N: a string of N spaces.
+//   - add A B: the sum of two integers.
+//   - wrap WIDTH TEXT: the lines of TEXT wrapped to WIDTH columns.
+//   - style NAME TEXT: TEXT styled with the field NAME of the theme, such as "Flag" or "Title".
+//   - message ID PARAMETERS...: the message ID of the catalog, such as "usage", formatted with PARAMETERS.
+//
+// Parameters:
+//   - theme: The theme used by the style function, which is empty when the messages are not colored.
+//   - catalog: The catalog used by the message function, in the language of the parser.
+//
+// Returns:
+//   - The functions of the help templates.
+func helpTemplateFuncs(theme *Theme, catalog *MessageCatalog) template.FuncMap {
+	return template.FuncMap{
+		"indent": func(n int) string { return strings.Repeat(" ", max(n, 0)) },
+		"add":    func(a, b int) int { return a + b },
+		"wrap":   func(width int, text string) []string { return utils.WrapText(text, width) },
+		"style": func(name string, text string) (string, error) {
+			styles := map[string]string{
+				"Title":      theme.Title,
+				"Flag":       theme.Flag,
+				"Metavar":    theme.Metavar,
+				"Required":   theme.Required,
+				"Subcommand": theme.Subcommand,
+				"Error":      theme.Error,
+			}
+			style, exists := styles[name]
+			if !exists {
+				return "", fmt.Errorf("unknown style \"%s\"", name)
+			}
+			return theme.apply(style, text), nil
+		},
+		"message": func(id string, parameters ...any) string { return catalog.format(id, -1, parameters...) },
+	}
+}
+
+// parseHelpTemplate parses a help template on top of the default one, so that it can use the "group"
+// template of DefaultHelpTemplate or redefine it.
+//
+// Parameters:
+//   - text: The text of the help template.
+//   - theme: The theme used by the style function of the template.
+//   - catalog: The catalog used by the message function of the template.
+//
+// Returns:
+//   - The parsed template, or an error if the text is not a valid template.
+func parseHelpTemplate(text string, theme *Theme, catalog *MessageCatalog) (*template.Template, error) {
+	tmpl, err := template.New("help").Funcs(helpTemplateFuncs(theme, catalog)).Parse(DefaultHelpTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if text == DefaultHelpTemplate {
+		return tmpl, nil
+	}
+
+	return tmpl.Parse(text)
+}
+
+// SetHelpTemplate sets the text/template the help message of the parser is rendered with, instead of
+// DefaultHelpTemplate. The template is executed with a HelpData, can use the "group" template of
+// DefaultHelpTemplate, and the functions "indent", "add", "wrap", "style" and "message". The subparsers that did
+// not set their own template use the one of their parent parser.
+//
+// Parameters:
+//   - text: The text of the help template.
+//
+// Returns:
+//   - An error if the text is not a valid template, in which case the template is not changed.
+func (ap *ArgumentsParser) SetHelpTemplate(text string) error {
+	if _, err := parseHelpTemplate(text, &Theme{}, DefaultMessageCatalog); err != nil {
+		return fmt.Errorf("invalid help template: %w", err)
+	}
+	ap.Options.HelpTemplate = text
+
+	return nil
+}
+
+// helpTemplate returns the text of the help template of the parser, which is its own if it set one,
+// or else the one of its parent parser, or else DefaultHelpTemplate.
+//
+// Returns:
+//   - The text of the help template.
+func (ap *ArgumentsParser) helpTemplate() string {
+	for parser := ap; parser != nil; parser = parser.parent {
+		if len(parser.Options.HelpTemplate) != 0 {
+			return parser.Options.HelpTemplate
+		}
+	}
+
+	return DefaultHelpTemplate
+}
+
+// renderHelp renders the help message of the parser with its help template. A template failing to
+// execute, for example by referring to a field that does not exist, falls back to DefaultHelpTemplate
+// so that the help message is still displayed.
+//
+// Parameters:
+//   - data: The data model of the help message.
+//
+// Returns:
+//   - The help message.
+func (ap *ArgumentsParser) renderHelp(data HelpData) string {
+	theme := ap.theme()
+	catalog := ap.messageCatalog()
+
+	output := strings.Builder{}
+	tmpl, err := parseHelpTemplate(ap.helpTemplate(), theme, catalog)
+	if err == nil {
+		err = tmpl.Execute(&output, data)
+	}
+	if err != nil {
+		output.Reset()
+		tmpl, _ = parseHelpTemplate(DefaultHelpTemplate, theme, catalog)
+		tmpl.Execute(&output, data)
+	}
+
+	return output.String()
+}
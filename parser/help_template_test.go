@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// newHelpTemplateParser returns a parser with a subparser, used to render help templates.
+func newHelpTemplateParser() (*ArgumentsParser, *ArgumentsParser) {
+	var mode, target, output string
+	ap := NewParser("test")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetOptHelpWidth(80)
+	ap.SetupSubParsing("mode", &mode, false)
+	scan := ap.AddSubParser("scan", "Scan a target.")
+	scan.NewStringPositionalArgument(&target, "target", "Target.")
+	scan.NewStringArgument(&output, "-o", "--output", "out.txt", false, "Output file.")
+	scan.SetArgumentMetavar("--output", "FILE")
+	scan.populateMaps(&scan.ParsingState)
+
+	return ap, scan
+}
+
+// TestHelpDataModel verifies the data model the help template is rendered with.
+func TestHelpDataModel(t *testing.T) {
+	_, scan := newHelpTemplateParser()
+	data := scan.helpData(2, &ParsingState{RawArguments: []string{"test", "scan"}}, arguments.VISIBILITY_DEFAULT)
+
+	if data.Program != "test" || strings.Join(data.Path, " ") != "scan" {
+		t.Errorf("expected the program \"test\" and the path \"scan\", got %q and %q", data.Program, data.Path)
+	}
+	if data.UsageLine != "Usage: test scan <target> [--output <FILE>]" {
+		t.Errorf("unexpected usage line %q", data.UsageLine)
+	}
+	if len(data.Positionals) != 1 || data.Positionals[0].Placeholder != "<target>" {
+		t.Errorf("expected the positional argument <target>, got %#v", data.Positionals)
+	}
+	if len(data.Arguments) != 1 {
+		t.Fatalf("expected one argument, got %#v", data.Arguments)
+	}
+	argument := data.Arguments[0]
+	if argument.LongName != "--output" || argument.Placeholder != "<FILE>" || argument.TypeName != "string" || argument.DefaultValue != "\"out.txt\"" {
+		t.Errorf("unexpected argument %#v", argument)
+	}
+}
+
+// TestHelpTemplateOverride verifies that a help template can be set per parser, that subparsers use the
+// template of their parent, and that invalid templates are rejected.
+func TestHelpTemplateOverride(t *testing.T) {
+	ap, scan := newHelpTemplateParser()
+	state := &ParsingState{RawArguments: []string{"test", "scan"}}
+
+	if err := ap.SetHelpTemplate("{{.UsageLine"); err == nil {
+		t.Errorf("expected an error for an invalid template")
+	}
+
+	if err := ap.SetHelpTemplate("{{.UsageLine}}\n{{range .Arguments}}{{.LongName}}={{.DefaultValue}}\n{{end}}"); err != nil {
+		t.Fatalf("SetHelpTemplate failed: %v", err)
+	}
+	scan.parent = ap
+	if got := scan.renderHelp(scan.helpData(2, state, arguments.VISIBILITY_DEFAULT)); got != "Usage: test scan <target> [--output <FILE>]\n--output=\"out.txt\"\n" {
+		t.Errorf("expected the subparser to use the template of its parent, got %q", got)
+	}
+
+	if err := scan.SetHelpTemplate("{{range .Groups}}{{template \"group\" .}}{{end}}{{style \"Flag\" \"--output\"}} {{index (wrap 6 \"a long text\") 1}}\n"); err != nil {
+		t.Fatalf("SetHelpTemplate failed: %v", err)
+	}
+	if got := scan.renderHelp(scan.helpData(2, state, arguments.VISIBILITY_DEFAULT)); got != "--output text\n" {
+		t.Errorf("expected the template of the subparser, got %q", got)
+	}
+
+	if err := scan.SetHelpTemplate("{{style \"Unknown\" .Program}}"); err != nil {
+		t.Fatalf("SetHelpTemplate failed: %v", err)
+	}
+	if got := scan.renderHelp(scan.helpData(2, state, arguments.VISIBILITY_DEFAULT)); !strings.HasPrefix(got, "Usage: test scan <target>") {
+		t.Errorf("expected a failing template to fall back to the default one, got %q", got)
+	}
+}
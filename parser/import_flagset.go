@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// ImportFlagSet registers every flag defined on a flag.FlagSet as an argument of the default group
+// of the ArgumentsParser, so that a program using the standard flag package can migrate to goopts
+// incrementally.
+//
+// Each flag becomes a FlagValueArgument sharing the flag.Value of the FlagSet, so the variables
+// bound with fs.String, fs.IntVar, fs.Var and the like keep receiving the parsed values. Flags with
+// a single character name become short flags (e.g., "-v") and the others become long flags
+// (e.g., "--port"). The usage string of each flag becomes its help message, and the value name
+// extracted from it by flag.UnquoteUsage becomes its metavar.
+//
+// Parameters:
+// - fs: The flag.FlagSet whose flags are imported.
+//
+// Returns:
+// - An error if a flag could not be registered, for example because its name is already used.
+func (ap *ArgumentsParser) ImportFlagSet(fs *flag.FlagSet) error {
+	var err error
+
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil {
+			return
+		}
+
+		shortName, longName := "", f.Name
+		if len(f.Name) == 1 {
+			shortName, longName = f.Name, ""
+		}
+
+		valueName, usage := flag.UnquoteUsage(f)
+
+		arg := &arguments.FlagValueArgument{}
+		arg.Init(f.Value, shortName, longName, f.DefValue, false, usage)
+		if len(valueName) != 0 && arg.ExpectsValue() {
+			arg.SetMetavar(valueName)
+		}
+
+		if registerErr := ap.Register(arg); registerErr != nil {
+			err = fmt.Errorf("could not import flag \"%s\": %s", f.Name, registerErr)
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestImportFlagSet verifies that the flags of a flag.FlagSet become arguments of the parser that
+// write to the same variables, with single character names mapped to short flags.
+func TestImportFlagSet(t *testing.T) {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	host := fs.String("host", "localhost", "The `HOSTNAME` to connect to.")
+	port := fs.Int("port", 80, "The port to connect to.")
+	verbose := fs.Bool("v", false, "Verbose output.")
+
+	ap := NewParser("test")
+	if err := ap.ImportFlagSet(fs); err != nil {
+		t.Fatalf("ImportFlagSet failed: %v", err)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"tool", "--host", "example.com", "-v"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if *host != "example.com" {
+		t.Errorf("expected host to be \"example.com\", got %q", *host)
+	}
+	if *port != 80 {
+		t.Errorf("expected port to keep its default value 80, got %d", *port)
+	}
+	if !*verbose {
+		t.Errorf("expected verbose to be true")
+	}
+
+	hostArg, ok := ap.findArgument("--host").(*arguments.FlagValueArgument)
+	if !ok {
+		t.Fatalf("expected --host to be imported as a FlagValueArgument")
+	}
+	if hostArg.GetMetavar() != "HOSTNAME" {
+		t.Errorf("expected the metavar of --host to be \"HOSTNAME\", got %q", hostArg.GetMetavar())
+	}
+	if hostArg.GetHelp() != "The HOSTNAME to connect to." {
+		t.Errorf("expected the help of --host to be unquoted, got %q", hostArg.GetHelp())
+	}
+	if ap.findArgument("-v") == nil {
+		t.Errorf("expected the single character flag to be imported as \"-v\"")
+	}
+
+	// Importing the same flags a second time conflicts with the arguments already registered.
+	if err := ap.ImportFlagSet(fs); err == nil {
+		t.Errorf("expected an error when importing flags whose names are already registered")
+	}
+}
@@ -0,0 +1,327 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// OUTPUT_FORMAT_AUTO uses the output format of the parent parser, and for the top-level parser the
+	// JSON output format when the GOOPTS_OUTPUT environment variable is "json", or else the text one.
+	OUTPUT_FORMAT_AUTO = 0
+
+	// OUTPUT_FORMAT_TEXT displays the usage and error messages for humans.
+	OUTPUT_FORMAT_TEXT = 1
+
+	// OUTPUT_FORMAT_JSON emits the usage and error messages as JSON documents, one per line, for the
+	// programs running the command:
+	//   - The help flags and the help command write a ParserDescription to the standard output, and
+	//     the version flags a document with a "version" field.
+	//   - A help search writes a document with the "term" searched and its "results" to the standard
+	//     output.
+	//   - The errors found while parsing are written as an ErrorReport to the standard error.
+	//   - The warnings are written to the standard error as documents with a "warning" field, unless a
+	//     warning handler is set.
+	OUTPUT_FORMAT_JSON = 2
+)
+
+const (
+	// EXIT_CODE_SUCCESS is the exit code of the program once the help message, a help search with
+	// results or the version has been displayed.
+	EXIT_CODE_SUCCESS = 0
+
+	// EXIT_CODE_USAGE_ERROR is the exit code of the program when the command line has errors, when a
+	// parser with subparsers is given none, and when a help search has no results.
+	EXIT_CODE_USAGE_ERROR = 1
+)
+
+// outputFormatEnvVar is the environment variable selecting the output format of the parsers in
+// OUTPUT_FORMAT_AUTO.
+const outputFormatEnvVar = "GOOPTS_OUTPUT"
+
+// SetOptOutputFormat sets the format of the usage and error messages.
+//
+// Parameters:
+// - outputFormat: One of OUTPUT_FORMAT_AUTO, OUTPUT_FORMAT_TEXT or OUTPUT_FORMAT_JSON.
+func (ap *ArgumentsParser) SetOptOutputFormat(outputFormat int) {
+	ap.Options.OutputFormat = outputFormat
+}
+
+// outputFormat returns the format of the usage and error messages of the parser, which is its own if it
+// set one, or else the one of its parent parser, or else the one selected by the GOOPTS_OUTPUT
+// environment variable.
+//
+// Returns:
+// - OUTPUT_FORMAT_TEXT or OUTPUT_FORMAT_JSON.
+func (ap *ArgumentsParser) outputFormat() int {
+	if ap.Options.OutputFormat != OUTPUT_FORMAT_AUTO {
+		return ap.Options.OutputFormat
+	}
+	if ap.parent != nil {
+		return ap.parent.outputFormat()
+	}
+
+	if strings.EqualFold(os.Getenv(outputFormatEnvVar), "json") {
+		return OUTPUT_FORMAT_JSON
+	}
+
+	return OUTPUT_FORMAT_TEXT
+}
+
+// ErrorReport is the document listing the errors found while parsing in the JSON output format.
+type ErrorReport struct {
+	// Command is the name of the program followed by the names of the subparsers leading to the parser
+	// that found the errors.
+	Command string `json:"command"`
+	// Errors are the errors found while parsing, in the order their messages are displayed.
+	Errors []ParsingError `json:"errors"`
+	// ExitCode is the exit code of the program, EXIT_CODE_USAGE_ERROR.
+	ExitCode int `json:"exit_code"`
+}
+
+// ParserDescription is the description of a parser emitted by the help flags in the JSON output format.
+type ParserDescription struct {
+	// Command is the name of the program followed by the names of the subparsers leading to the parser.
+	Command string `json:"command"`
+	// Aliases are the additional names of the subparser.
+	Aliases []string `json:"aliases"`
+	// Summary is the one-line description of the parser, or its banner when it has none.
+	Summary string `json:"summary"`
+	// Description is the long description of the parser.
+	Description string `json:"description"`
+	// Usage is the usage line of the parser, without its title.
+	Usage string `json:"usage"`
+	// Positionals are the positional arguments of the parser, in the order they are expected.
+	Positionals []PositionalDescription `json:"positionals"`
+	// Arguments are the arguments of the default group, followed by the version flags once the version
+	// is enabled.
+	Arguments []ArgumentDescription `json:"arguments"`
+	// Groups are the named argument groups, in the order they are displayed.
+	Groups []GroupDescription `json:"groups"`
+	// Subcommands are the descriptions of the subparsers, sorted by name.
+	Subcommands []ParserDescription `json:"subcommands"`
+	// Examples are examples of invocations of the program.
+	Examples []Example `json:"examples"`
+	// Epilog is the text displayed at the end of the help message.
+	Epilog string `json:"epilog"`
+}
+
+// PositionalDescription describes a positional argument in a ParserDescription.
+type PositionalDescription struct {
+	// Name is the name of the positional argument.
+	Name string `json:"name"`
+	// Placeholder is the placeholder displayed in the usage line, such as "<target>".
+	Placeholder string `json:"placeholder"`
+	// Help is the help message of the positional argument.
+	Help string `json:"help"`
+	// Grouped indicates whether the positional argument belongs to an argument group, which decides
+	// whether it has to be given.
+	Grouped bool `json:"grouped"`
+}
+
+// ArgumentDescription describes an argument in a ParserDescription.
+type ArgumentDescription struct {
+	// ShortName is the short flag of the argument, or an empty string.
+	ShortName string `json:"short_name"`
+	// LongName is the long flag of the argument, or an empty string.
+	LongName string `json:"long_name"`
+	// Aliases are the additional names of the argument that are not deprecated.
+	Aliases []string `json:"aliases"`
+	// Placeholder is the placeholder of the value of the argument, or an empty string for a flag that
+	// is not followed by a value.
+	Placeholder string `json:"placeholder"`
+	// Type is the name of the type of the value of the argument, such as "string".
+	Type string `json:"type"`
+	// Choices are the values accepted by the argument, or null when any value is accepted.
+	Choices []string `json:"choices"`
+	// Help is the help message of the argument.
+	Help string `json:"help"`
+	// Default is the default value of the argument formatted for display.
+	Default string `json:"default"`
+	// Required indicates whether the argument needs to be given.
+	Required bool `json:"required"`
+	// Repeatable indicates whether the argument can be given several times.
+	Repeatable bool `json:"repeatable"`
+	// EnvVar is the environment variable the value of the argument is read from, or an empty string.
+	EnvVar string `json:"env_var"`
+}
+
+// GroupDescription describes an argument group in a ParserDescription.
+type GroupDescription struct {
+	// Name is the name of the group.
+	Name string `json:"name"`
+	// Description is the description of the group.
+	Description string `json:"description"`
+	// Arguments are the arguments of the group.
+	Arguments []ArgumentDescription `json:"arguments"`
+	// Groups are the nested subgroups of the group.
+	Groups []GroupDescription `json:"groups"`
+}
+
+// Describe returns the description of the parser and of its subparsers, as emitted by the help flags
+// in the JSON output format.
+//
+// Parameters:
+//   - path: The name of the program followed by the names of the subparsers leading to the parser.
+//   - level: The most restricted visibility described, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+//   - The description of the parser.
+func (ap *ArgumentsParser) Describe(path []string, level int) ParserDescription {
+	parsingState := &ParsingState{RawArguments: path}
+	ap.populateMaps(parsingState)
+	data := ap.buildHelpData(len(path), parsingState, documentationWidth, &Theme{}, level)
+
+	description := ParserDescription{
+		Command:     strings.Join(path, " "),
+		Aliases:     []string{},
+		Summary:     data.Summary,
+		Description: data.Description,
+		Usage:       data.Synopsis,
+		Positionals: []PositionalDescription{},
+		Arguments:   describeArguments(data.Arguments),
+		Groups:      describeGroups(data.Groups),
+		Subcommands: []ParserDescription{},
+		Examples:    append([]Example{}, ap.Examples...),
+		Epilog:      data.Epilog,
+	}
+	for _, positional := range data.Positionals {
+		description.Positionals = append(description.Positionals, PositionalDescription(positional))
+	}
+
+	if ap.SubParsers.Enabled {
+		names := []string{}
+		for name := range ap.SubParsers.Parsers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			subparser := ap.SubParsers.Parsers[name]
+			subparser.parent = ap
+			subcommand := subparser.Describe(append(slices.Clone(path), name), level)
+			subcommand.Aliases = ap.SubParsers.AliasesOf(name)
+			description.Subcommands = append(description.Subcommands, subcommand)
+		}
+	}
+
+	return description
+}
+
+// describeArguments converts the arguments of the data model of the help message for a ParserDescription.
+//
+// Parameters:
+// - args: The arguments of the data model of the help message.
+//
+// Returns:
+// - The descriptions of the arguments.
+func describeArguments(args []HelpArgument) []ArgumentDescription {
+	descriptions := []ArgumentDescription{}
+	for _, arg := range args {
+		aliases := append([]string{}, arg.Aliases...)
+		descriptions = append(descriptions, ArgumentDescription{
+			ShortName:   arg.ShortName,
+			LongName:    arg.LongName,
+			Aliases:     aliases,
+			Placeholder: arg.Placeholder,
+			Type:        arg.TypeName,
+			Choices:     arg.Choices,
+			Help:        arg.Help,
+			Default:     arg.DefaultValue,
+			Required:    arg.Required,
+			Repeatable:  arg.Repeatable,
+			EnvVar:      arg.EnvVar,
+		})
+	}
+
+	return descriptions
+}
+
+// describeGroups converts the groups of the data model of the help message for a ParserDescription.
+//
+// Parameters:
+// - groups: The groups of the data model of the help message.
+//
+// Returns:
+// - The descriptions of the groups, along with their subgroups.
+func describeGroups(groups []HelpGroup) []GroupDescription {
+	descriptions := []GroupDescription{}
+	for _, group := range groups {
+		descriptions = append(descriptions, GroupDescription{
+			Name:        group.Name,
+			Description: group.Description,
+			Arguments:   describeArguments(group.Arguments),
+			Groups:      describeGroups(group.SubGroups),
+		})
+	}
+
+	return descriptions
+}
+
+// commandPath returns the name of the program followed by the names of the subparsers leading to the
+// parser whose first raw argument is at an index.
+//
+// Parameters:
+// - index: The index of the first raw argument of the parser.
+// - parsingState: The parsing state holding the raw arguments.
+//
+// Returns:
+// - The name of the program followed by the names of the subparsers.
+func commandPath(index int, parsingState *ParsingState) []string {
+	path := []string{programName(parsingState)}
+	for k := 1; k < index && k < len(parsingState.RawArguments); k++ {
+		path = append(path, parsingState.RawArguments[k])
+	}
+
+	return path
+}
+
+// writeJSON writes a document as JSON on a single line.
+//
+// Parameters:
+// - output: The writer the document is written to.
+// - document: The document to write.
+func writeJSON(output io.Writer, document any) {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		encoded, _ = json.Marshal(map[string]string{"error": err.Error()})
+	}
+	fmt.Fprintf(output, "%s\n", encoded)
+}
+
+// printJSONWarning writes a warning message to the standard error as a JSON document.
+//
+// Parameters:
+// - message: The warning message.
+func printJSONWarning(message string) {
+	writeJSON(os.Stderr, map[string]string{"warning": message})
+}
+
+// exitWithErrors displays the errors recorded in the parsing state and exits with
+// EXIT_CODE_USAGE_ERROR. In the text output format, the usage message of the parser is displayed
+// followed by the error messages, and in the JSON output format an ErrorReport is written to the
+// standard error.
+//
+// Parameters:
+// - index: The index of the first raw argument of the parser.
+// - parsingState: The parsing state holding the raw arguments and the errors.
+func (ap *ArgumentsParser) exitWithErrors(index int, parsingState *ParsingState) {
+	if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+		writeJSON(os.Stderr, ErrorReport{
+			Command:  strings.Join(commandPath(index, parsingState), " "),
+			Errors:   append([]ParsingError{}, parsingState.GetErrors()...),
+			ExitCode: EXIT_CODE_USAGE_ERROR,
+		})
+		os.Exit(EXIT_CODE_USAGE_ERROR)
+	}
+
+	ap.UsageFrom(index, parsingState)
+	theme := ap.theme()
+	for _, errmsg := range parsingState.ErrorMessages {
+		fmt.Printf("%s\n", theme.apply(theme.Error, "[!] "+errmsg))
+	}
+	os.Exit(EXIT_CODE_USAGE_ERROR)
+}
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newJSONParser returns a parser with a "scan" subparser, also selected by "s", which has a required
+// positional argument, a required argument, a deprecated alias and a group.
+func newJSONParser() *ArgumentsParser {
+	var mode, target, user string
+	var port int
+	var verbose, debug bool
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetupSubParsing("mode", &mode, false)
+	scan := ap.AddSubParser("scan", "Scan a host")
+	ap.AddSubParserAlias("scan", "s")
+	scan.NewStringPositionalArgument(&target, "target", "Host to scan.")
+	scan.NewIntArgument(&port, "-p", "--port", 445, false, "Port to scan.")
+	scan.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	scan.AddDeprecatedArgumentAlias("--port", "--tcp-port", "")
+	group, _ := scan.NewNotRequiredMutuallyExclusiveArgumentGroup("Output")
+	group.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose output.")
+	group.NewBoolArgument(&debug, "-d", "--debug", false, "Debug output.")
+	return ap
+}
+
+// TestOutputFormat verifies that the output format is taken from the parser, its parent, or else from
+// the GOOPTS_OUTPUT environment variable.
+func TestOutputFormat(t *testing.T) {
+	t.Setenv("GOOPTS_OUTPUT", "")
+	ap := newJSONParser()
+	sub := ap.SubParsers.GetSubParser("scan")
+	if got := sub.outputFormat(); got != OUTPUT_FORMAT_TEXT {
+		t.Errorf("expected the text output format by default, got %d", got)
+	}
+
+	t.Setenv("GOOPTS_OUTPUT", "JSON")
+	if got := sub.outputFormat(); got != OUTPUT_FORMAT_JSON {
+		t.Errorf("expected the JSON output format from the environment, got %d", got)
+	}
+
+	ap.SetOptOutputFormat(OUTPUT_FORMAT_TEXT)
+	if got := sub.outputFormat(); got != OUTPUT_FORMAT_TEXT {
+		t.Errorf("expected the output format of the parent parser to take precedence over the environment, got %d", got)
+	}
+	sub.SetOptOutputFormat(OUTPUT_FORMAT_JSON)
+	if got := sub.outputFormat(); got != OUTPUT_FORMAT_JSON {
+		t.Errorf("expected the output format of the subparser to take precedence over its parent, got %d", got)
+	}
+}
+
+// TestParsingStateErrors verifies that the errors and the error messages of the parsing state are kept
+// in the same order, including when error messages are appended directly.
+func TestParsingStateErrors(t *testing.T) {
+	ps := &ParsingState{}
+	ps.AddError(ParsingError{Kind: ERROR_KIND_UNKNOWN_ARGUMENT, Token: "--x", Message: "first"})
+	ps.AddErrorMessage("third")
+	ps.insertError(1, ParsingError{Kind: ERROR_KIND_MISSING_POSITIONAL, Argument: "<a>", Message: "second"})
+
+	kinds := []string{}
+	for _, parsingError := range ps.GetErrors() {
+		kinds = append(kinds, parsingError.Kind+":"+parsingError.Message)
+	}
+	if got := strings.Join(kinds, " "); got != "unknown-argument:first missing-positional:second validation:third" {
+		t.Errorf("unexpected errors %q", got)
+	}
+	if got := strings.Join(ps.GetErrorMessages(), " "); got != "first second third" {
+		t.Errorf("unexpected error messages %q", got)
+	}
+
+	ps.ErrorMessages = append(ps.ErrorMessages, "fourth")
+	ps.insertError(10, ParsingError{Kind: ERROR_KIND_MISSING_POSITIONAL, Argument: "<b>", Message: "fifth"})
+	kinds = []string{}
+	for _, parsingError := range ps.GetErrors() {
+		kinds = append(kinds, parsingError.Kind+":"+parsingError.Message)
+	}
+	if got := strings.Join(kinds[3:], " "); got != "validation:fourth missing-positional:fifth" {
+		t.Errorf("expected the error messages appended directly to be described, got %q", got)
+	}
+
+	ps.ClearErrorMessages()
+	if len(ps.GetErrors()) != 0 || len(ps.GetErrorMessages()) != 0 {
+		t.Errorf("expected no errors once cleared, got %v", ps.GetErrors())
+	}
+}
+
+// TestDescribe verifies the description of a parser and of its subparsers.
+func TestDescribe(t *testing.T) {
+	description := newJSONParser().Describe([]string{"tool"}, 0)
+
+	if description.Command != "tool" || description.Usage != "tool <scan>" || len(description.Subcommands) != 1 {
+		t.Fatalf("unexpected description of the parser: %+v", description)
+	}
+	scan := description.Subcommands[0]
+	if scan.Command != "tool scan" || strings.Join(scan.Aliases, ",") != "s" || scan.Summary != "Scan a host" {
+		t.Errorf("unexpected description of the subparser: %+v", scan)
+	}
+	if len(scan.Positionals) != 1 || scan.Positionals[0].Name != "target" {
+		t.Errorf("unexpected positional arguments: %+v", scan.Positionals)
+	}
+	if len(scan.Arguments) != 2 || scan.Arguments[0].LongName != "--port" || scan.Arguments[0].Type != "int" || scan.Arguments[0].Default != "445" || !scan.Arguments[1].Required {
+		t.Errorf("unexpected arguments: %+v", scan.Arguments)
+	}
+	if len(scan.Groups) != 1 || scan.Groups[0].Name != "Output" || len(scan.Groups[0].Arguments) != 2 {
+		t.Errorf("unexpected groups: %+v", scan.Groups)
+	}
+}
+
+// TestJSONOutputSubprocess is the body of the subprocesses started by TestJSONOutput, and does nothing
+// when run directly.
+func TestJSONOutputSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_JSON_OUTPUT_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	ap := newJSONParser()
+	ap.ParsingState.SetRawArguments(append([]string{"tool"}, strings.Fields(scenario)...))
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// runJSONOutput runs a scenario in the JSON output format, and returns its standard output, standard
+// error and exit code.
+func runJSONOutput(t *testing.T, scenario string) (string, string, int) {
+	t.Helper()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	exitCode := runTestSubprocessWithOutputs(t, "TestJSONOutputSubprocess", stdout, stderr, "GOOPTS_JSON_OUTPUT_SUBPROCESS="+scenario, "GOOPTS_OUTPUT=json")
+
+	// The test binary reports on the standard output when the test passes
+	return strings.TrimSuffix(stdout.String(), "PASS\n"), stderr.String(), exitCode
+}
+
+// TestJSONOutput verifies the errors, help and warnings written in the JSON output format, and their
+// exit codes.
+func TestJSONOutput(t *testing.T) {
+	stdout, stderr, exitCode := runJSONOutput(t, "scan host extra --tcp-port abc --verbose -d --unknown")
+	if exitCode != EXIT_CODE_USAGE_ERROR || len(stdout) != 0 {
+		t.Fatalf("expected the errors to exit with code %d and nothing on the standard output, got %d:\n%s", EXIT_CODE_USAGE_ERROR, exitCode, stdout)
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	report := ErrorReport{}
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &report); err != nil {
+		t.Fatalf("expected a JSON error report on the standard error, got %v:\n%s", err, stderr)
+	}
+	if report.Command != "tool scan" || report.ExitCode != EXIT_CODE_USAGE_ERROR {
+		t.Errorf("unexpected error report %+v", report)
+	}
+	errors := []string{}
+	for _, parsingError := range report.Errors {
+		errors = append(errors, strings.Join([]string{parsingError.Kind, parsingError.Argument, parsingError.Token}, "|"))
+	}
+	expected := []string{
+		"unexpected-positional||extra",
+		"invalid-value|--port|--tcp-port",
+		"unknown-argument||--unknown",
+		"missing-required|--user|",
+		"group|--verbose, --debug|",
+	}
+	if strings.Join(errors, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected the errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(errors, "\n"))
+	}
+
+	stdout, _, exitCode = runJSONOutput(t, "s --help")
+	description := ParserDescription{}
+	if err := json.Unmarshal([]byte(stdout), &description); err != nil || exitCode != EXIT_CODE_SUCCESS {
+		t.Fatalf("expected the help to write a JSON description and exit with code %d, got %d and %v:\n%s", EXIT_CODE_SUCCESS, exitCode, err, stdout)
+	}
+	if description.Command != "tool s" || len(description.Arguments) != 2 {
+		t.Errorf("unexpected description %+v", description)
+	}
+
+	_, stderr, exitCode = runJSONOutput(t, " ")
+	if exitCode != EXIT_CODE_USAGE_ERROR || !strings.Contains(stderr, `"kind":"missing-subparser"`) {
+		t.Errorf("expected a missing subparser to be reported, got %d:\n%s", exitCode, stderr)
+	}
+
+	stdout, stderr, exitCode = runJSONOutput(t, "scan host --tcp-port 80 --user admin")
+	if exitCode != 0 || stderr != `{"warning":"Argument \"--tcp-port\" is deprecated, use \"--port\" instead."}`+"\n" {
+		t.Errorf("expected the warning as a JSON document, got %d:\n%s%s", exitCode, stdout, stderr)
+	}
+}
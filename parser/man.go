@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateManPage generates the manual page of the program in the roff format of man(7), documenting
+// the parser and all its subparsers: their synopsis, description, arguments, examples, and the epilog
+// of the parser.
+//
+// Parameters:
+// - name: The name of the program, as it is invoked.
+// - section: The section of the manual the page belongs to, 1 for user commands.
+//
+// Returns:
+// - The manual page, which can be written to a file such as "name.1".
+func (ap *ArgumentsParser) GenerateManPage(name string, section int) string {
+	pages := ap.documentationPages([]string{name})
+	root := pages[0].Data
+
+	output := strings.Builder{}
+	fmt.Fprintf(&output, ".TH \"%s\" \"%d\"\n", manEscape(strings.ToUpper(name)), section)
+
+	output.WriteString(".SH NAME\n")
+	if len(root.Summary) != 0 {
+		fmt.Fprintf(&output, "%s \\- %s\n", manEscape(name), manEscape(root.Summary))
+	} else {
+		fmt.Fprintf(&output, "%s\n", manEscape(name))
+	}
+
+	output.WriteString(".SH SYNOPSIS\n")
+	writeManSynopsis(&output, root)
+
+	if len(root.Description) != 0 {
+		output.WriteString(".SH DESCRIPTION\n")
+		writeManParagraphs(&output, root.Description)
+	}
+
+	if len(root.Arguments) != 0 || len(root.Groups) != 0 {
+		output.WriteString(".SH OPTIONS\n")
+		writeManArguments(&output, root)
+	}
+
+	if len(pages) > 1 {
+		output.WriteString(".SH COMMANDS\n")
+		for _, page := range pages[1:] {
+			fmt.Fprintf(&output, ".SS \"%s\"\n", manEscape(strings.Join(page.Path, " ")))
+			if len(page.Data.Summary) != 0 {
+				fmt.Fprintf(&output, "%s\n", manEscape(page.Data.Summary))
+			}
+			output.WriteString(".PP\n")
+			writeManSynopsis(&output, page.Data)
+			if len(page.Data.Description) != 0 {
+				output.WriteString(".PP\n")
+				writeManParagraphs(&output, page.Data.Description)
+			}
+			writeManArguments(&output, page.Data)
+		}
+	}
+
+	examples := []HelpExample{}
+	for _, page := range pages {
+		examples = append(examples, page.Data.Examples...)
+	}
+	if len(examples) != 0 {
+		output.WriteString(".SH EXAMPLES\n")
+		for _, example := range examples {
+			fmt.Fprintf(&output, ".PP\n.nf\n.RS\n%s\n.RE\n.fi\n", manEscape(example.Command))
+			if len(example.Explanation) != 0 {
+				writeManParagraphs(&output, example.Explanation)
+			}
+		}
+	}
+
+	if len(root.Epilog) != 0 {
+		output.WriteString(".SH NOTES\n")
+		writeManParagraphs(&output, root.Epilog)
+	}
+
+	return output.String()
+}
+
+// writeManSynopsis writes the usage line of a parser to a manual page, the command in bold.
+//
+// Parameters:
+// - output: The manual page being written.
+// - data: The data model of the help message of the parser.
+func writeManSynopsis(output *strings.Builder, data HelpData) {
+	command := strings.Join(append([]string{data.Program}, data.Path...), " ")
+	arguments := strings.TrimPrefix(data.Synopsis, command)
+
+	fmt.Fprintf(output, ".B %s\n", manEscape(command))
+	if arguments = strings.TrimSpace(arguments); len(arguments) != 0 {
+		fmt.Fprintf(output, "%s\n", manEscape(arguments))
+	}
+}
+
+// writeManArguments writes the arguments of a parser to a manual page, the ones of the default group
+// first, then each group under its name.
+//
+// Parameters:
+// - output: The manual page being written.
+// - data: The data model of the help message of the parser.
+func writeManArguments(output *strings.Builder, data HelpData) {
+	for _, arg := range data.Arguments {
+		writeManArgument(output, arg)
+	}
+	for _, group := range data.Groups {
+		writeManGroup(output, group)
+	}
+}
+
+// writeManGroup writes an argument group and its subgroups to a manual page.
+//
+// Parameters:
+// - output: The manual page being written.
+// - group: The argument group of the data model of the help message.
+func writeManGroup(output *strings.Builder, group HelpGroup) {
+	fmt.Fprintf(output, ".PP\n\\fB%s:\\fR\n", manEscape(group.Name))
+	if len(group.Description) != 0 {
+		output.WriteString(".br\n")
+		writeManParagraphs(output, group.Description)
+	}
+	for _, arg := range group.Arguments {
+		writeManArgument(output, arg)
+	}
+	for _, subgroup := range group.SubGroups {
+		writeManGroup(output, subgroup)
+	}
+}
+
+// writeManArgument writes an argument to a manual page as a tagged paragraph, its flags in bold and
+// the placeholder of its value in italics.
+//
+// Parameters:
+// - output: The manual page being written.
+// - arg: The argument of the data model of the help message.
+func writeManArgument(output *strings.Builder, arg HelpArgument) {
+	names := []string{}
+	for _, name := range []string{arg.ShortName, arg.LongName} {
+		if len(name) != 0 {
+			names = append(names, "\\fB"+manEscape(name)+"\\fR")
+		}
+	}
+
+	tag := strings.Join(names, ", ")
+	if len(arg.Placeholder) != 0 {
+		tag += " \\fI" + manEscape(arg.Placeholder) + "\\fR"
+	}
+
+	fmt.Fprintf(output, ".TP\n%s\n", tag)
+	writeManParagraphs(output, arg.DecoratedHelp)
+}
+
+// writeManParagraphs writes a text to a manual page, each of its lines as a paragraph.
+//
+// Parameters:
+// - output: The manual page being written.
+// - text: The text to write.
+func writeManParagraphs(output *strings.Builder, text string) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return
+	}
+
+	for k, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if k != 0 {
+			output.WriteString(".br\n")
+		}
+		fmt.Fprintf(output, "%s\n", manEscape(line))
+	}
+}
+
+// manEscape escapes a text for roff, so that its backslashes and dashes are displayed as they are,
+// and that a line starting with a period or an apostrophe is not read as a request.
+//
+// Parameters:
+// - text: The text to escape.
+//
+// Returns:
+// - The escaped text.
+func manEscape(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\e")
+	text = strings.ReplaceAll(text, "-", "\\-")
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = "\\&" + text
+	}
+
+	return text
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateMarkdown generates the documentation of the program in Markdown, such as for a README or a
+// wiki page, documenting the parser and all its subparsers: their usage, description, arguments and
+// examples, and the epilog of the parser.
+//
+// Parameters:
+// - name: The name of the program, as it is invoked.
+//
+// Returns:
+// - The Markdown documentation.
+func (ap *ArgumentsParser) GenerateMarkdown(name string) string {
+	pages := ap.documentationPages([]string{name})
+
+	output := strings.Builder{}
+	for k, page := range pages {
+		level := "#"
+		if k != 0 {
+			level = "##"
+		}
+		fmt.Fprintf(&output, "%s %s\n\n", level, strings.Join(page.Path, " "))
+		writeMarkdownPage(&output, page.Data)
+	}
+
+	if epilog := pages[0].Data.Epilog; len(epilog) != 0 {
+		fmt.Fprintf(&output, "%s\n", strings.TrimSpace(epilog))
+	}
+
+	return output.String()
+}
+
+// writeMarkdownPage writes the documentation of a parser in Markdown, without its title.
+//
+// Parameters:
+// - output: The documentation being written.
+// - data: The data model of the help message of the parser.
+func writeMarkdownPage(output *strings.Builder, data HelpData) {
+	if len(data.Summary) != 0 {
+		fmt.Fprintf(output, "%s\n\n", data.Summary)
+	}
+
+	fmt.Fprintf(output, "```\n%s\n```\n\n", data.Synopsis)
+
+	if len(data.Description) != 0 {
+		fmt.Fprintf(output, "%s\n\n", strings.TrimSpace(data.Description))
+	}
+
+	if len(data.Subcommands) != 0 {
+		output.WriteString("| Command | Description |\n| --- | --- |\n")
+		for _, subcommand := range data.Subcommands {
+			fmt.Fprintf(output, "| %s | %s |\n", markdownSubcommandNames(subcommand), markdownTableCell(subcommand.Summary))
+		}
+		output.WriteString("\n")
+	}
+
+	if len(data.Arguments) != 0 {
+		writeMarkdownArguments(output, data.Arguments)
+	}
+	for _, group := range data.Groups {
+		writeMarkdownGroup(output, group, "###")
+	}
+
+	if len(data.Examples) != 0 {
+		output.WriteString("**Examples:**\n\n")
+		for _, example := range data.Examples {
+			fmt.Fprintf(output, "```\n%s\n```\n\n", example.Command)
+			if len(example.Explanation) != 0 {
+				fmt.Fprintf(output, "%s\n\n", strings.TrimSpace(example.Explanation))
+			}
+		}
+	}
+}
+
+// markdownSubcommandNames formats the names of a subparser for the table of the subcommands, as the
+// path leading to it from the parser documented, followed by the same path ending with each of its
+// aliases, such as "`remote remove`, `remote rm`".
+//
+// Parameters:
+// - subcommand: The subparser of the data model of the help message.
+//
+// Returns:
+// - The names of the subparser, each one as inline code.
+func markdownSubcommandNames(subcommand HelpSubcommand) string {
+	parent := subcommand.Path[:len(subcommand.Path)-1]
+	names := []string{}
+	for _, name := range append([]string{subcommand.Name}, subcommand.Aliases...) {
+		names = append(names, "`"+markdownTableCell(strings.Join(append(append([]string{}, parent...), name), " "))+"`")
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// writeMarkdownGroup writes an argument group and its subgroups in Markdown, under a heading of the
+// given level, the subgroups one level deeper.
+//
+// Parameters:
+// - output: The documentation being written.
+// - group: The argument group of the data model of the help message.
+// - level: The heading of the group, such as "###".
+func writeMarkdownGroup(output *strings.Builder, group HelpGroup, level string) {
+	fmt.Fprintf(output, "%s %s\n\n", level, group.Name)
+	if len(group.Description) != 0 {
+		fmt.Fprintf(output, "%s\n\n", strings.TrimSpace(group.Description))
+	}
+	if len(group.Arguments) != 0 {
+		writeMarkdownArguments(output, group.Arguments)
+	}
+	for _, subgroup := range group.SubGroups {
+		writeMarkdownGroup(output, subgroup, level+"#")
+	}
+}
+
+// writeMarkdownArguments writes arguments as a Markdown table.
+//
+// Parameters:
+// - output: The documentation being written.
+// - args: The arguments of the data model of the help message.
+func writeMarkdownArguments(output *strings.Builder, args []HelpArgument) {
+	output.WriteString("| Option | Description |\n| --- | --- |\n")
+	for _, arg := range args {
+		fmt.Fprintf(output, "| `%s` | %s |\n", markdownTableCell(argumentDocumentationFlags(arg)), markdownTableCell(arg.DecoratedHelp))
+	}
+	output.WriteString("\n")
+}
+
+// markdownTableCell escapes a text for a cell of a Markdown table, which has to fit on one line and
+// cannot contain an unescaped pipe.
+//
+// Parameters:
+// - text: The text of the cell.
+//
+// Returns:
+// - The escaped text.
+func markdownTableCell(text string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "|", "\\|")
+
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
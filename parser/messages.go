@@ -0,0 +1,337 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// The IDs of the messages of the parser, the keys of a MessageCatalog. The parameters of each message
+// are given in the order documented next to its ID, and a translation can use them in another order
+// with explicit argument indexes, such as "%[2]s".
+const (
+	// MESSAGE_USAGE is the title of the usage line.
+	MESSAGE_USAGE = "usage"
+	// MESSAGE_USAGE_OPTIONS replaces the optional arguments of a usage line wider than the help width.
+	MESSAGE_USAGE_OPTIONS = "usage-options"
+	// MESSAGE_EXAMPLES is the title of the examples section of the help message.
+	MESSAGE_EXAMPLES = "examples"
+	// MESSAGE_HELP_ALL_HINT points to the full help message. Parameters: the help-all flag.
+	MESSAGE_HELP_ALL_HINT = "help-all-hint"
+	// MESSAGE_VERSION_HELP is the help message of the version flags.
+	MESSAGE_VERSION_HELP = "version-help"
+	// MESSAGE_DECORATION_REQUIRED marks a required argument in its help message.
+	MESSAGE_DECORATION_REQUIRED = "decoration-required"
+	// MESSAGE_DECORATION_DEFAULT displays the default value of an argument in its help message.
+	// Parameters: the default value.
+	MESSAGE_DECORATION_DEFAULT = "decoration-default"
+	// MESSAGE_DECORATION_RANGE displays the range of the values of an argument in its help message.
+	// Parameters: the start and the stop of the range.
+	MESSAGE_DECORATION_RANGE = "decoration-range"
+	// MESSAGE_DECORATION_REPEATABLE marks an argument that can be given several times in its help message.
+	MESSAGE_DECORATION_REPEATABLE = "decoration-repeatable"
+	// MESSAGE_DECORATION_ENV_VAR displays the environment variable of an argument in its help message.
+	// Parameters: the environment variable.
+	MESSAGE_DECORATION_ENV_VAR = "decoration-env-var"
+	// MESSAGE_DECORATION_DEPRECATED displays a deprecated alias of an argument in its help message.
+	// Parameters: the alias.
+	MESSAGE_DECORATION_DEPRECATED = "decoration-deprecated"
+	// MESSAGE_UNKNOWN_SUBPARSER reports an unknown subcommand. Parameters: the name of the subcommand.
+	MESSAGE_UNKNOWN_SUBPARSER = "unknown-subparser"
+	// MESSAGE_MISSING_SUBPARSER reports a parser with subparsers given none, in the JSON output format.
+	MESSAGE_MISSING_SUBPARSER = "missing-subparser"
+	// MESSAGE_UNKNOWN_ARGUMENT reports an unknown flag. Parameters: the flag.
+	MESSAGE_UNKNOWN_ARGUMENT = "unknown-argument"
+	// MESSAGE_INVALID_ARGUMENT reports a value an argument failed to parse. Parameters: the error.
+	MESSAGE_INVALID_ARGUMENT = "invalid-argument"
+	// MESSAGE_INVALID_POSITIONAL reports a value a positional argument failed to parse. Parameters: the
+	// name of the positional argument, the error.
+	MESSAGE_INVALID_POSITIONAL = "invalid-positional"
+	// MESSAGE_MISSING_POSITIONALS reports missing positional arguments, counting them. Parameters: the
+	// count, the names of the positional arguments such as "<a> <b>".
+	MESSAGE_MISSING_POSITIONALS = "missing-positionals"
+	// MESSAGE_EXTRA_POSITIONALS reports unexpected positional arguments, counting them. Parameters: the
+	// count, the values such as "\"a\" \"b\"".
+	MESSAGE_EXTRA_POSITIONALS = "extra-positionals"
+	// MESSAGE_MISSING_REQUIRED reports missing required arguments, counting them. Parameters: the
+	// names of the arguments separated by "\", \"".
+	MESSAGE_MISSING_REQUIRED = "missing-required"
+	// MESSAGE_GROUP_ERROR prefixes the error of a constraint with the name of its group. Parameters:
+	// the name of the group, the error.
+	MESSAGE_GROUP_ERROR = "group-error"
+	// MESSAGE_GROUP_NEEDS_ONE reports a group none of the members of which is set, counting the
+	// members. Parameters: the names of the members separated by "\", \"".
+	MESSAGE_GROUP_NEEDS_ONE = "group-needs-one"
+	// MESSAGE_GROUP_EXCLUSIVE reports members of a mutually exclusive group set together. Parameters:
+	// the names of the members separated by "\", \"".
+	MESSAGE_GROUP_EXCLUSIVE = "group-exclusive"
+	// MESSAGE_GROUP_DEPENDENT reports the members of a dependent group that are missing, counting the
+	// members that are set. Parameters: the names of the members set, the names of the members missing.
+	MESSAGE_GROUP_DEPENDENT = "group-dependent"
+	// MESSAGE_GROUP_EXACTLY_N reports a group that does not have exactly N members set, counting N.
+	// Parameters: N, the names of the members, the number of members set.
+	MESSAGE_GROUP_EXACTLY_N = "group-exactly-n"
+	// MESSAGE_GROUP_AT_MOST_N reports a group that has more than N members set, counting N.
+	// Parameters: N, the names of the members set.
+	MESSAGE_GROUP_AT_MOST_N = "group-at-most-n"
+	// MESSAGE_RULE_CONFLICT reports two conflicting arguments set together. Parameters: both arguments.
+	MESSAGE_RULE_CONFLICT = "rule-conflict"
+	// MESSAGE_RULE_REQUIRES reports an argument set without the one it requires. Parameters: the
+	// argument, the argument it requires.
+	MESSAGE_RULE_REQUIRES = "rule-requires"
+	// MESSAGE_RULE_REQUIRED_UNLESS reports an argument missing while the other one is missing too.
+	// Parameters: the argument, the other argument.
+	MESSAGE_RULE_REQUIRED_UNLESS = "rule-required-unless"
+	// MESSAGE_RULE_REQUIRED_IF reports an argument missing while the other one has a given value.
+	// Parameters: the argument, the other argument, the value.
+	MESSAGE_RULE_REQUIRED_IF = "rule-required-if"
+	// MESSAGE_FAILED_CONSTRAINT reports a constraint expression that is not satisfied. Parameters: the
+	// message of the constraint, the expression.
+	MESSAGE_FAILED_CONSTRAINT = "failed-constraint"
+	// MESSAGE_INVALID_VALUE reports a value rejected by a validator. Parameters: the argument, the error.
+	MESSAGE_INVALID_VALUE = "invalid-value"
+	// MESSAGE_ACTION_ERROR reports an action that failed. Parameters: the argument, the error.
+	MESSAGE_ACTION_ERROR = "action-error"
+	// MESSAGE_ENV_NOT_BOOLEAN reports an environment variable of a flag that is not a boolean.
+	// Parameters: the environment variable, its value.
+	MESSAGE_ENV_NOT_BOOLEAN = "env-not-boolean"
+	// MESSAGE_ENV_INVALID reports an environment variable an argument failed to parse. Parameters: the
+	// environment variable, the error.
+	MESSAGE_ENV_INVALID = "env-invalid"
+	// MESSAGE_DEPRECATED_ALIAS warns about the use of a deprecated alias. Parameters: the alias, the hint.
+	MESSAGE_DEPRECATED_ALIAS = "deprecated-alias"
+	// MESSAGE_DEPRECATED_ALIAS_HINT is the hint of a deprecated alias that was given none. Parameters:
+	// the name of the argument.
+	MESSAGE_DEPRECATED_ALIAS_HINT = "deprecated-alias-hint"
+	// MESSAGE_SEARCH_RESULTS is the title of the results of a help search. Parameters: the term.
+	MESSAGE_SEARCH_RESULTS = "search-results"
+	// MESSAGE_SEARCH_NO_RESULTS reports a help search without results. Parameters: the term.
+	MESSAGE_SEARCH_NO_RESULTS = "search-no-results"
+)
+
+// Message is a message of a MessageCatalog, as format strings of the fmt package.
+type Message struct {
+	// One is the text of the message, used for a count taking the singular form in the language of the
+	// catalog, and for the messages that do not count anything.
+	One string
+	// Other is the text of the message for a count taking the plural form, or an empty string when the
+	// message does not count anything.
+	Other string
+}
+
+// MessageCatalog holds the messages of the parser in a language.
+type MessageCatalog struct {
+	// Language is the language of the messages, such as "fr" or "fr_CA", matched against the language
+	// of the parser.
+	Language string
+	// Messages are the messages by ID, the MESSAGE_* constants. The messages that are missing are
+	// taken from DefaultMessageCatalog.
+	Messages map[string]Message
+	// IsPlural reports whether a count takes the plural form in the language of the catalog. When nil,
+	// every count but 1 does, as in English.
+	IsPlural func(count int) bool
+}
+
+// DefaultMessageCatalog holds the English messages of the parser, used when no catalog was registered
+// for its language, and for the messages the catalog of its language is missing.
+var DefaultMessageCatalog = &MessageCatalog{
+	Language: "en",
+	Messages: map[string]Message{
+		MESSAGE_USAGE:                 {One: "Usage:"},
+		MESSAGE_USAGE_OPTIONS:         {One: "[options]"},
+		MESSAGE_EXAMPLES:              {One: "Examples:"},
+		MESSAGE_HELP_ALL_HINT:         {One: "Use %s to display the advanced options."},
+		MESSAGE_VERSION_HELP:          {One: "Show the version and exit."},
+		MESSAGE_DECORATION_REQUIRED:   {One: "required"},
+		MESSAGE_DECORATION_DEFAULT:    {One: "default: %s"},
+		MESSAGE_DECORATION_RANGE:      {One: "range: [%d, %d]"},
+		MESSAGE_DECORATION_REPEATABLE: {One: "repeatable"},
+		MESSAGE_DECORATION_ENV_VAR:    {One: "env: %s"},
+		MESSAGE_DECORATION_DEPRECATED: {One: "deprecated: %s"},
+		MESSAGE_UNKNOWN_SUBPARSER:     {One: "No subparser with name \"%s\" was found."},
+		MESSAGE_MISSING_SUBPARSER:     {One: "A subparser needs to be given."},
+		MESSAGE_UNKNOWN_ARGUMENT:      {One: "Unknown argument \"%s\"."},
+		MESSAGE_INVALID_ARGUMENT:      {One: "Error parsing argument: %s"},
+		MESSAGE_INVALID_POSITIONAL:    {One: "Error parsing positional argument <%s>: %s"},
+		MESSAGE_MISSING_POSITIONALS:   {One: "Missing %d positional argument: %s.", Other: "Missing %d positional arguments: %s."},
+		MESSAGE_EXTRA_POSITIONALS:     {One: "Got %d more positional argument than expected: %s.", Other: "Got %d more positional arguments than expected: %s."},
+		MESSAGE_MISSING_REQUIRED:      {One: "Missing required argument \"%s\"", Other: "Missing required arguments \"%s\""},
+		MESSAGE_GROUP_ERROR:           {One: "%s: %s"},
+		MESSAGE_GROUP_NEEDS_ONE:       {One: "the argument \"%s\" needs to be set.", Other: "at least one of the arguments \"%s\" needs to be set."},
+		MESSAGE_GROUP_EXCLUSIVE:       {One: "arguments \"%s\" cannot be set together."},
+		MESSAGE_GROUP_DEPENDENT:       {One: "when argument \"%s\" is set, \"%s\" need to be set too.", Other: "when arguments \"%s\" are set, \"%s\" need to be set too."},
+		MESSAGE_GROUP_EXACTLY_N:       {One: "exactly %d of the arguments \"%s\" needs to be set, got %d.", Other: "exactly %d of the arguments \"%s\" need to be set, got %d."},
+		MESSAGE_GROUP_AT_MOST_N:       {One: "at most %d of the arguments \"%s\" can be set together."},
+		MESSAGE_RULE_CONFLICT:         {One: "arguments \"%s\" and \"%s\" cannot be set together."},
+		MESSAGE_RULE_REQUIRES:         {One: "when argument \"%s\" is set, \"%s\" needs to be set too."},
+		MESSAGE_RULE_REQUIRED_UNLESS:  {One: "the argument \"%s\" needs to be set unless \"%s\" is set."},
+		MESSAGE_RULE_REQUIRED_IF:      {One: "the argument \"%s\" needs to be set when \"%s\" is \"%s\"."},
+		MESSAGE_FAILED_CONSTRAINT:     {One: "%s (failed constraint: %s)"},
+		MESSAGE_INVALID_VALUE:         {One: "Invalid value for argument \"%s\": %s"},
+		MESSAGE_ACTION_ERROR:          {One: "Error running action \"%s\": %s"},
+		MESSAGE_ENV_NOT_BOOLEAN:       {One: "Error parsing environment variable \"%s\": \"%s\" is not a boolean value."},
+		MESSAGE_ENV_INVALID:           {One: "Error parsing environment variable \"%s\": %s"},
+		MESSAGE_DEPRECATED_ALIAS:      {One: "Argument \"%s\" is deprecated, %s."},
+		MESSAGE_DEPRECATED_ALIAS_HINT: {One: "use \"%s\" instead"},
+		MESSAGE_SEARCH_RESULTS:        {One: "Search results for \"%s\":"},
+		MESSAGE_SEARCH_NO_RESULTS:     {One: "Nothing matches \"%s\"."},
+	},
+}
+
+// AddMessageCatalog registers the messages of the parser in a language, which are used when it is the
+// language of the parser, see SetLanguage. The subparsers use the catalogs of their parent parsers as
+// well.
+//
+// Parameters:
+// - catalog: The messages in a language, replacing the catalog previously registered for it, if any.
+func (ap *ArgumentsParser) AddMessageCatalog(catalog *MessageCatalog) {
+	ap.Options.MessageCatalogs = append(ap.Options.MessageCatalogs, catalog)
+}
+
+// SetLanguage sets the language of the messages of the parser, such as "fr" or "fr_CA", instead of the
+// one read from the LC_ALL, LC_MESSAGES and LANG environment variables. The subparsers that did not set
+// their own use the language of their parent parser.
+//
+// Parameters:
+// - language: The language of the messages, or an empty string to read it from the environment again.
+func (ap *ArgumentsParser) SetLanguage(language string) {
+	ap.Options.Language = language
+}
+
+// language returns the language of the messages of the parser, which is its own if it set one, or else
+// the one of its parent parser, or else the first of the LC_ALL, LC_MESSAGES and LANG environment
+// variables that is set.
+//
+// Returns:
+// - The language of the messages, or an empty string when none is set.
+func (ap *ArgumentsParser) language() string {
+	if len(ap.Options.Language) != 0 {
+		return ap.Options.Language
+	}
+	if ap.parent != nil {
+		return ap.parent.language()
+	}
+
+	for _, variable := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(variable); len(value) != 0 {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// normalizeLanguage returns a language without its encoding and modifier, in lower case and with "-"
+// replaced by "_", such as "fr_fr" for "fr_FR.UTF-8" or "fr-FR".
+//
+// Parameters:
+// - language: The language, as set with SetLanguage, in a locale environment variable or in a catalog.
+//
+// Returns:
+// - The normalized language.
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.ReplaceAll(language, "-", "_"))
+	if end := strings.IndexAny(language, ".@"); end >= 0 {
+		language = language[:end]
+	}
+
+	return language
+}
+
+// languageCandidates returns the languages a catalog is looked up for, the most specific first, such
+// as "fr_fr" then "fr" for "fr_FR.UTF-8".
+//
+// Parameters:
+// - language: The language, as set with SetLanguage or in a locale environment variable.
+//
+// Returns:
+// - The normalized languages to look up, in order.
+func languageCandidates(language string) []string {
+	language = normalizeLanguage(language)
+	if len(language) == 0 {
+		return []string{}
+	}
+
+	candidates := []string{language}
+	if territory := strings.Index(language, "_"); territory > 0 {
+		candidates = append(candidates, language[:territory])
+	}
+
+	return candidates
+}
+
+// messageCatalog returns the catalog of the language of the parser, looked up in the catalogs of the
+// parser and then of its parent parsers, the most recently registered first.
+//
+// Returns:
+// - The catalog of the language of the parser, or DefaultMessageCatalog when none was registered.
+func (ap *ArgumentsParser) messageCatalog() *MessageCatalog {
+	for _, candidate := range languageCandidates(ap.language()) {
+		for parser := ap; parser != nil; parser = parser.parent {
+			for k := len(parser.Options.MessageCatalogs) - 1; k >= 0; k-- {
+				catalog := parser.Options.MessageCatalogs[k]
+				if normalizeLanguage(catalog.Language) == candidate {
+					return catalog
+				}
+			}
+		}
+	}
+
+	return DefaultMessageCatalog
+}
+
+// message formats a message of the parser in its language.
+//
+// Parameters:
+// - id: The ID of the message, one of the MESSAGE_* constants.
+// - parameters: The parameters of the message.
+//
+// Returns:
+// - The formatted message.
+func (ap *ArgumentsParser) message(id string, parameters ...any) string {
+	return ap.messageCatalog().format(id, -1, parameters...)
+}
+
+// pluralMessage formats a message of the parser counting something in its language, taking the
+// singular or plural form of the message depending on the count.
+//
+// Parameters:
+// - id: The ID of the message, one of the MESSAGE_* constants.
+// - count: The count the form of the message depends on.
+// - parameters: The parameters of the message.
+//
+// Returns:
+// - The formatted message.
+func (ap *ArgumentsParser) pluralMessage(id string, count int, parameters ...any) string {
+	return ap.messageCatalog().format(id, count, parameters...)
+}
+
+// format formats a message of the catalog, taken from DefaultMessageCatalog when the catalog is
+// missing it.
+//
+// Parameters:
+// - id: The ID of the message, one of the MESSAGE_* constants.
+// - count: The count the form of the message depends on, or -1 when the message counts nothing.
+// - parameters: The parameters of the message.
+//
+// Returns:
+// - The formatted message.
+func (catalog *MessageCatalog) format(id string, count int, parameters ...any) string {
+	msg, exists := catalog.Messages[id]
+	if !exists && catalog != DefaultMessageCatalog {
+		return DefaultMessageCatalog.format(id, count, parameters...)
+	}
+
+	text := msg.One
+	if count >= 0 && len(msg.Other) != 0 {
+		isPlural := catalog.IsPlural
+		if isPlural == nil {
+			isPlural = func(count int) bool { return count != 1 }
+		}
+		if isPlural(count) {
+			text = msg.Other
+		}
+	}
+
+	return fmt.Sprintf(text, parameters...)
+}
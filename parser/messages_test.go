@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// newFrenchCatalog returns a partial French catalog, which counts 0 as singular.
+func newFrenchCatalog() *MessageCatalog {
+	return &MessageCatalog{
+		Language: "fr",
+		Messages: map[string]Message{
+			MESSAGE_USAGE:               {One: "Utilisation :"},
+			MESSAGE_UNKNOWN_ARGUMENT:    {One: "Argument inconnu « %s »."},
+			MESSAGE_MISSING_POSITIONALS: {One: "%d argument positionnel manquant : %s.", Other: "%d arguments positionnels manquants : %s."},
+			MESSAGE_GROUP_ERROR:         {One: "%s : %s"},
+			MESSAGE_RULE_CONFLICT:       {One: "les arguments « %[2]s » et « %[1]s » sont incompatibles."},
+		},
+		IsPlural: func(count int) bool { return count > 1 },
+	}
+}
+
+// TestLanguageCandidates verifies that the languages are normalized and fall back to the language
+// without its territory.
+func TestLanguageCandidates(t *testing.T) {
+	for language, expected := range map[string]string{
+		"fr_FR.UTF-8":    "fr_fr fr",
+		"fr-CA":          "fr_ca fr",
+		"de_DE@euro":     "de_de de",
+		"en":             "en",
+		"C.UTF-8":        "c",
+		"":               "",
+		"pt_BR.utf8@foo": "pt_br pt",
+	} {
+		if got := strings.Join(languageCandidates(language), " "); got != expected {
+			t.Errorf("expected the candidates of %q to be %q, got %q", language, expected, got)
+		}
+	}
+}
+
+// TestMessageCatalogSelection verifies that the catalog is selected from the option of the parser, of
+// its parent, or else from the environment, and that English is used when no catalog matches.
+func TestMessageCatalogSelection(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	ap := NewParser("Tool")
+	if got := ap.message(MESSAGE_UNKNOWN_ARGUMENT, "--x"); got != "Unknown argument \"--x\"." {
+		t.Errorf("expected the English message without a French catalog, got %q", got)
+	}
+
+	ap.AddMessageCatalog(newFrenchCatalog())
+	sub := ap.AddSubParser("scan", "Scan")
+	if got := sub.message(MESSAGE_UNKNOWN_ARGUMENT, "--x"); got != "Argument inconnu « --x »." {
+		t.Errorf("expected the French message from LANG and the catalog of the parent, got %q", got)
+	}
+	if got := sub.message(MESSAGE_UNKNOWN_SUBPARSER, "x"); got != "No subparser with name \"x\" was found." {
+		t.Errorf("expected the English message when the catalog is missing it, got %q", got)
+	}
+
+	t.Setenv("LC_MESSAGES", "en_US")
+	if got := sub.message(MESSAGE_USAGE); got != "Usage:" {
+		t.Errorf("expected LC_MESSAGES to take precedence over LANG, got %q", got)
+	}
+
+	ap.SetLanguage("fr")
+	if got := sub.message(MESSAGE_USAGE); got != "Utilisation :" {
+		t.Errorf("expected the language of the parent parser to take precedence over the environment, got %q", got)
+	}
+	sub.SetLanguage("en")
+	if got := sub.message(MESSAGE_USAGE); got != "Usage:" {
+		t.Errorf("expected the language of the subparser to take precedence over its parent, got %q", got)
+	}
+}
+
+// TestPluralMessages verifies that the form of a message depends on the count and on the language.
+func TestPluralMessages(t *testing.T) {
+	ap := NewParser("Tool")
+	for count, expected := range map[int]string{
+		0: "Missing 0 positional arguments: .",
+		1: "Missing 1 positional argument: <a>.",
+		2: "Missing 2 positional arguments: <a> <b>.",
+	} {
+		names := []string{"", "<a>", "<a> <b>"}[count]
+		if got := ap.pluralMessage(MESSAGE_MISSING_POSITIONALS, count, count, names); got != expected {
+			t.Errorf("expected %q in English for %d, got %q", expected, count, got)
+		}
+	}
+
+	ap.AddMessageCatalog(newFrenchCatalog())
+	ap.SetLanguage("fr")
+	for count, expected := range map[int]string{
+		0: "0 argument positionnel manquant : .",
+		1: "1 argument positionnel manquant : <a>.",
+		2: "2 arguments positionnels manquants : <a> <b>.",
+	} {
+		names := []string{"", "<a>", "<a> <b>"}[count]
+		if got := ap.pluralMessage(MESSAGE_MISSING_POSITIONALS, count, count, names); got != expected {
+			t.Errorf("expected %q in French for %d, got %q", expected, count, got)
+		}
+	}
+}
+
+// TestLocalizedParsingErrorsSubprocess is the body of the subprocess started by
+// TestLocalizedParsingErrors, and does nothing when run directly.
+func TestLocalizedParsingErrorsSubprocess(t *testing.T) {
+	if len(os.Getenv("GOOPTS_MESSAGES_SUBPROCESS")) == 0 {
+		return
+	}
+
+	var first, second bool
+	var target string
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.AddMessageCatalog(newFrenchCatalog())
+	ap.SetLanguage("fr_FR")
+	ap.NewStringPositionalArgument(&target, "target", "Target.")
+	group, _ := ap.NewNotRequiredMutuallyExclusiveArgumentGroup("Mode")
+	group.NewBoolArgument(&first, "-a", "--first", false, "First.")
+	group.NewBoolArgument(&second, "-b", "--second", false, "Second.")
+	ap.AddConflict("--first", "--second")
+	ap.ParsingState.SetRawArguments([]string{"tool", "host", "--first", "--second", "--third"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// TestLocalizedParsingErrors verifies the usage line and the errors of the parser in another language,
+// including reordered parameters, group names and the messages missing from the catalog.
+func TestLocalizedParsingErrors(t *testing.T) {
+	out, code := runTestSubprocess(t, "TestLocalizedParsingErrorsSubprocess", "GOOPTS_MESSAGES_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d:\n%s", code, out)
+	}
+
+	for _, expected := range []string{
+		"Utilisation : tool <target>",
+		"[!] Argument inconnu « --third ».\n" +
+			"[!] Mode : arguments \"--first\", \"--second\" cannot be set together.\n" +
+			"[!] les arguments « --second » et « --first » sont incompatibles.\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected the output to contain:\n%s\ngot:\n%s", expected, out)
+		}
+	}
+}
+
+// TestLocalizedHelp verifies the usage line, the decorations of the arguments and the help template
+// functions in another language.
+func TestLocalizedHelp(t *testing.T) {
+	var port, retries int
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.AddMessageCatalog(&MessageCatalog{
+		Language: "fr",
+		Messages: map[string]Message{
+			MESSAGE_USAGE:              {One: "Utilisation :"},
+			MESSAGE_HELP_ALL_HINT:      {One: "Utilisez %s pour afficher les options avancées."},
+			MESSAGE_DECORATION_DEFAULT: {One: "défaut : %s"},
+			MESSAGE_DECORATION_RANGE:   {One: "intervalle : [%d, %d]"},
+		},
+	})
+	ap.SetLanguage("fr")
+	ap.Flag("--port").Help("Port.").Advanced().Int(&port)
+	ap.Flag("--retries").Help("Retries.").Default(3).IntRange(&retries, 1, 5)
+
+	help := ap.renderHelp(ap.helpData(1, &ParsingState{RawArguments: []string{"tool"}}, arguments.VISIBILITY_DEFAULT))
+	for _, expected := range []string{"Utilisation : tool", "Retries. (défaut : 3, intervalle : [1, 5])", "Utilisez --help-all pour afficher les options avancées."} {
+		if !strings.Contains(help, expected) {
+			t.Errorf("expected the help message to contain %q, got:\n%s", expected, help)
+		}
+	}
+}
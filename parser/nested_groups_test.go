@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+)
+
+// newNestedGroupsParser returns a parser whose groups are created in non-alphabetical order, with an
+// exclusive "Authentication" group holding two dependent subgroups.
+func newNestedGroupsParser() *ArgumentsParser {
+	var user, password, aesKey, ccache, host, output string
+	var port int
+	ap := NewParser("test")
+
+	auth, _ := ap.NewRequiredMutuallyExclusiveArgumentGroup("Authentication")
+	auth.Description = "Credentials used to authenticate."
+	ntlm, _ := auth.NewSubGroup("NTLM", argumentgroup.ARGUMENT_GROUP_TYPE_DEPENDENT, 0)
+	ntlm.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	ntlm.NewStringArgument(&password, "-p", "--password", "", true, "Password.")
+	kerberos, _ := auth.NewSubGroup("Kerberos", argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE, 0)
+	kerberos.NewStringArgument(&aesKey, "", "--aes-key", "", false, "AES key.")
+	kerberos.NewStringArgument(&ccache, "", "--ccache", "", false, "Credential cache.")
+
+	connection, _ := ap.NewArgumentGroup("Connection")
+	connection.NewStringArgument(&host, "", "--host", "", true, "Host.")
+	connection.NewIntArgument(&port, "", "--port", 445, false, "Port.")
+
+	out, _ := ap.NewArgumentGroup("Output")
+	out.NewStringArgument(&output, "-o", "--output", "", false, "Output file.")
+
+	return ap
+}
+
+// TestOrderedGroupNames verifies that groups are ordered by Order, then in the order they were created.
+func TestOrderedGroupNames(t *testing.T) {
+	ap := newNestedGroupsParser()
+	if got := strings.Join(ap.orderedGroupNames(), ","); got != "Authentication,Connection,Output" {
+		t.Errorf("expected groups in creation order, got %q", got)
+	}
+
+	ap.Groups["Output"].Order = -1
+	if got := strings.Join(ap.orderedGroupNames(), ","); got != "Output,Authentication,Connection" {
+		t.Errorf("expected the group with the lowest Order first, got %q", got)
+	}
+}
+
+// TestNestedGroupsAreParsed verifies that the arguments of subgroups are parsed, and that a subgroup
+// counts as a single member of the constraint of its parent group.
+func TestNestedGroupsAreParsed(t *testing.T) {
+	ap := newNestedGroupsParser()
+	ap.ParsingState.SetRawArguments([]string{"test", "--host", "dc01", "-u", "alice", "-p", "secret"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if len(ap.ParsingState.ErrorMessages) != 0 {
+		t.Errorf("expected no error messages, got %v", ap.ParsingState.ErrorMessages)
+	}
+	if !ap.ArgumentIsPresent("--password") {
+		t.Errorf("expected the argument of a subgroup to be parsed")
+	}
+}
+
+// TestNestedGroupsInHelp verifies that groups are displayed in creation order with their
+// description, and that subgroups are indented under their parent.
+func TestNestedGroupsInHelp(t *testing.T) {
+	if os.Getenv("GOOPTS_NESTED_GROUPS_SUBPROCESS") == "1" {
+		ap := newNestedGroupsParser()
+		ap.ParsingState.SetRawArguments([]string{"test"})
+		ap.populateMaps(&ap.ParsingState)
+		ap.UsageFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestNestedGroupsInHelp", "GOOPTS_NESTED_GROUPS_SUBPROCESS=1", "COLUMNS=200")
+	if code != 0 {
+		t.Fatalf("expected the usage to be printed without error, got exit code %d", code)
+	}
+
+	expected := "Usage: test ((--user <string> --password <string>) | {--aes-key <string>,--ccache <string>}+) --host <string> [--port <int>] [--output <string>]\n" +
+		"\n" +
+		"\n" +
+		"  Authentication:\n" +
+		"    Credentials used to authenticate.\n" +
+		"\n" +
+		"    NTLM:\n" +
+		"      -u, --user <string>     User. (required)\n" +
+		"      -p, --password <string> Password. (required)\n" +
+		"\n" +
+		"    Kerberos:\n" +
+		"      --aes-key <string>      AES key. (default: \"\")\n" +
+		"      --ccache <string>       Credential cache. (default: \"\")\n" +
+		"\n" +
+		"  Connection:\n"
+	if !strings.Contains(out, expected) {
+		t.Fatalf("expected output to contain:\n%s\ngot:\n%s", expected, out)
+	}
+	if strings.Index(out, "  Connection:") > strings.Index(out, "  Output:") {
+		t.Errorf("expected \"Connection\" before \"Output\", got:\n%s", out)
+	}
+}
+
+// TestNestedGroupsErrorsAreReported verifies that a parent group reports its subgroups as units, and
+// that the constraints of the subgroups are checked as well.
+func TestNestedGroupsErrorsAreReported(t *testing.T) {
+	if os.Getenv("GOOPTS_NESTED_GROUPS_SUBPROCESS") == "1" {
+		ap := newNestedGroupsParser()
+		ap.ParsingState.SetRawArguments([]string{"test", "--host", "dc01", "-u", "alice", "--ccache", "a.ccache"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestNestedGroupsErrorsAreReported", "GOOPTS_NESTED_GROUPS_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d", code)
+	}
+
+	expected := "[!] Authentication: arguments \"(--user, --password)\", \"(--aes-key, --ccache)\" cannot be set together.\n" +
+		"[!] NTLM: when argument \"--user\" is set, \"--password\" need to be set too.\n"
+	if !strings.Contains(out, expected) {
+		t.Fatalf("expected output to contain:\n%s\ngot:\n%s", expected, out)
+	}
+}
@@ -5,31 +5,58 @@ import (
 	"os"
 	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/TheManticoreProject/goopts/argumentgroup"
 	"github.com/TheManticoreProject/goopts/arguments"
 	"github.com/TheManticoreProject/goopts/positionals"
 )
 
-// isMutuallyExclusiveGroup reports whether a group type enforces mutual exclusivity between its
-// members, in which case the group rule alone decides whether a member has to be set.
+// groupDecidesPresence reports whether a group type constrains the number of its members that are
+// set, in which case the group rule alone decides whether a member has to be set.
 //
 // Parameters:
 //   - groupType: The type of the argument group.
 //
 // Returns:
-// - true for the required and not required mutually exclusive group types, false otherwise.
-func isMutuallyExclusiveGroup(groupType int) bool {
-	return groupType == argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE ||
-		groupType == argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE
+// - true for the mutually exclusive, at-least-one, exactly-N and at-most-N group types, false otherwise.
+func groupDecidesPresence(groupType int) bool {
+	switch groupType {
+	case argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE,
+		argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE,
+		argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE,
+		argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N,
+		argumentgroup.ARGUMENT_GROUP_TYPE_AT_MOST_N:
+		return true
+	}
+
+	return false
+}
+
+// groupedPositionalNames returns the names of the positional arguments that take part in the
+// constraint of an argument group. Whether they have to be given is decided by their group, so they
+// are not reported as missing on their own.
+//
+// Returns:
+//   - A set of the names of the positional arguments that belong to a group.
+func (ap *ArgumentsParser) groupedPositionalNames() map[string]bool {
+	names := make(map[string]bool)
+	for _, group := range ap.Groups {
+		for _, posarg := range group.AllPositionals() {
+			names[posarg.GetName()] = true
+		}
+	}
+
+	return names
 }
 
 // sortedGroupNames returns the names of all argument groups sorted alphabetically, including the
 // empty name of the default group.
 //
 // Iterating groups through this method instead of ranging over the `Groups` map keeps every output
-// derived from that iteration reproducible, since Go randomizes map iteration order. It also matches
-// the alphabetical group ordering used by the usage message.
+// derived from that iteration reproducible, since Go randomizes map iteration order. The usage message
+// displays the groups in their own order instead, see orderedGroupNames.
 //
 // Returns:
 // - A slice of group names sorted alphabetically.
@@ -43,6 +70,43 @@ func (ap *ArgumentsParser) sortedGroupNames() []string {
 	return groupNames
 }
 
+// registerGroupArguments adds the arguments of a group and of its subgroups to the lookup maps, under
+// their names and their aliases, and to the per-parse slices of the parser.
+//
+// Parameters:
+//   - group: The argument group whose arguments are registered.
+//   - decidedByParent: Whether a parent group already decides whether the members of the group are set.
+func (ap *ArgumentsParser) registerGroupArguments(group *argumentgroup.ArgumentGroup, decidedByParent bool) {
+	decided := decidedByParent || groupDecidesPresence(group.Type)
+
+	for _, arg := range group.Arguments {
+		if shortName := arg.GetShortName(); shortName != "" {
+			ap.shortNameToArgument[shortName] = arg
+		}
+		if longName := arg.GetLongName(); longName != "" {
+			ap.longNameToArgument[longName] = arg
+		}
+		for _, alias := range argumentAliases(arg) {
+			if strings.HasPrefix(alias.Name, "--") {
+				ap.longNameToArgument[alias.Name] = arg
+			} else {
+				ap.shortNameToArgument[alias.Name] = arg
+			}
+		}
+		// In a group constraining how many of its members are set, whether a member has to
+		// be set is decided by the group rule, so its individual required flag is not enforced
+		// on its own: doing so would contradict the group and make every other member unusable
+		if arg.IsRequired() && !decided {
+			ap.requiredArguments = append(ap.requiredArguments, arg)
+		}
+		ap.allArguments = append(ap.allArguments, arg)
+	}
+
+	for _, subgroup := range group.SubGroups {
+		ap.registerGroupArguments(subgroup, decided)
+	}
+}
+
 // formatGroupErrorMessage builds the error message reported for an unsatisfied argument group
 // constraint, prefixed with the name of the group the constraint belongs to.
 //
@@ -57,18 +121,20 @@ func (ap *ArgumentsParser) sortedGroupNames() []string {
 //     group name.
 //
 // Returns:
-//   - The message prefixed with the group name, or the message alone with its first letter
-//     capitalized when the group has no name.
-func formatGroupErrorMessage(groupName, message string) string {
+//   - The message prefixed with the group name as set by the MESSAGE_GROUP_ERROR message of the
+//     language of the parser, or the message alone with its first letter capitalized when the group
+//     has no name.
+func (ap *ArgumentsParser) formatGroupErrorMessage(groupName, message string) string {
 	if len(groupName) == 0 {
 		if len(message) == 0 {
 			return message
 		}
 
-		return strings.ToUpper(message[:1]) + message[1:]
+		first, size := utf8.DecodeRuneInString(message)
+		return string(unicode.ToUpper(first)) + message[size:]
 	}
 
-	return fmt.Sprintf("%s: %s", groupName, message)
+	return ap.message(MESSAGE_GROUP_ERROR, groupName, message)
 }
 
 // populateMaps initializes the maps that store the associations between short and long argument names
@@ -80,9 +146,10 @@ func formatGroupErrorMessage(groupName, message string) string {
 //     are up-to-date.
 //   - Registers arguments from the default argument group, storing their short and long names in the
 //     respective maps. If an argument is required, it adds it to the `requiredArguments` slice, unless
-//     it belongs to a mutually exclusive group, where the group rule decides whether it has to be set.
-//   - Registers arguments from all named subgroups in the `Groups` map, similarly storing their names
-//     and tracking required arguments.
+//     it belongs to a group constraining how many of its members are set, where the group rule
+//     decides whether it has to be set.
+//   - Registers arguments from all named groups in the `Groups` map and from their nested subgroups,
+//     similarly storing their names and tracking required arguments.
 //   - Initializes the `ParsedArguments` maps of `parsingState`, which is the state parsing results are
 //     recorded into and is not necessarily the parser's own `ParsingState`.
 //
@@ -112,22 +179,7 @@ func (ap *ArgumentsParser) populateMaps(parsingState *ParsingState) {
 	// Register arguments from every group exactly once (the default group is keyed by ""),
 	// in a stable order so that error messages built from these slices are reproducible
 	for _, groupName := range ap.sortedGroupNames() {
-		group := ap.Groups[groupName]
-		for _, arg := range group.Arguments {
-			if shortName := arg.GetShortName(); shortName != "" {
-				ap.shortNameToArgument[shortName] = arg
-			}
-			if longName := arg.GetLongName(); longName != "" {
-				ap.longNameToArgument[longName] = arg
-			}
-			// In a mutually exclusive group, whether a member has to be set is decided by the
-			// group rule, so its individual required flag is not enforced on its own: doing so
-			// would contradict the group and make every other member unusable
-			if arg.IsRequired() && !isMutuallyExclusiveGroup(group.Type) {
-				ap.requiredArguments = append(ap.requiredArguments, arg)
-			}
-			ap.allArguments = append(ap.allArguments, arg)
-		}
+		ap.registerGroupArguments(ap.Groups[groupName], false)
 	}
 
 	// Initialize the maps of the parsing state that will be written to during this parse,
@@ -146,16 +198,33 @@ func (ap *ArgumentsParser) populateMaps(parsingState *ParsingState) {
 // Parse processes the command-line arguments and sets the values for the defined arguments.
 // This method handles both positional and named arguments, supports flags with values
 // specified using "=", and checks for missing or unexpected arguments. It also provides
-// a usage message if the "-h" or "--help" flags are present.
+// a usage message if one of the help flags, "-h" or "--help" by default, is present.
 //
 // Behavior:
 //   - Populates maps for quick lookup of arguments based on their short and long names.
 //   - Splits input arguments on "=" to allow for flags like "--key=value".
-//   - Detects the presence of help flags ("-h" or "--help") and displays usage information.
+//   - Detects the presence of help flags ("-h" or "--help" unless configured with SetHelpFlags) where a
+//     flag is expected, and displays usage information. With subparsers, "help <subcommand>" displays
+//     the usage information of the subcommand. A term following a help flag, as in "--help <term>", or
+//     "help search <term>" with subparsers, is searched in the arguments and subparsers of the whole
+//     tree of parsers instead.
+//   - Detects the presence of the version flags, once enabled with SetVersion, and prints the version
+//     before the required arguments and the argument groups are checked.
 //   - Separates positional arguments from named arguments based on the order of inputs.
 //   - Validates that all required positional and named arguments are provided and parses them.
 //   - Reports an error for any argument starting with "-" that matches no registered short or long name.
-//   - Displays error messages for missing, unknown or extra arguments and exits if any errors are detected.
+//   - Sets the arguments that were not given on the command line from their environment variable, if any.
+//   - Runs the actions of the action arguments that were given, in the order they were given. When one of
+//     them exits once its action has run, the missing arguments and the argument groups are not checked;
+//     otherwise the actions run once all the checks have passed.
+//   - Checks the rules between arguments added with AddConflict, AddRequires, AddRequiredUnless and
+//     AddRequiredIf after the argument group constraints, in the order they were added, then the
+//     constraint expressions added with AddConstraint.
+//   - Runs the validators of the arguments that were set, then the validation hooks of the parser after
+//     the argument group constraints have been checked, reporting their errors with the other ones.
+//   - Displays error messages for missing, unknown or extra arguments and exits with EXIT_CODE_USAGE_ERROR if
+//     any errors are detected. In the JSON output format, see SetOptOutputFormat, the errors are written as an
+//     ErrorReport to the standard error, and the help flags write a ParserDescription instead of the usage.
 //
 // Note:
 //
@@ -180,15 +249,23 @@ func (ap *ArgumentsParser) ParseFrom(index int, parsingState *ParsingState) {
 	if ap.SubParsers.Enabled && len(ap.SubParsers.Parsers) != 0 {
 		if index < len(parsingState.RawArguments) {
 			subparserName := parsingState.RawArguments[index]
-			if subparserName == "-h" || subparserName == "--help" {
-				ap.UsageFrom(index, parsingState)
-				os.Exit(0)
+			if level := ap.helpLevel(subparserName); level >= 0 {
+				// A term following the help flag is searched in the whole tree of parsers
+				if index+1 < len(parsingState.RawArguments) && !strings.HasPrefix(parsingState.RawArguments[index+1], "-") {
+					ap.printHelpSearch(parsingState.RawArguments[index+1], parsingState)
+				}
+				ap.printHelp(index, parsingState, level)
+				os.Exit(EXIT_CODE_SUCCESS)
 			}
-			lookupName := subparserName
-			if ap.SubParsers.CaseInsensitive {
-				lookupName = strings.ToLower(subparserName)
+			if ap.isVersionFlag(subparserName) {
+				ap.printVersion()
+				os.Exit(EXIT_CODE_SUCCESS)
 			}
+			lookupName := ap.SubParsers.ResolveName(subparserName)
 			if asp, exists := ap.SubParsers.Parsers[lookupName]; exists {
+				// Subparsers take the options they do not set from their parent, including the
+				// ones registered directly in the map of subparsers
+				asp.parent = ap
 				// Set the subparser name value to the pointer, which is only supplied by
 				// SetupSubParsing: subparsers registered without it have nowhere to store the name
 				if ap.SubParsers.Value != nil {
@@ -196,14 +273,22 @@ func (ap *ArgumentsParser) ParseFrom(index int, parsingState *ParsingState) {
 				}
 				asp.ParseFrom(index+1, parsingState)
 				return
+			} else if subparserName == "help" && !ap.Options.DisableHelpCommand && len(ap.helpFlags()) != 0 {
+				ap.helpCommand(index, parsingState)
 			} else {
-				parsingState.AddErrorMessage(fmt.Sprintf("No subparser with name \"%s\" was found.", lookupName))
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_UNKNOWN_SUBPARSER, Token: subparserName, Message: ap.message(MESSAGE_UNKNOWN_SUBPARSER, lookupName)})
 			}
+		} else if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+			parsingState.AddError(ParsingError{Kind: ERROR_KIND_MISSING_SUBPARSER, Message: ap.message(MESSAGE_MISSING_SUBPARSER)})
 		} else {
 			ap.UsageFrom(index, parsingState)
-			os.Exit(1)
+			os.Exit(EXIT_CODE_USAGE_ERROR)
 		}
 	} else {
+		// Action arguments are recorded in the order they are given, their actions being run once
+		// all the flags have been read
+		actions := []arguments.Argument{}
+
 		// Prepare arguments and split on "=" for `--arg=value`
 		// The index comes from the caller and can point past the end of the raw arguments, in
 		// which case there is simply nothing left to parse
@@ -221,12 +306,6 @@ func (ap *ArgumentsParser) ParseFrom(index int, parsingState *ParsingState) {
 			}
 		}
 
-		// Check if -h or --help are present
-		if slices.Contains(arguments, "-h") || slices.Contains(arguments, "--help") {
-			ap.UsageFrom(index, parsingState)
-			os.Exit(0)
-		}
-
 		// Reset all arguments to their default values
 		for _, arg := range ap.allArguments {
 			arg.ResetDefaultValue()
@@ -249,150 +328,188 @@ func (ap *ArgumentsParser) ParseFrom(index int, parsingState *ParsingState) {
 
 		// Parse the positional arguments first
 		missingPositionalArguments := []string{}
+		presentPositionalArguments := make(map[string]bool)
+		groupedPositionalArguments := ap.groupedPositionalNames()
 		for k, posarg := range ap.PositionalArguments {
 			if k < len(potentialPositionalArguments) {
+				presentPositionalArguments[posarg.GetName()] = true
 				_, err := posarg.Consume([]string{potentialPositionalArguments[k]})
 				if err != nil {
-					parsingState.AddErrorMessage(fmt.Sprintf("Error parsing positional argument <%s>: %s", posarg.GetName(), err))
+					parsingState.AddError(ParsingError{Kind: ERROR_KIND_INVALID_VALUE, Argument: "<" + posarg.GetName() + ">", Token: potentialPositionalArguments[k], Message: ap.message(MESSAGE_INVALID_POSITIONAL, posarg.GetName(), err)})
 				} else {
 					parsingState.ParsedArguments.AddPositionalArgument(&posarg)
 				}
-			} else {
+			} else if !groupedPositionalArguments[posarg.GetName()] {
 				missingPositionalArguments = append(missingPositionalArguments, posarg.GetName())
 			}
 		}
+		// The message for missing positional arguments is only recorded once it is known that no
+		// action argument stops parsing, at the position it would have had among the other messages
+		missingPositionalError := ParsingError{}
+		missingPositionalIndex := len(parsingState.ErrorMessages)
 		if len(missingPositionalArguments) != 0 {
-			if len(missingPositionalArguments) == 1 {
-				parsingState.ErrorMessages = append(parsingState.ErrorMessages, fmt.Sprintf("Missing %d positional argument: <%s>.", len(missingPositionalArguments), missingPositionalArguments[0]))
-			} else {
-				errmsg := fmt.Sprintf("Missing %d positional arguments:", len(missingPositionalArguments))
-				for _, posarg := range missingPositionalArguments {
-					errmsg = errmsg + fmt.Sprintf(" <%s>", posarg)
-				}
-				errmsg = errmsg + "."
-				parsingState.AddErrorMessage(errmsg)
+			names := []string{}
+			for _, posarg := range missingPositionalArguments {
+				names = append(names, fmt.Sprintf("<%s>", posarg))
+			}
+			missingPositionalError = ParsingError{
+				Kind:     ERROR_KIND_MISSING_POSITIONAL,
+				Argument: strings.Join(names, ", "),
+				Message:  ap.pluralMessage(MESSAGE_MISSING_POSITIONALS, len(missingPositionalArguments), len(missingPositionalArguments), strings.Join(names, " ")),
 			}
 		}
 		if len(potentialPositionalArguments) > len(ap.PositionalArguments) {
 			leftoverPositionalArguments := potentialPositionalArguments[len(ap.PositionalArguments):]
-			if len(leftoverPositionalArguments) == 1 {
-				parsingState.ErrorMessages = append(parsingState.ErrorMessages, fmt.Sprintf("Got %d more positional argument than expected: \"%s\".", len(leftoverPositionalArguments), leftoverPositionalArguments[0]))
-			} else {
-				errmsg := fmt.Sprintf("Got %d more positional argument than expected: ", len(leftoverPositionalArguments))
-				for _, loposarg := range leftoverPositionalArguments {
-					errmsg = errmsg + fmt.Sprintf(" \"%s\"", loposarg)
-				}
-				errmsg = errmsg + "."
-				parsingState.AddErrorMessage(errmsg)
+			values := []string{}
+			for _, loposarg := range leftoverPositionalArguments {
+				values = append(values, fmt.Sprintf("\"%s\"", loposarg))
 			}
+			parsingState.AddError(ParsingError{Kind: ERROR_KIND_UNEXPECTED_POSITIONAL, Token: leftoverPositionalArguments[0], Message: ap.pluralMessage(MESSAGE_EXTRA_POSITIONALS, len(leftoverPositionalArguments), len(leftoverPositionalArguments), strings.Join(values, " "))})
 		}
 
 		// Parse all other arguments
 		// Positions consumed as the value of a recognized flag are tracked so that
 		// values which look like flags (e.g. "--port -1") are not reported as unknown.
+		// A help flag is only recognized where a flag is expected, so that a value equal to a help
+		// flag (e.g. "--grep -h") is consumed as a value, and it takes effect once all the flags
+		// have been read. The help level is the one of the most complete help message requested, and
+		// a term following a help flag is searched in the help messages instead.
 		consumedAsValue := make(map[int]bool)
+		helpLevel, versionRequested, searchTerm := -1, false, ""
 		for k, otherarg := range otherArguments {
+			if !consumedAsValue[k] && ap.longNameToArgument[otherarg] == nil && ap.shortNameToArgument[otherarg] == nil {
+				if level := ap.helpLevel(otherarg); level >= 0 {
+					helpLevel = max(helpLevel, level)
+					if k+1 < len(otherArguments) && !strings.HasPrefix(otherArguments[k+1], "-") {
+						searchTerm = otherArguments[k+1]
+						consumedAsValue[k+1] = true
+					}
+					continue
+				}
+				if ap.isVersionFlag(otherarg) {
+					versionRequested = true
+					continue
+				}
+			}
 			if strings.HasPrefix(otherarg, "--") {
 				// Long flag name
 				if _, exists := ap.longNameToArgument[otherarg]; exists {
 					arg := ap.longNameToArgument[otherarg]
-					remaining, err := arg.Consume(otherArguments[k:])
+					remaining, err := arg.Consume(ap.resolveAlias(arg, otherArguments[k:], parsingState))
 					if err != nil {
-						parsingState.AddErrorMessage(fmt.Sprintf("Error parsing argument: %s", err))
+						parsingState.AddError(ParsingError{Kind: ERROR_KIND_INVALID_VALUE, Argument: argumentDisplayName(arg), Token: otherarg, Message: ap.message(MESSAGE_INVALID_ARGUMENT, err)})
 					} else {
 						parsingState.ParsedArguments.AddArgument(&arg)
+						actions = appendAction(actions, arg)
 					}
 					for i := k + 1; i < len(otherArguments)-len(remaining); i++ {
 						consumedAsValue[i] = true
 					}
 				} else if !consumedAsValue[k] {
-					parsingState.AddErrorMessage(fmt.Sprintf("Unknown argument \"%s\".", otherarg))
+					parsingState.AddError(ParsingError{Kind: ERROR_KIND_UNKNOWN_ARGUMENT, Token: otherarg, Message: ap.message(MESSAGE_UNKNOWN_ARGUMENT, otherarg)})
 				}
 			} else if strings.HasPrefix(otherarg, "-") {
 				// Short flag name
 				if _, exists := ap.shortNameToArgument[otherarg]; exists {
 					arg := ap.shortNameToArgument[otherarg]
-					remaining, err := arg.Consume(otherArguments[k:])
+					remaining, err := arg.Consume(ap.resolveAlias(arg, otherArguments[k:], parsingState))
 					if err != nil {
-						parsingState.AddErrorMessage(fmt.Sprintf("Error parsing argument: %s", err))
+						parsingState.AddError(ParsingError{Kind: ERROR_KIND_INVALID_VALUE, Argument: argumentDisplayName(arg), Token: otherarg, Message: ap.message(MESSAGE_INVALID_ARGUMENT, err)})
 					} else {
 						parsingState.ParsedArguments.AddArgument(&arg)
+						actions = appendAction(actions, arg)
 					}
 					for i := k + 1; i < len(otherArguments)-len(remaining); i++ {
 						consumedAsValue[i] = true
 					}
 				} else if !consumedAsValue[k] {
-					parsingState.AddErrorMessage(fmt.Sprintf("Unknown argument \"%s\".", otherarg))
+					parsingState.AddError(ParsingError{Kind: ERROR_KIND_UNKNOWN_ARGUMENT, Token: otherarg, Message: ap.message(MESSAGE_UNKNOWN_ARGUMENT, otherarg)})
 				}
 			}
 		}
 
-		// Check if all required arguments have been parsed
-		requiredArgumentsMissing := []string{}
-		for _, arg := range ap.requiredArguments {
-			if !arg.IsPresent() {
-				requiredArgumentsMissing = append(requiredArgumentsMissing, arg.GetLongName())
-			}
+		// Display the help message or the version, whatever the errors found so far and before
+		// the required arguments and the argument groups are checked
+		if len(searchTerm) != 0 {
+			ap.printHelpSearch(searchTerm, parsingState)
 		}
-		if len(requiredArgumentsMissing) != 0 {
-			if len(requiredArgumentsMissing) == 1 {
-				parsingState.AddErrorMessage(fmt.Sprintf("Missing required argument \"%s\"", requiredArgumentsMissing[0]))
-			} else {
-				parsingState.AddErrorMessage(fmt.Sprintf("Missing required arguments \"%s\"", strings.Join(requiredArgumentsMissing, "\", \"")))
-			}
+		if helpLevel >= 0 {
+			ap.printHelp(index, parsingState, helpLevel)
+			os.Exit(EXIT_CODE_SUCCESS)
+		}
+		if versionRequested {
+			ap.printVersion()
+			os.Exit(EXIT_CODE_SUCCESS)
 		}
 
-		// Check if all required arguments in groups have been parsed, in a stable order so that
-		// the resulting error messages are always reported in the same sequence
-		for _, groupName := range ap.sortedGroupNames() {
-			group := ap.Groups[groupName]
-			argumentsPresent := []string{}
-			argumentsMissing := []string{}
-			for _, arg := range group.Arguments {
-				if arg.IsPresent() {
-					argumentsPresent = append(argumentsPresent, arg.GetLongName())
-				} else {
-					argumentsMissing = append(argumentsMissing, arg.GetLongName())
-				}
+		// Warnings, such as the use of deprecated aliases, do not stop parsing
+		ap.emitWarnings(parsingState)
+
+		// Arguments that were not given on the command line can take their value from their
+		// environment variable, before checking that the required ones are present
+		ap.applyEnvironmentVariables(parsingState)
+
+		// Check the values of the arguments that were set with their validators
+		ap.runArgumentValidators(parsingState)
+
+		// An action argument stopping parsing runs and exits before the missing arguments and the
+		// argument groups are checked, like the help and version flags
+		if stopsParsing(actions) {
+			if len(parsingState.ErrorMessages) == 0 {
+				ap.runActions(actions, parsingState)
 			}
+		} else {
+			if len(missingPositionalError.Message) != 0 {
+				parsingState.insertError(missingPositionalIndex, missingPositionalError)
+			}
+			ap.checkParsedArguments(presentPositionalArguments, parsingState)
 
-			if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE {
-				// One needs to be set, and one only
-				if len(argumentsPresent) == 0 {
-					if len(argumentsMissing) == 1 {
-						parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("the argument \"%s\" needs to be set.", argumentsMissing[0])))
-					} else if len(argumentsMissing) > 1 {
-						parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("at least one of the arguments \"%s\" needs to be set.", strings.Join(argumentsMissing, "\", \""))))
-					}
-				} else if len(argumentsPresent) > 1 {
-					parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("arguments \"%s\" cannot be set together.", strings.Join(argumentsPresent, "\", \""))))
-				}
-			} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE {
-				// None can be set but if one is set then only one has to be set
-				if len(argumentsPresent) > 1 {
-					parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("arguments \"%s\" cannot be set together.", strings.Join(argumentsPresent, "\", \""))))
-				}
-			} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_DEPENDENT {
-				// If one is set, all need to be set
-				if len(argumentsMissing) != 0 {
-					if len(argumentsPresent) > 1 {
-						parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("when arguments \"%s\" are set, \"%s\" need to be set too.", strings.Join(argumentsPresent, "\", \""), strings.Join(argumentsMissing, "\", \""))))
-					} else if len(argumentsPresent) == 1 {
-						parsingState.AddErrorMessage(formatGroupErrorMessage(group.Name, fmt.Sprintf("when argument \"%s\" is set, \"%s\" need to be set too.", argumentsPresent[0], strings.Join(argumentsMissing, "\", \""))))
-					}
-				}
+			// The actions that continue parsing run once the arguments have been checked
+			if len(parsingState.ErrorMessages) == 0 {
+				ap.runActions(actions, parsingState)
 			}
 		}
 	}
 
 	// If there are error messages, print usage and exit
 	if len(parsingState.ErrorMessages) != 0 {
-		ap.UsageFrom(index, parsingState)
-		for _, errmsg := range parsingState.ErrorMessages {
-			fmt.Printf("[!] %s\n", errmsg)
+		ap.exitWithErrors(index, parsingState)
+	}
+}
+
+// checkParsedArguments checks that the required arguments are present, then the constraints of the
+// argument groups, the rules and constraint expressions between arguments, and finally runs the
+// validation hooks, recording an error message for each check that fails.
+//
+// Parameters:
+//   - presentPositionalArguments: The set of the names of the positional arguments that were given.
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) checkParsedArguments(presentPositionalArguments map[string]bool, parsingState *ParsingState) {
+	// Check if all required arguments have been parsed
+	requiredArgumentsMissing := []string{}
+	for _, arg := range ap.requiredArguments {
+		if !arg.IsPresent() {
+			requiredArgumentsMissing = append(requiredArgumentsMissing, arg.GetLongName())
 		}
-		os.Exit(1)
 	}
+	if len(requiredArgumentsMissing) != 0 {
+		parsingState.AddError(ParsingError{Kind: ERROR_KIND_MISSING_REQUIRED, Argument: strings.Join(requiredArgumentsMissing, ", "), Message: ap.pluralMessage(MESSAGE_MISSING_REQUIRED, len(requiredArgumentsMissing), strings.Join(requiredArgumentsMissing, "\", \""))})
+	}
+
+	// Check if all required arguments in groups have been parsed, in a stable order so that
+	// the resulting error messages are always reported in the same sequence
+	for _, groupName := range ap.sortedGroupNames() {
+		ap.checkGroup(ap.Groups[groupName], false, presentPositionalArguments, parsingState)
+	}
+
+	// Check the relations between arguments, such as conflicts or conditional requirements
+	ap.checkRules(parsingState)
+
+	// Check the constraint expressions over the arguments
+	ap.checkConstraints(parsingState)
+
+	// Check the parsed arguments as a whole with the validation hooks
+	ap.runValidationHooks(parsingState)
 }
 
 // Parse parses the arguments and returns the parsed arguments.
@@ -403,3 +520,133 @@ func (ap *ArgumentsParser) Parse() {
 	ap.ParsingState.SetRawArguments(os.Args)
 	ap.ParseFrom(1, &ap.ParsingState)
 }
+
+// checkGroup checks the constraint of an argument group, then the constraints of its subgroups, and
+// records an error message for each constraint that is not satisfied.
+//
+// The members of the constraint are the positional arguments of the group, its arguments, and its
+// subgroups, each subgroup counting as a single unit which is set when at least one of its arguments
+// or positional arguments is set.
+//
+// A subgroup whose parent decides whether its members are set, such as one of the alternatives of a
+// mutually exclusive group, is only checked when at least one of its members is set: when none is,
+// its parent already reports it if it had to be set.
+//
+// Parameters:
+//   - group: The argument group to check.
+//   - decidedByParent: Whether the parent group of the group decides whether its members are set.
+//   - presentPositionalArguments: The set of the names of the positional arguments that were given.
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) checkGroup(group *argumentgroup.ArgumentGroup, decidedByParent bool, presentPositionalArguments map[string]bool, parsingState *ParsingState) {
+	if decidedByParent && !subgroupIsSet(group, presentPositionalArguments) {
+		return
+	}
+
+	argumentsPresent := []string{}
+	argumentsMissing := []string{}
+	argumentsInGroup := []string{}
+	addMember := func(name string, present bool) {
+		argumentsInGroup = append(argumentsInGroup, name)
+		if present {
+			argumentsPresent = append(argumentsPresent, name)
+		} else {
+			argumentsMissing = append(argumentsMissing, name)
+		}
+	}
+	for _, posarg := range group.Positionals {
+		addMember("<"+posarg.GetName()+">", presentPositionalArguments[posarg.GetName()])
+	}
+	for _, arg := range group.Arguments {
+		addMember(arg.GetLongName(), arg.IsPresent())
+	}
+	for _, subgroup := range group.SubGroups {
+		addMember(subgroupMemberName(subgroup), subgroupIsSet(subgroup, presentPositionalArguments))
+	}
+	addGroupError := func(members []string, message string) {
+		parsingState.AddError(ParsingError{Kind: ERROR_KIND_GROUP, Argument: strings.Join(members, ", "), Message: ap.formatGroupErrorMessage(group.Name, message)})
+	}
+
+	if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE {
+		// One needs to be set, and one only
+		if len(argumentsPresent) == 0 {
+			if len(argumentsMissing) != 0 {
+				addGroupError(argumentsMissing, ap.pluralMessage(MESSAGE_GROUP_NEEDS_ONE, len(argumentsMissing), strings.Join(argumentsMissing, "\", \"")))
+			}
+		} else if len(argumentsPresent) > 1 {
+			addGroupError(argumentsPresent, ap.message(MESSAGE_GROUP_EXCLUSIVE, strings.Join(argumentsPresent, "\", \"")))
+		}
+	} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE {
+		// None can be set but if one is set then only one has to be set
+		if len(argumentsPresent) > 1 {
+			addGroupError(argumentsPresent, ap.message(MESSAGE_GROUP_EXCLUSIVE, strings.Join(argumentsPresent, "\", \"")))
+		}
+	} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_DEPENDENT {
+		// If one is set, all need to be set
+		if len(argumentsMissing) != 0 && len(argumentsPresent) != 0 {
+			addGroupError(argumentsMissing, ap.pluralMessage(MESSAGE_GROUP_DEPENDENT, len(argumentsPresent), strings.Join(argumentsPresent, "\", \""), strings.Join(argumentsMissing, "\", \"")))
+		}
+	} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE {
+		// At least one needs to be set, in any combination
+		if len(argumentsPresent) == 0 && len(argumentsMissing) != 0 {
+			addGroupError(argumentsMissing, ap.pluralMessage(MESSAGE_GROUP_NEEDS_ONE, len(argumentsMissing), strings.Join(argumentsMissing, "\", \"")))
+		}
+	} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N {
+		// Exactly Count need to be set
+		if len(argumentsPresent) != group.Count {
+			addGroupError(argumentsInGroup, ap.pluralMessage(MESSAGE_GROUP_EXACTLY_N, group.Count, group.Count, strings.Join(argumentsInGroup, "\", \""), len(argumentsPresent)))
+		}
+	} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_AT_MOST_N {
+		// None can be set, but no more than Count
+		if len(argumentsPresent) > group.Count {
+			addGroupError(argumentsPresent, ap.pluralMessage(MESSAGE_GROUP_AT_MOST_N, group.Count, group.Count, strings.Join(argumentsPresent, "\", \"")))
+		}
+	}
+
+	for _, subgroup := range group.SubGroups {
+		ap.checkGroup(subgroup, groupDecidesPresence(group.Type), presentPositionalArguments, parsingState)
+	}
+}
+
+// subgroupMemberName returns the name a subgroup is referred to with in the error messages of the
+// constraint of its parent group, which lists its members between parentheses.
+//
+// Parameters:
+//   - group: The subgroup to name.
+//
+// Returns:
+//   - The names of the positional arguments and arguments of the subgroup, such as "(--user, --password)".
+func subgroupMemberName(group *argumentgroup.ArgumentGroup) string {
+	names := []string{}
+	for _, posarg := range group.AllPositionals() {
+		names = append(names, "<"+posarg.GetName()+">")
+	}
+	for _, arg := range group.AllArguments() {
+		names = append(names, argumentDisplayName(arg))
+	}
+
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+// subgroupIsSet reports whether at least one of the arguments or positional arguments of a group,
+// including the ones of its subgroups, is set.
+//
+// Parameters:
+//   - group: The argument group to check.
+//   - presentPositionalArguments: The set of the names of the positional arguments that were given.
+//
+// Returns:
+//   - true if a member of the group is set, false otherwise.
+func subgroupIsSet(group *argumentgroup.ArgumentGroup, presentPositionalArguments map[string]bool) bool {
+	for _, posarg := range group.AllPositionals() {
+		if presentPositionalArguments[posarg.GetName()] {
+			return true
+		}
+	}
+	for _, arg := range group.AllArguments() {
+		if arg.IsPresent() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+// Package parsertest provides helpers to check the definition of a parser from the tests of a program
+// using goopts, so that mistakes in the definition fail the tests instead of showing at runtime.
+package parsertest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/parser"
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// exampleSubprocessEnv is the environment variable holding the index of the example parsed by the
+// subprocesses started by ValidateExamples.
+const exampleSubprocessEnv = "GOOPTS_PARSERTEST_EXAMPLE"
+
+// Validate fails the test with one error per mistake found by the Validate method of the parser,
+// including the mistakes in its subparsers.
+//
+// Parameters:
+//   - t: The test, or benchmark, to fail.
+//   - ap: The parser whose definition is checked.
+func Validate(t testing.TB, ap *parser.ArgumentsParser) {
+	t.Helper()
+
+	for _, err := range ap.Validate() {
+		t.Errorf("invalid parser definition: %s", err)
+	}
+}
+
+// ValidateExamples fails the test for each example of the parser or of its subparsers, added with
+// AddExample, that does not parse: an example has to be accepted by the parser as it is written, or
+// print the help message.
+//
+// Parsing reports errors by exiting the program, so each example is parsed in a subprocess running
+// the calling test again, which has to build the parser the same way and call ValidateExamples once.
+// The actions of the action arguments given in an example run in that subprocess.
+//
+// Parameters:
+//   - t: The test to fail.
+//   - ap: The parser whose examples are checked.
+func ValidateExamples(t testing.TB, ap *parser.ArgumentsParser) {
+	t.Helper()
+
+	examples := collectExamples(ap)
+
+	// In the subprocess, parse the example and exit, with the code of the parser on errors
+	if value, defined := os.LookupEnv(exampleSubprocessEnv); defined {
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(examples) {
+			os.Exit(2)
+		}
+		arguments, err := utils.SplitCommandLine(examples[index].Command)
+		if err != nil {
+			os.Exit(2)
+		}
+		ap.ParsingState.SetRawArguments(arguments)
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	for index, example := range examples {
+		if _, err := utils.SplitCommandLine(example.Command); err != nil {
+			t.Errorf("example %q cannot be split into arguments: %s", example.Command, err)
+			continue
+		}
+
+		cmd := exec.Command(os.Args[0], "-test.run="+testRunPattern(t.Name()))
+		cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", exampleSubprocessEnv, index), "NO_COLOR=1")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Errorf("example %q does not parse (%s):\n%s", example.Command, err, parsingErrors(string(out)))
+		}
+	}
+}
+
+// collectExamples returns the examples of a parser followed by the ones of its subparsers, depth
+// first and sorted by name.
+//
+// Parameters:
+//   - ap: The parser whose examples are collected.
+//
+// Returns:
+//   - The examples of the parser and of all its subparsers.
+func collectExamples(ap *parser.ArgumentsParser) []parser.Example {
+	examples := slices.Clone(ap.Examples)
+
+	names := []string{}
+	for name := range ap.SubParsers.Parsers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		examples = append(examples, collectExamples(ap.SubParsers.Parsers[name])...)
+	}
+
+	return examples
+}
+
+// testRunPattern returns the -test.run pattern matching exactly the test with the given name,
+// including the names of its parent tests for a subtest.
+//
+// Parameters:
+//   - name: The name of the test, as returned by its Name method.
+//
+// Returns:
+//   - The pattern matching the test only.
+func testRunPattern(name string) string {
+	parts := []string{}
+	for _, part := range strings.Split(name, "/") {
+		parts = append(parts, "^"+regexp.QuoteMeta(part)+"$")
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// parsingErrors extracts the error messages printed by the parser from the output of a subprocess,
+// or returns the whole output when there are none, such as when the subprocess panicked.
+//
+// Parameters:
+//   - output: The output of the subprocess.
+//
+// Returns:
+//   - The error messages of the parser, one per line.
+func parsingErrors(output string) string {
+	errors := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "[!] ") {
+			errors = append(errors, line)
+		}
+	}
+	if len(errors) == 0 {
+		return output
+	}
+
+	return strings.Join(errors, "\n")
+}
@@ -0,0 +1,59 @@
+package parsertest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/parser"
+)
+
+// recordingTB records the errors reported through it instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestValidate(t *testing.T) {
+	var port int
+	ap := parser.NewParser("test")
+	ap.NewIntArgument(&port, "-p", "--port", 0, false, "Port.")
+
+	recorder := &recordingTB{TB: t}
+	Validate(recorder, ap)
+	if len(recorder.errors) != 0 {
+		t.Errorf("Expected no errors for a valid parser, got %v", recorder.errors)
+	}
+
+	group, _ := ap.NewArgumentGroup("Other")
+	group.NewIntArgument(&port, "-p", "--other-port", 0, false, "Port.")
+	Validate(recorder, ap)
+	if len(recorder.errors) != 1 {
+		t.Errorf("Expected 1 error for a duplicate short name, got %v", recorder.errors)
+	}
+}
+
+func TestValidateExamples(t *testing.T) {
+	var mode, target string
+	var port int
+	ap := parser.NewParser("test")
+	ap.SetupSubParsing("mode", &mode, false)
+	scan := ap.AddSubParser("scan", "Scan a target.")
+	scan.NewStringPositionalArgument(&target, "target", "Target.")
+	scan.NewIntArgument(&port, "-p", "--port", 445, false, "Port.")
+	scan.AddExample("test scan 10.0.0.1 --port 139", "Scan a target on port 139.")
+	scan.AddExample("test scan -h", "Show the help.")
+	scan.AddExample("test scan 10.0.0.1 --port http", "Invalid port.")
+	scan.AddExample("test scan 'unterminated", "Invalid command line.")
+
+	recorder := &recordingTB{TB: t}
+	ValidateExamples(recorder, ap)
+	if len(recorder.errors) != 2 {
+		t.Errorf("Expected 2 errors for the invalid examples, got %v", recorder.errors)
+	}
+}
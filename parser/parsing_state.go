@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"slices"
+
 	"github.com/TheManticoreProject/goopts/arguments"
 	"github.com/TheManticoreProject/goopts/positionals"
 )
@@ -8,7 +10,57 @@ import (
 type ParsingState struct {
 	RawArguments    []string
 	ErrorMessages   []string
+	WarningMessages []string
 	ParsedArguments ParsedArguments
+	// Errors describe the error messages, in the same order, for the JSON output format. The error
+	// messages appended directly to ErrorMessages are described as validation errors, see syncErrors.
+	Errors []ParsingError
+}
+
+// The kinds of the errors found while parsing, as reported by the JSON output format.
+const (
+	// ERROR_KIND_UNKNOWN_ARGUMENT is a flag that matches no argument.
+	ERROR_KIND_UNKNOWN_ARGUMENT = "unknown-argument"
+	// ERROR_KIND_UNKNOWN_SUBPARSER is a name that matches no subparser.
+	ERROR_KIND_UNKNOWN_SUBPARSER = "unknown-subparser"
+	// ERROR_KIND_MISSING_SUBPARSER is a parser with subparsers given none.
+	ERROR_KIND_MISSING_SUBPARSER = "missing-subparser"
+	// ERROR_KIND_INVALID_VALUE is a value an argument or a positional argument failed to parse, or
+	// that a validator rejected.
+	ERROR_KIND_INVALID_VALUE = "invalid-value"
+	// ERROR_KIND_MISSING_POSITIONAL is a positional argument that was not given.
+	ERROR_KIND_MISSING_POSITIONAL = "missing-positional"
+	// ERROR_KIND_UNEXPECTED_POSITIONAL is a positional argument given beyond the expected ones.
+	ERROR_KIND_UNEXPECTED_POSITIONAL = "unexpected-positional"
+	// ERROR_KIND_MISSING_REQUIRED is a required argument that was not given.
+	ERROR_KIND_MISSING_REQUIRED = "missing-required"
+	// ERROR_KIND_GROUP is an argument group whose constraint is not satisfied.
+	ERROR_KIND_GROUP = "group"
+	// ERROR_KIND_RULE is a rule between two arguments that is not satisfied, see AddConflict.
+	ERROR_KIND_RULE = "rule"
+	// ERROR_KIND_CONSTRAINT is a constraint expression that is not satisfied, see AddConstraint.
+	ERROR_KIND_CONSTRAINT = "constraint"
+	// ERROR_KIND_ENVIRONMENT is an environment variable an argument failed to parse.
+	ERROR_KIND_ENVIRONMENT = "environment"
+	// ERROR_KIND_ACTION is an action that failed.
+	ERROR_KIND_ACTION = "action"
+	// ERROR_KIND_VALIDATION is an error returned by a validation hook, and the kind of the error
+	// messages added with AddErrorMessage.
+	ERROR_KIND_VALIDATION = "validation"
+)
+
+// ParsingError describes an error found while parsing.
+type ParsingError struct {
+	// Kind is the kind of the error, one of the ERROR_KIND_* constants.
+	Kind string `json:"kind"`
+	// Argument is the name of the argument the error is about, such as "--port" or "<target>", the
+	// names of the arguments separated by ", " when it is about several, or an empty string.
+	Argument string `json:"argument"`
+	// Token is the command line token the error was found at, or an empty string when the error is
+	// about something missing.
+	Token string `json:"token"`
+	// Message is the error message, as displayed in the text output format.
+	Message string `json:"message"`
 }
 
 type ParsedArguments struct {
@@ -30,7 +82,55 @@ func (ps *ParsingState) SetRawArguments(rawArguments []string) {
 // Parameters:
 // - message: The error message to add.
 func (ps *ParsingState) AddErrorMessage(message string) {
-	ps.ErrorMessages = append(ps.ErrorMessages, message)
+	ps.AddError(ParsingError{Kind: ERROR_KIND_VALIDATION, Message: message})
+}
+
+// AddError adds an error to the parsing state, along with its error message.
+//
+// Parameters:
+// - parsingError: The error to add.
+func (ps *ParsingState) AddError(parsingError ParsingError) {
+	ps.ErrorMessages = append(ps.ErrorMessages, parsingError.Message)
+	ps.Errors = append(ps.Errors, parsingError)
+}
+
+// insertError inserts an error in the parsing state, along with its error message, before the error
+// at an index.
+//
+// Parameters:
+// - index: The index of the error in the errors of the parsing state.
+// - parsingError: The error to insert.
+func (ps *ParsingState) insertError(index int, parsingError ParsingError) {
+	ps.syncErrors()
+	index = max(0, min(index, len(ps.ErrorMessages)))
+	ps.ErrorMessages = slices.Insert(ps.ErrorMessages, index, parsingError.Message)
+	ps.Errors = slices.Insert(ps.Errors, index, parsingError)
+}
+
+// syncErrors makes the errors describe the error messages again after error messages were appended
+// to ErrorMessages directly, or removed from it. The errors whose message is still there are kept in
+// order, and the other error messages are described as validation errors, like with AddErrorMessage.
+func (ps *ParsingState) syncErrors() {
+	errors := make([]ParsingError, 0, len(ps.ErrorMessages))
+	next := 0
+	for _, message := range ps.ErrorMessages {
+		if next < len(ps.Errors) && ps.Errors[next].Message == message {
+			errors = append(errors, ps.Errors[next])
+			next++
+		} else {
+			errors = append(errors, ParsingError{Kind: ERROR_KIND_VALIDATION, Message: message})
+		}
+	}
+	ps.Errors = errors
+}
+
+// GetErrors returns the errors from the parsing state.
+//
+// Returns:
+// - A slice of errors, one per error message.
+func (ps *ParsingState) GetErrors() []ParsingError {
+	ps.syncErrors()
+	return ps.Errors
 }
 
 // ClearErrorMessages clears the error messages from the parsing state.
@@ -38,6 +138,7 @@ func (ps *ParsingState) AddErrorMessage(message string) {
 // This method resets the error messages slice to an empty state, effectively clearing any previously added error messages.
 func (ps *ParsingState) ClearErrorMessages() {
 	ps.ErrorMessages = []string{}
+	ps.Errors = []ParsingError{}
 }
 
 // GetErrorMessages returns the error messages from the parsing state.
@@ -48,6 +149,23 @@ func (ps *ParsingState) GetErrorMessages() []string {
 	return ps.ErrorMessages
 }
 
+// AddWarningMessage adds a warning message to the parsing state. Unlike error messages, warning
+// messages do not make parsing fail.
+//
+// Parameters:
+// - message: The warning message to add.
+func (ps *ParsingState) AddWarningMessage(message string) {
+	ps.WarningMessages = append(ps.WarningMessages, message)
+}
+
+// GetWarningMessages returns the warning messages from the parsing state.
+//
+// Returns:
+// - A slice of warning messages.
+func (ps *ParsingState) GetWarningMessages() []string {
+	return ps.WarningMessages
+}
+
 // AddArgument adds an argument to the parsing state.
 //
 // Parameters:
@@ -69,6 +69,27 @@ func (ap *ArgumentsParser) NewBoolArgument(ptr *bool, shortName, longName string
 	return err
 }
 
+// NewActionArgument initializes a new ActionArgument and registers it with the ArgumentsParser.
+// An action argument is a flag running a callback when it is given, such as "--list-modules", after
+// which the parser either continues normally or exits without checking the required arguments.
+//
+// Parameters:
+// - shortName: The short flag (e.g., "-l") used to run the action. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--list-modules") used to run the action. It can be empty if no long flag is defined.
+// - action: The callback run when the argument is given. An error it returns is reported like the parsing errors.
+// - behavior: What the parser does after running the action, arguments.ACTION_BEHAVIOR_CONTINUE or arguments.ACTION_BEHAVIOR_EXIT.
+// - exitCode: The code the program exits with after running the action, when behavior is arguments.ACTION_BEHAVIOR_EXIT.
+// - help: A description of what this argument does, displayed in help/usage information.
+//
+// Returns:
+// - An error if the argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewActionArgument(shortName, longName string, action func() error, behavior int, exitCode int, help string) error {
+	arg := &arguments.ActionArgument{}
+	arg.Init(shortName, longName, action, behavior, exitCode, help)
+	err := ap.Register(arg)
+	return err
+}
+
 // NewStringArgument initializes a new StringArgument and registers it with the ArgumentsParser.
 // It sets up the argument with the provided short and long names, default value, requirement status,
 // and help message.
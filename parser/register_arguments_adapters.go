@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"encoding"
+	"flag"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+)
+
+// NewFlagValueArgument initializes a new FlagValueArgument and registers it with the ArgumentsParser.
+// It allows any type implementing flag.Value to be used as an argument.
+//
+// Parameters:
+// - value: The flag.Value that parses and stores the value of the argument.
+// - shortName: The short flag (e.g., "-l") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--level") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The textual form of the value to be used if the argument is not provided by the user.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewFlagValueArgument(value flag.Value, shortName, longName string, defaultValue string, required bool, help string) error {
+	arg := &arguments.FlagValueArgument{}
+	arg.Init(value, shortName, longName, defaultValue, required, help)
+	err := ap.Register(arg)
+	return err
+}
+
+// NewTextUnmarshalerArgument initializes a new TextUnmarshalerArgument and registers it with the ArgumentsParser.
+// It allows any type implementing encoding.TextUnmarshaler (e.g., *net.IP, *netip.Addr, *slog.Level) to be used as an argument.
+//
+// Parameters:
+// - value: The encoding.TextUnmarshaler that parses and stores the value of the argument.
+// - shortName: The short flag (e.g., "-t") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--target") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The textual form of the value to be used if the argument is not provided by the user.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewTextUnmarshalerArgument(value encoding.TextUnmarshaler, shortName, longName string, defaultValue string, required bool, help string) error {
+	arg := &arguments.TextUnmarshalerArgument{}
+	arg.Init(value, shortName, longName, defaultValue, required, help)
+	err := ap.Register(arg)
+	return err
+}
+
+// NewFlagValuePositionalArgument registers a new positional argument handled by a flag.Value with the argument parser.
+//
+// Parameters:
+// - value: The flag.Value that parses and stores the value of the argument.
+// - name: The name of the positional argument, displayed in the usage message.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// Returns:
+// - An error if the positional argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewFlagValuePositionalArgument(value flag.Value, name string, help string) error {
+	arg := &positionals.FlagValuePositionalArgument{}
+	arg.Init(value, name, help)
+	err := ap.RegisterPositional(arg)
+	return err
+}
+
+// NewTextUnmarshalerPositionalArgument registers a new positional argument parsed by an encoding.TextUnmarshaler
+// with the argument parser.
+//
+// Parameters:
+// - value: The encoding.TextUnmarshaler that parses and stores the value of the argument.
+// - name: The name of the positional argument, displayed in the usage message.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// Returns:
+// - An error if the positional argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewTextUnmarshalerPositionalArgument(value encoding.TextUnmarshaler, name string, help string) error {
+	arg := &positionals.TextUnmarshalerPositionalArgument{}
+	arg.Init(value, name, help)
+	err := ap.RegisterPositional(arg)
+	return err
+}
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// listValue is a flag.Value accumulating the values given to its Set method.
+type listValue []string
+
+func (l *listValue) String() string { return strings.Join(*l, ",") }
+
+func (l *listValue) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// TestAdapterArgumentsParsedTwice verifies that parsing twice does not call the flag.Value and
+// encoding.TextUnmarshaler arguments with an empty default value, and only gives them their default
+// value again when they were set by a previous parse.
+func TestAdapterArgumentsParsedTwice(t *testing.T) {
+	fs := flag.NewFlagSet("tool", flag.ContinueOnError)
+	calls := []string{}
+	fs.Func("hook", "A callback.", func(value string) error {
+		calls = append(calls, value)
+		return nil
+	})
+
+	level := slog.LevelWarn
+	tags := listValue{"default"}
+
+	ap := NewParser("test")
+	if err := ap.ImportFlagSet(fs); err != nil {
+		t.Fatalf("ImportFlagSet failed: %v", err)
+	}
+	if err := ap.NewTextUnmarshalerArgument(&level, "", "--level", "", false, "The log level."); err != nil {
+		t.Fatalf("NewTextUnmarshalerArgument failed: %v", err)
+	}
+	if err := ap.NewFlagValueArgument(&tags, "", "--tag", "default", false, "A tag."); err != nil {
+		t.Fatalf("NewFlagValueArgument failed: %v", err)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"tool", "--hook", "x", "--level", "error"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	ap.ParsingState.SetRawArguments([]string{"tool"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if len(calls) != 1 || calls[0] != "x" {
+		t.Errorf("expected the callback to be called once with \"x\", got %q", calls)
+	}
+	if level != slog.LevelError {
+		t.Errorf("expected the level without a default value to keep its value, got %v", level)
+	}
+	if tags.String() != "default" {
+		t.Errorf("expected the tags not to be given their default value again, got %q", tags.String())
+	}
+}
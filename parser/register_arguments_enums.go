@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+)
+
+// NewEnumArgument initializes a new EnumArgument storing a string and registers it with the ArgumentsParser.
+// The argument only accepts one of the given choices, and the value stored is always spelled as in the choices.
+//
+// Parameters:
+// - ptr: A pointer to the string variable where the argument's value will be stored.
+// - shortName: The short flag (e.g., "-f") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--format") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The choice to be used if the argument is not provided by the user. It can be empty.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the value given by the user matches a choice regardless of its case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the choices are invalid or if the argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewEnumArgument(ptr *string, shortName, longName string, defaultValue string, choices []string, caseInsensitive bool, required bool, help string) error {
+	return NewTypedEnumArgument(ap, ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+}
+
+// NewTypedEnumArgument initializes a new EnumArgument storing a value of a type of typed constants based
+// on string, and registers it with the ArgumentsParser. It is a function rather than a method because
+// methods cannot have type parameters.
+//
+// Parameters:
+// - ap: The ArgumentsParser to register the argument with.
+// - ptr: A pointer to the variable where the argument's value will be stored.
+// - shortName: The short flag (e.g., "-f") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--format") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The choice to be used if the argument is not provided by the user. It can be empty.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the value given by the user matches a choice regardless of its case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the choices are invalid or if the argument registration fails, otherwise nil.
+func NewTypedEnumArgument[T ~string](ap *ArgumentsParser, ptr *T, shortName, longName string, defaultValue T, choices []T, caseInsensitive bool, required bool, help string) error {
+	if err := arguments.CheckChoices(choices, defaultValue); err != nil {
+		return err
+	}
+
+	arg := &arguments.EnumArgument[T]{}
+	arg.Init(ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+	err := ap.Register(arg)
+	return err
+}
+
+// NewListOfEnumsArgument initializes a new ListOfEnumsArgument storing strings and registers it with the
+// ArgumentsParser. Each occurrence of the argument adds one of the given choices to the list.
+//
+// Parameters:
+// - ptr: A pointer to the slice of strings where the argument's values will be stored.
+// - shortName: The short flag (e.g., "-m") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--module") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The list of choices to be used if the argument is not provided by the user.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the values given by the user match a choice regardless of their case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the choices are invalid or if the argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewListOfEnumsArgument(ptr *[]string, shortName, longName string, defaultValue []string, choices []string, caseInsensitive bool, required bool, help string) error {
+	return NewTypedListOfEnumsArgument(ap, ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+}
+
+// NewTypedListOfEnumsArgument initializes a new ListOfEnumsArgument storing values of a type of typed
+// constants based on string, and registers it with the ArgumentsParser.
+//
+// Parameters:
+// - ap: The ArgumentsParser to register the argument with.
+// - ptr: A pointer to the slice where the argument's values will be stored.
+// - shortName: The short flag (e.g., "-m") used to specify the argument. It can be empty if no short flag is defined.
+// - longName: The long flag (e.g., "--module") used to specify the argument. It can be empty if no long flag is defined.
+// - defaultValue: The list of choices to be used if the argument is not provided by the user.
+// - choices: The list of values accepted by the argument.
+// - caseInsensitive: Indicates whether the values given by the user match a choice regardless of their case.
+// - required: Indicates whether the argument must be specified by the user.
+// - help: A description of what this argument represents, displayed in help/usage information.
+//
+// Returns:
+// - An error if the choices are invalid or if the argument registration fails, otherwise nil.
+func NewTypedListOfEnumsArgument[T ~string](ap *ArgumentsParser, ptr *[]T, shortName, longName string, defaultValue []T, choices []T, caseInsensitive bool, required bool, help string) error {
+	if err := arguments.CheckChoices(choices, defaultValue...); err != nil {
+		return err
+	}
+
+	arg := &arguments.ListOfEnumsArgument[T]{}
+	arg.Init(ptr, shortName, longName, defaultValue, choices, caseInsensitive, required, help)
+	err := ap.Register(arg)
+	return err
+}
+
+// NewEnumPositionalArgument registers a new positional argument accepting one of the given choices
+// with the argument parser.
+//
+// Parameters:
+// - ptr: A pointer to the string variable where the argument value will be stored.
+// - name: The name of the positional argument.
+// - choices: The list of values accepted by the argument, displayed in the usage message.
+// - caseInsensitive: Indicates whether the value given by the user matches a choice regardless of its case.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// Returns:
+// - An error if the choices are invalid or if the positional argument registration fails, otherwise nil.
+func (ap *ArgumentsParser) NewEnumPositionalArgument(ptr *string, name string, choices []string, caseInsensitive bool, help string) error {
+	return NewTypedEnumPositionalArgument(ap, ptr, name, choices, caseInsensitive, help)
+}
+
+// NewTypedEnumPositionalArgument registers a new positional argument accepting one of the given choices,
+// stored in a type of typed constants based on string, with the argument parser.
+//
+// Parameters:
+// - ap: The ArgumentsParser to register the positional argument with.
+// - ptr: A pointer to the variable where the argument value will be stored.
+// - name: The name of the positional argument.
+// - choices: The list of values accepted by the argument, displayed in the usage message.
+// - caseInsensitive: Indicates whether the value given by the user matches a choice regardless of its case.
+// - help: A description of the argument, which will be displayed in the help message.
+//
+// Returns:
+// - An error if the choices are invalid or if the positional argument registration fails, otherwise nil.
+func NewTypedEnumPositionalArgument[T ~string](ap *ArgumentsParser, ptr *T, name string, choices []T, caseInsensitive bool, help string) error {
+	if err := arguments.CheckChoices(choices); err != nil {
+		return err
+	}
+
+	arg := &positionals.EnumPositionalArgument[T]{}
+	arg.Init(ptr, name, choices, caseInsensitive, help)
+	err := ap.RegisterPositional(arg)
+	return err
+}
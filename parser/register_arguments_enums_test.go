@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+type outputFormat string
+
+const (
+	formatJSON  outputFormat = "json"
+	formatTable outputFormat = "table"
+)
+
+// TestEnumArgumentsInUsageLine verifies that enum arguments and positionals display their choices
+// in the usage line.
+func TestEnumArgumentsInUsageLine(t *testing.T) {
+	var action string
+	var format outputFormat
+	ap := NewParser("test")
+
+	if err := ap.NewEnumPositionalArgument(&action, "action", []string{"add", "remove"}, false, "Action."); err != nil {
+		t.Fatalf("NewEnumPositionalArgument failed: %v", err)
+	}
+	if err := NewTypedEnumArgument(ap, &format, "-f", "--format", formatJSON, []outputFormat{formatJSON, formatTable}, true, false, "Format."); err != nil {
+		t.Fatalf("NewTypedEnumArgument failed: %v", err)
+	}
+
+	if got := positionalPlaceholder(ap.PositionalArguments[0]); got != "{add|remove}" {
+		t.Errorf("expected positional placeholder \"{add|remove}\", got %q", got)
+	}
+	if got := generateArgumentForUsageLine(ap.Groups[""].Arguments[0]); got != "[--format {json|table}]" {
+		t.Errorf("expected usage line entry \"[--format {json|table}]\", got %q", got)
+	}
+
+	ap.ParsingState.SetRawArguments([]string{"test", "add", "--format", "TABLE"})
+	ap.ParseFrom(1, &ap.ParsingState)
+	if action != "add" || format != formatTable {
+		t.Errorf("expected action \"add\" and format \"table\", got %q and %q", action, format)
+	}
+}
+
+// TestEnumArgumentRejectsDefaultOutsideChoices verifies that a default value which is not one of the
+// choices is reported when the argument is registered.
+func TestEnumArgumentRejectsDefaultOutsideChoices(t *testing.T) {
+	var format string
+	ap := NewParser("test")
+
+	if err := ap.NewEnumArgument(&format, "-f", "--format", "xml", []string{"json", "csv"}, false, false, "Format."); err == nil {
+		t.Fatalf("expected an error for a default value that is not one of the choices")
+	}
+	if ap.findArgument("--format") != nil {
+		t.Errorf("expected the argument not to be registered")
+	}
+}
+
+// TestEnumArgumentInvalidChoiceIsReported verifies that a value outside of the choices is reported
+// as a parsing error listing the choices.
+//
+// ParseFrom prints the messages and calls os.Exit(1), so the parse runs in a subprocess and its
+// output is inspected from the parent.
+func TestEnumArgumentInvalidChoiceIsReported(t *testing.T) {
+	if os.Getenv("GOOPTS_ENUM_SUBPROCESS") == "1" {
+		var auth string
+		ap := NewParser("test")
+		if err := ap.NewEnumArgument(&auth, "", "--auth", "ntlm", []string{"ntlm", "kerberos", "basic"}, true, false, "Auth."); err != nil {
+			os.Exit(2)
+		}
+		ap.ParsingState.SetRawArguments([]string{"test", "--auth", "digest"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestEnumArgumentInvalidChoiceIsReported")
+	cmd.Env = append(os.Environ(), "GOOPTS_ENUM_SUBPROCESS=1")
+	out, err := cmd.CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %v", err)
+	}
+
+	expected := "[!] Error parsing argument: --auth digest: invalid choice, choose from [\"ntlm\", \"kerberos\", \"basic\"]"
+	if !strings.Contains(string(out), expected) {
+		t.Fatalf("expected output to contain %q, got:\n%s", expected, out)
+	}
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// ruleType identifies the relation a rule enforces between two arguments.
+type ruleType int
+
+const (
+	// ruleConflict forbids two arguments to be set together.
+	ruleConflict ruleType = iota
+	// ruleRequires makes an argument require another one when it is set.
+	ruleRequires
+	// ruleRequiredUnless makes an argument required when another one is not set.
+	ruleRequiredUnless
+	// ruleRequiredIf makes an argument required when another one has a given value.
+	ruleRequiredIf
+)
+
+// rule is a relation between two arguments, checked by ParseFrom after the argument groups.
+//
+// Fields:
+//   - Type: The relation enforced by the rule.
+//   - Argument: The argument the rule applies to.
+//   - Other: The argument the relation refers to.
+//   - Value: The value of Other that triggers the rule, for ruleRequiredIf only.
+type rule struct {
+	Type     ruleType
+	Argument arguments.Argument
+	Other    arguments.Argument
+	Value    string
+}
+
+// AddConflict forbids two arguments to be set together, such as "--password" and "--hashes".
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the first argument.
+//   - otherFlag: The short or long name of the argument it conflicts with.
+//
+// Returns:
+//   - An error if one of the arguments is not registered.
+func (ap *ArgumentsParser) AddConflict(argumentFlag, otherFlag string) error {
+	return ap.addRule(ruleConflict, argumentFlag, otherFlag, "")
+}
+
+// AddRequires makes an argument require another one when it is set, such as "--kdc-host" requiring
+// "--kerberos".
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - requiredFlag: The short or long name of the argument that needs to be set with it.
+//
+// Returns:
+//   - An error if one of the arguments is not registered.
+func (ap *ArgumentsParser) AddRequires(argumentFlag, requiredFlag string) error {
+	return ap.addRule(ruleRequires, argumentFlag, requiredFlag, "")
+}
+
+// AddRequiredUnless makes an argument required unless another one is set, such as "--output" being
+// required unless "--stdout" is set.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - otherFlag: The short or long name of the argument that makes it optional when set.
+//
+// Returns:
+//   - An error if one of the arguments is not registered.
+func (ap *ArgumentsParser) AddRequiredUnless(argumentFlag, otherFlag string) error {
+	return ap.addRule(ruleRequiredUnless, argumentFlag, otherFlag, "")
+}
+
+// AddRequiredIf makes an argument required when another one has a given value, such as "--port"
+// being required if "--proto" is "tcp". The value of the other argument is compared with its
+// default formatting, and its default value counts as well when it was not set.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - conditionFlag: The short or long name of the argument whose value is checked.
+//   - conditionValue: The value of the condition argument that makes the argument required.
+//
+// Returns:
+//   - An error if one of the arguments is not registered.
+func (ap *ArgumentsParser) AddRequiredIf(argumentFlag, conditionFlag, conditionValue string) error {
+	return ap.addRule(ruleRequiredIf, argumentFlag, conditionFlag, conditionValue)
+}
+
+// addRule resolves the arguments of a rule and adds it to the parser.
+//
+// Parameters:
+//   - ruleType: The relation enforced by the rule.
+//   - argumentFlag: The short or long name of the argument the rule applies to.
+//   - otherFlag: The short or long name of the argument the relation refers to.
+//   - value: The value of the other argument that triggers the rule, if any.
+//
+// Returns:
+//   - An error if one of the arguments is not registered, or if both flags refer to the same argument.
+func (ap *ArgumentsParser) addRule(ruleType ruleType, argumentFlag, otherFlag, value string) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
+	}
+
+	other := ap.findArgument(otherFlag)
+	if other == nil {
+		return fmt.Errorf("argument '%s' not found", otherFlag)
+	}
+
+	if arg == other {
+		return fmt.Errorf("argument '%s' cannot be in a rule with itself", argumentFlag)
+	}
+
+	ap.rules = append(ap.rules, rule{Type: ruleType, Argument: arg, Other: other, Value: value})
+
+	return nil
+}
+
+// checkRules checks the rules of the parser in the order they were added, and records an error
+// message naming both arguments for each rule that is not satisfied.
+//
+// Parameters:
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) checkRules(parsingState *ParsingState) {
+	for _, r := range ap.rules {
+		name := argumentDisplayName(r.Argument)
+		otherName := argumentDisplayName(r.Other)
+
+		switch r.Type {
+		case ruleConflict:
+			if r.Argument.IsPresent() && r.Other.IsPresent() {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_RULE, Argument: name, Message: ap.message(MESSAGE_RULE_CONFLICT, name, otherName)})
+			}
+		case ruleRequires:
+			if r.Argument.IsPresent() && !r.Other.IsPresent() {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_RULE, Argument: otherName, Message: ap.message(MESSAGE_RULE_REQUIRES, name, otherName)})
+			}
+		case ruleRequiredUnless:
+			if !r.Argument.IsPresent() && !r.Other.IsPresent() {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_RULE, Argument: name, Message: ap.message(MESSAGE_RULE_REQUIRED_UNLESS, name, otherName)})
+			}
+		case ruleRequiredIf:
+			if !r.Argument.IsPresent() && fmt.Sprint(r.Other.GetValue()) == r.Value {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_RULE, Argument: name, Message: ap.message(MESSAGE_RULE_REQUIRED_IF, name, otherName, r.Value)})
+			}
+		}
+	}
+}
+
+// argumentDisplayName returns the name an argument is referred to with in error messages, which is
+// its long name, or its short name when it has no long name.
+//
+// Parameters:
+//   - arg: The argument to name.
+//
+// Returns:
+//   - The long name of the argument, or its short name.
+func argumentDisplayName(arg arguments.Argument) string {
+	if len(arg.GetLongName()) != 0 {
+		return arg.GetLongName()
+	}
+	return arg.GetShortName()
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// newRulesParser returns a parser with the rules used by the tests of this file.
+func newRulesParser(t *testing.T) *ArgumentsParser {
+	var password, hashes, kdcHost, output, proto string
+	var kerberos, stdout bool
+	var port int
+	ap := NewParser("test")
+	ap.NewStringArgument(&password, "-p", "--password", "", false, "Password.")
+	ap.NewStringArgument(&hashes, "-H", "--hashes", "", false, "Hashes.")
+	ap.NewBoolArgument(&kerberos, "-k", "--kerberos", false, "Use Kerberos.")
+	ap.NewStringArgument(&kdcHost, "", "--kdc-host", "", false, "KDC host.")
+	ap.NewStringArgument(&output, "-o", "--output", "", false, "Output file.")
+	ap.NewBoolArgument(&stdout, "", "--stdout", false, "Write to stdout.")
+	ap.NewStringArgument(&proto, "", "--proto", "udp", false, "Protocol.")
+	ap.NewIntArgument(&port, "", "--port", 0, false, "Port.")
+
+	for _, err := range []error{
+		ap.AddConflict("--password", "--hashes"),
+		ap.AddRequires("--kdc-host", "-k"),
+		ap.AddRequiredUnless("--output", "--stdout"),
+		ap.AddRequiredIf("--port", "--proto", "tcp"),
+	} {
+		if err != nil {
+			t.Fatalf("failed to add rule: %v", err)
+		}
+	}
+
+	return ap
+}
+
+// TestRulesSatisfied verifies that no error is reported when all the rules are satisfied.
+func TestRulesSatisfied(t *testing.T) {
+	ap := newRulesParser(t)
+	ap.ParsingState.SetRawArguments([]string{"test", "-p", "secret", "-k", "--kdc-host", "dc01", "--stdout", "--proto", "tcp", "--port", "88"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if len(ap.ParsingState.ErrorMessages) != 0 {
+		t.Errorf("expected no error messages, got %v", ap.ParsingState.ErrorMessages)
+	}
+}
+
+// TestAddRuleErrors verifies that rules referring to unknown arguments, or to a single argument,
+// are rejected.
+func TestAddRuleErrors(t *testing.T) {
+	var a, b string
+	ap := NewParser("test")
+	ap.NewStringArgument(&a, "-a", "--alpha", "", false, "Alpha.")
+	ap.NewStringArgument(&b, "-b", "--beta", "", false, "Beta.")
+
+	if err := ap.AddConflict("--alpha", "--missing"); err == nil {
+		t.Errorf("expected an error for an unknown argument")
+	}
+	if err := ap.AddRequires("-a", "--alpha"); err == nil {
+		t.Errorf("expected an error for a rule between an argument and itself")
+	}
+	if len(ap.rules) != 0 {
+		t.Errorf("expected no rule to be added, got %d", len(ap.rules))
+	}
+}
+
+// TestRulesErrorsAreReported verifies that the rules that are not satisfied are reported in the
+// order they were added, with the names of both arguments.
+func TestRulesErrorsAreReported(t *testing.T) {
+	if os.Getenv("GOOPTS_RULES_SUBPROCESS") == "1" {
+		ap := newRulesParser(t)
+		ap.ParsingState.SetRawArguments([]string{"test", "-p", "secret", "-H", "aad3b435", "--kdc-host", "dc01", "--proto", "tcp"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestRulesErrorsAreReported", "GOOPTS_RULES_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d", code)
+	}
+
+	expected := "[!] arguments \"--password\" and \"--hashes\" cannot be set together.\n" +
+		"[!] when argument \"--kdc-host\" is set, \"--kerberos\" needs to be set too.\n" +
+		"[!] the argument \"--output\" needs to be set unless \"--stdout\" is set.\n" +
+		"[!] the argument \"--port\" needs to be set when \"--proto\" is \"tcp\".\n"
+	if !strings.Contains(out, expected) {
+		t.Fatalf("expected output to contain:\n%s\ngot:\n%s", expected, out)
+	}
+}
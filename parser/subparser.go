@@ -1,6 +1,11 @@
 package parser
 
-import "strings"
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
 
 type SubParsers struct {
 	// Name is the name of the subparser.
@@ -13,6 +18,8 @@ type SubParsers struct {
 	CaseInsensitive bool
 	// Parsers is a map of subparsers.
 	Parsers map[string]*ArgumentsParser
+	// Aliases maps the additional names of the subparsers to their name.
+	Aliases map[string]string
 }
 
 // AddSubParser adds a new subparser to the SubParsers.
@@ -53,11 +60,76 @@ func (sp *SubParsers) AddSubParser(name, banner string) *ArgumentsParser {
 // GetSubParser returns the subparser with the specified name.
 // If the subparser is case-insensitive, it returns the subparser with the specified name in lowercase.
 // Otherwise, it returns the subparser with the specified name.
+// The name can also be one of the aliases of the subparser.
 func (sp *SubParsers) GetSubParser(name string) *ArgumentsParser {
+	return sp.Parsers[sp.ResolveName(name)]
+}
+
+// ResolveName returns the name of the subparser selected by a name given on the command line, which is
+// the name itself unless it is an alias, in lowercase if the subparsers are case-insensitive.
+//
+// Parameters:
+// - name: The name given on the command line.
+//
+// Returns:
+// - The name of the subparser the name selects, which may not exist.
+func (sp *SubParsers) ResolveName(name string) string {
+	if sp.CaseInsensitive {
+		name = strings.ToLower(name)
+	}
+	if target, isAlias := sp.Aliases[name]; isAlias {
+		return target
+	}
+	return name
+}
+
+// AddAlias adds an additional name selecting a subparser, such as "ls" for "list".
+//
+// Parameters:
+// - name: The name of the subparser.
+// - alias: The additional name of the subparser.
+//
+// Returns:
+// - An error if there is no subparser with this name, or if the alias already selects a subparser.
+func (sp *SubParsers) AddAlias(name, alias string) error {
 	if sp.CaseInsensitive {
-		return sp.Parsers[strings.ToLower(name)]
+		name, alias = strings.ToLower(name), strings.ToLower(alias)
+	}
+	if _, exists := sp.Parsers[name]; !exists {
+		return fmt.Errorf("no subparser with name \"%s\" was found", name)
+	}
+	if len(alias) == 0 {
+		return fmt.Errorf("the alias of subparser \"%s\" cannot be empty", name)
 	}
-	return sp.Parsers[name]
+	if sp.GetSubParser(alias) != nil {
+		return fmt.Errorf("alias \"%s\" already selects a subparser", alias)
+	}
+
+	if sp.Aliases == nil {
+		sp.Aliases = make(map[string]string)
+	}
+	sp.Aliases[alias] = name
+
+	return nil
+}
+
+// AliasesOf returns the aliases of a subparser.
+//
+// Parameters:
+// - name: The name of the subparser.
+//
+// Returns:
+// - The aliases of the subparser, sorted alphabetically.
+func (sp *SubParsers) AliasesOf(name string) []string {
+	aliases := []string{}
+	for alias, target := range sp.Aliases {
+		if target == name {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+
+	return aliases
 }
 
 // SetupSubParsing initializes a new subparser with the specified name, value, and case sensitivity.
@@ -87,5 +159,56 @@ func (ap *ArgumentsParser) SetupSubParsing(name string, value *string, caseInsen
 // Returns:
 // - A pointer to the newly created ArgumentsParser instance for the subparser.
 func (ap *ArgumentsParser) AddSubParser(name, banner string) *ArgumentsParser {
-	return ap.SubParsers.AddSubParser(name, banner)
+	subparser := ap.SubParsers.AddSubParser(name, banner)
+	subparser.parent = ap
+	return subparser
+}
+
+// AddSubParserAlias adds an additional name selecting a subparser of the ArgumentsParser, such as "ls"
+// for "list". The aliases are displayed next to the name of the subparser in the usage message.
+//
+// Parameters:
+// - name: The name of the subparser.
+// - alias: The additional name of the subparser.
+//
+// Returns:
+// - An error if there is no subparser with this name, or if the alias already selects a subparser.
+func (ap *ArgumentsParser) AddSubParserAlias(name, alias string) error {
+	return ap.SubParsers.AddAlias(name, alias)
+}
+
+// helpCommand handles the "help <subcommand>" command of a parser with subparsers: it displays the
+// usage of the subcommand named after "help", which can be a nested subcommand such as
+// "help groupA groupAB", or the usage of the parser itself when no subcommand is named, then exits.
+// "help search <term>" searches the term in the whole tree of parsers, unless a subcommand is named
+// "search".
+//
+// Parameters:
+// - index: The index of the "help" token in the raw arguments.
+// - parsingState: The parsing state holding the raw arguments.
+func (ap *ArgumentsParser) helpCommand(index int, parsingState *ParsingState) {
+	// The usage line is built from the raw arguments, which must not include "help"
+	rawArguments := append([]string{}, parsingState.RawArguments[:index]...)
+	rawArguments = append(rawArguments, parsingState.RawArguments[index+1:]...)
+	helpState := &ParsingState{RawArguments: rawArguments}
+
+	if index+1 < len(rawArguments) && rawArguments[index] == "search" && ap.SubParsers.GetSubParser("search") == nil {
+		ap.printHelpSearch(rawArguments[index+1], helpState)
+	}
+
+	current := ap
+	position := index
+	for position < len(rawArguments) && current.SubParsers.Enabled {
+		subparser := current.SubParsers.GetSubParser(rawArguments[position])
+		if subparser == nil {
+			helpState.AddError(ParsingError{Kind: ERROR_KIND_UNKNOWN_SUBPARSER, Token: rawArguments[position], Message: current.message(MESSAGE_UNKNOWN_SUBPARSER, rawArguments[position])})
+			current.exitWithErrors(position, helpState)
+		}
+		subparser.parent = current
+		current = subparser
+		position++
+	}
+
+	current.UsageFrom(position, helpState)
+	os.Exit(EXIT_CODE_SUCCESS)
 }
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"os"
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	// COLOR_MODE_AUTO colors the usage and error messages when the standard output is a terminal and
+	// the NO_COLOR environment variable is not set. A subparser in this mode uses the mode of its parent.
+	COLOR_MODE_AUTO = 0
+
+	// COLOR_MODE_ALWAYS always colors the usage and error messages.
+	COLOR_MODE_ALWAYS = 1
+
+	// COLOR_MODE_NEVER never colors the usage and error messages.
+	COLOR_MODE_NEVER = 2
+)
+
+// ansiReset is the ANSI escape sequence restoring the default style of the terminal.
+const ansiReset = "\x1b[0m"
+
+// ansiEscapeSequence matches the ANSI escape sequences setting the style of the text.
+var ansiEscapeSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Theme holds the ANSI escape sequences used to style the parts of the usage and error messages,
+// such as "\x1b[1;31m" for bold red. An empty sequence leaves the part unstyled.
+type Theme struct {
+	// Title styles "Usage:" and the names of the argument groups.
+	Title string
+	// Flag styles the short and long names of the arguments.
+	Flag string
+	// Metavar styles the placeholders of the values of the arguments, such as "<string>".
+	Metavar string
+	// Required styles the arguments that need to be given in the usage line.
+	Required string
+	// Subcommand styles the names of the subparsers.
+	Subcommand string
+	// Error styles the error messages.
+	Error string
+}
+
+// DefaultTheme is the theme used when colors are enabled and no theme was set with SetTheme.
+var DefaultTheme = Theme{
+	Title:      "\x1b[1m",
+	Flag:       "\x1b[36m",
+	Metavar:    "\x1b[33m",
+	Required:   "\x1b[1;36m",
+	Subcommand: "\x1b[36m",
+	Error:      "\x1b[1;31m",
+}
+
+// stdoutIsTerminal reports whether the standard output is a terminal. It is a variable so that tests
+// can replace it.
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetOptColor sets when the usage and error messages are colored.
+//
+// Parameters:
+// - colorMode: One of COLOR_MODE_AUTO, COLOR_MODE_ALWAYS or COLOR_MODE_NEVER.
+func (ap *ArgumentsParser) SetOptColor(colorMode int) {
+	ap.Options.ColorMode = colorMode
+}
+
+// SetTheme sets the styles of the usage and error messages, once colors are enabled. The subparsers
+// that did not set their own theme use the one of their parent parser.
+//
+// Parameters:
+// - theme: The ANSI escape sequences styling each part of the messages.
+func (ap *ArgumentsParser) SetTheme(theme Theme) {
+	ap.Options.Theme = &theme
+}
+
+// colorMode returns the color mode of the parser, which is its own if it set one, or else the one
+// of its parent parser.
+//
+// Returns:
+// - One of COLOR_MODE_AUTO, COLOR_MODE_ALWAYS or COLOR_MODE_NEVER.
+func (ap *ArgumentsParser) colorMode() int {
+	if ap.Options.ColorMode != COLOR_MODE_AUTO || ap.parent == nil {
+		return ap.Options.ColorMode
+	}
+
+	return ap.parent.colorMode()
+}
+
+// theme returns the theme styling the usage and error messages of the parser, which is an empty theme
+// when they are not colored.
+//
+// In COLOR_MODE_AUTO, the messages are colored when the standard output is a terminal, unless the
+// NO_COLOR environment variable is set to a non-empty value or TERM is "dumb".
+//
+// Returns:
+// - The theme of the parser, of its closest parent parser that set one, or DefaultTheme, or an empty
+// theme when the messages are not colored.
+func (ap *ArgumentsParser) theme() *Theme {
+	switch ap.colorMode() {
+	case COLOR_MODE_NEVER:
+		return &Theme{}
+	case COLOR_MODE_AUTO:
+		if len(os.Getenv("NO_COLOR")) != 0 || os.Getenv("TERM") == "dumb" || !stdoutIsTerminal() {
+			return &Theme{}
+		}
+	}
+
+	for parser := ap; parser != nil; parser = parser.parent {
+		if parser.Options.Theme != nil {
+			return parser.Options.Theme
+		}
+	}
+
+	return &DefaultTheme
+}
+
+// apply styles a text with one of the styles of the theme.
+//
+// Parameters:
+// - style: The ANSI escape sequence to style the text with, one of the fields of the theme.
+// - text: The text to style.
+//
+// Returns:
+// - The styled text, or the text unchanged when the style or the text is empty.
+func (t *Theme) apply(style string, text string) string {
+	if len(style) == 0 || len(text) == 0 {
+		return text
+	}
+
+	return style + text + ansiReset
+}
+
+// visibleLength returns the number of characters of a text displayed in the terminal, without its
+// ANSI escape sequences, so that styled texts can be aligned.
+//
+// Parameters:
+// - text: The text, possibly styled.
+//
+// Returns:
+// - The length of the text without its ANSI escape sequences.
+func visibleLength(text string) int {
+	return utf8.RuneCountInString(ansiEscapeSequence.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestThemeSelection verifies when the messages are colored and which theme styles them.
+func TestThemeSelection(t *testing.T) {
+	defer func(previous func() bool) { stdoutIsTerminal = previous }(stdoutIsTerminal)
+	stdoutIsTerminal = func() bool { return true }
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	ap := NewParser("test")
+	if got := ap.theme(); *got != DefaultTheme {
+		t.Errorf("expected the default theme on a terminal, got %#v", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := ap.theme(); *got != (Theme{}) {
+		t.Errorf("expected no colors when NO_COLOR is set, got %#v", got)
+	}
+
+	ap.SetOptColor(COLOR_MODE_ALWAYS)
+	ap.SetTheme(Theme{Error: "\x1b[35m"})
+	var mode string
+	ap.SetupSubParsing("mode", &mode, false)
+	sub := ap.AddSubParser("scan", "Scan.")
+	if got := sub.theme(); got.Error != "\x1b[35m" {
+		t.Errorf("expected the subparser to use the color mode and theme of its parent, got %#v", got)
+	}
+
+	sub.SetOptColor(COLOR_MODE_NEVER)
+	if got := sub.theme(); *got != (Theme{}) {
+		t.Errorf("expected no colors in COLOR_MODE_NEVER, got %#v", got)
+	}
+
+	stdoutIsTerminal = func() bool { return false }
+	t.Setenv("NO_COLOR", "")
+	ap.SetOptColor(COLOR_MODE_AUTO)
+	if got := ap.theme(); *got != (Theme{}) {
+		t.Errorf("expected no colors when the output is not a terminal, got %#v", got)
+	}
+}
+
+// TestStyledHelpLineIsAligned verifies that the flags and value placeholders are styled without
+// changing the alignment of the descriptions.
+func TestStyledHelpLineIsAligned(t *testing.T) {
+	var output string
+	ap := NewParser("test")
+	ap.NewStringArgument(&output, "-o", "--output", "", false, "Output file.")
+	arg := ap.Groups[""].Arguments[0]
+
+	plain := ap.generateArgumentLineInHelp(arg, 2, 24, 80, &Theme{}, defaultHelpDecorations)
+	styled := ap.generateArgumentLineInHelp(arg, 2, 24, 80, &DefaultTheme, defaultHelpDecorations)
+
+	if !strings.Contains(styled, DefaultTheme.Flag+"--output"+ansiReset) || !strings.Contains(styled, DefaultTheme.Metavar+"<string>"+ansiReset) {
+		t.Errorf("expected the flags and the placeholder to be styled, got %q", styled)
+	}
+	if got := ansiEscapeSequence.ReplaceAllString(styled, ""); got != plain {
+		t.Errorf("expected the styled line to be aligned as %q, got %q", plain, got)
+	}
+}
+
+// TestVisibleLength verifies that the length of a text counts its characters rather than its bytes,
+// without its ANSI escape sequences.
+func TestVisibleLength(t *testing.T) {
+	if got := visibleLength(DefaultTheme.Flag + "--sortie" + ansiReset + " côté"); got != 13 {
+		t.Errorf("expected a visible length of 13, got %d", got)
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/TheManticoreProject/goopts/argumentgroup"
 	"github.com/TheManticoreProject/goopts/arguments"
+	"github.com/TheManticoreProject/goopts/positionals"
+	"github.com/TheManticoreProject/goopts/utils"
 )
 
 // programName returns the name to display at the start of the usage line.
@@ -35,26 +39,111 @@ func programName(parsingState *ParsingState) string {
 
 // Usage prints the usage information for the command-line arguments.
 //
-// The function first prints the banner, followed by the usage string, which includes the name of the executable.
-// It then iterates through all the arguments in the DefaultGroup and prints their short name, long name, and help description.
-// The arguments are formatted using the padding format calculated by the computePaddingFormat function.
+// The help message is rendered from the help template of the parser, see SetHelpTemplate, with the data
+// model built by helpData. The default template, DefaultHelpTemplate, first prints the usage line, which
+// includes the name of the executable. When the usage line is wider than the help width, its optional
+// arguments are collapsed into "[options]". It then prints the short name, long name, and help description
+// of the arguments in the DefaultGroup.
 //
-// After printing the arguments in the DefaultGroup, the function iterates over the named subgroups in the Groups map.
-// For each subgroup, it prints the group name and the arguments within that group, including their short name, long name, and help description.
-// The subgroup arguments are also formatted using the padding format calculated by the computePaddingFormat function.
+// After printing the arguments in the DefaultGroup, the template prints the named groups in the Groups map,
+// by increasing Order and then in the order they were created. For each group, it prints the group name, its description
+// and the arguments within that group, including their short name, long name, and help description, followed by its
+// nested subgroups indented under it.
+//
+// The help descriptions of all the groups start on the same column, computed by computeHelpColumn, and are wrapped
+// to the help width, which is set with SetOptHelpWidth or read from the COLUMNS environment variable.
+//
+// When colors are enabled, see SetOptColor, the titles, flags, value placeholders and required arguments are styled
+// with the theme of the parser.
+//
+// The advanced and hidden arguments and groups are left out, see SetArgumentVisibility, the advanced ones being
+// displayed by the full help message requested with the help-all flags.
 //
 // The function ensures that the usage information is displayed in a clear and organized manner, making it easy for users to understand
 // the available command-line arguments and their descriptions.
 func (ap *ArgumentsParser) UsageFrom(index int, parsingState *ParsingState) {
-	// Create usage string
-	usage := "Usage: " + programName(parsingState)
+	ap.printHelp(index, parsingState, arguments.VISIBILITY_DEFAULT)
+}
+
+// printHelp prints the help message of the parser at a help level, the full help message displaying
+// the advanced arguments as well.
+//
+// Parameters:
+//   - index: The index of the first raw argument of the parser.
+//   - parsingState: The parsing state holding the raw arguments.
+//   - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+func (ap *ArgumentsParser) printHelp(index int, parsingState *ParsingState, level int) {
+	if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+		writeJSON(os.Stdout, ap.Describe(commandPath(index, parsingState), level))
+		return
+	}
+
+	fmt.Print(ap.renderHelp(ap.helpData(index, parsingState, level)))
+}
+
+// helpData builds the data model the help template of the parser is rendered with.
+//
+// Parameters:
+//   - index: The index of the first raw argument of the parser, the ones before it being the program
+//     name and the names of the subparsers leading to it.
+//   - parsingState: The parsing state holding the raw arguments.
+//   - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+//   - The data model of the help message.
+func (ap *ArgumentsParser) helpData(index int, parsingState *ParsingState, level int) HelpData {
+	data := ap.buildHelpData(index, parsingState, ap.helpWidth(), ap.theme(), level)
+
+	// The usual help message points to the full one when it leaves arguments out
+	if helpAllFlags := ap.helpAllFlags(); level < arguments.VISIBILITY_ADVANCED && len(helpAllFlags) != 0 && ap.hasAdvancedArguments() {
+		data.HelpAllFlag = helpAllFlags[len(helpAllFlags)-1]
+	}
+
+	return data
+}
+
+// buildHelpData builds the data model of the help message for the given width and theme, which are the
+// ones of the parser for the usage message, and plain ones for the generated documentation.
+//
+// Parameters:
+//   - index: The index of the first raw argument of the parser.
+//   - parsingState: The parsing state holding the raw arguments.
+//   - width: The help width, in columns.
+//   - theme: The theme styling the help message, which is empty when it is not colored.
+//   - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+//   - The data model of the help message.
+func (ap *ArgumentsParser) buildHelpData(index int, parsingState *ParsingState, width int, theme *Theme, level int) HelpData {
+	data := HelpData{
+		Program:          programName(parsingState),
+		Path:             []string{},
+		Banner:           ap.Banner,
+		Summary:          ap.summary(),
+		Description:      ap.Description,
+		DescriptionLines: utils.WrapText(ap.Description, max(width-2, minimumHelpTextWidth)),
+		Epilog:           ap.Epilog,
+		EpilogLines:      utils.WrapText(ap.Epilog, max(width-2, minimumHelpTextWidth)),
+		Width:            width,
+	}
+	for _, example := range ap.Examples {
+		data.Examples = append(data.Examples, HelpExample{
+			Command:          example.Command,
+			Explanation:      example.Explanation,
+			ExplanationLines: utils.WrapText(example.Explanation, max(width-6, minimumHelpTextWidth)),
+		})
+	}
 
 	// The index and the raw arguments both come from the caller, so the subparser prefix is
 	// limited to the arguments that are actually there
 	for k := 1; k < index && k < len(parsingState.RawArguments); k++ {
-		usage += " " + parsingState.RawArguments[k]
+		data.Path = append(data.Path, parsingState.RawArguments[k])
 	}
 
+	// Create usage string, whose title is left out of the synopsis
+	title := theme.apply(theme.Title, ap.message(MESSAGE_USAGE)) + " "
+	usage := title + strings.Join(append([]string{data.Program}, data.Path...), " ")
+
 	// Add subparsers
 	if ap.SubParsers.Enabled {
 		names := []string{}
@@ -62,83 +151,238 @@ func (ap *ArgumentsParser) UsageFrom(index int, parsingState *ParsingState) {
 			names = append(names, name)
 		}
 		sort.Strings(names)
-		usage += " <" + strings.Join(names, "|") + ">"
+		data.UsageLine = usage + " <" + strings.Join(names, "|") + ">"
+		// The version flags can be given instead of a subparser, once the version is enabled
+		versionFlags := ap.versionFlags()
+		if len(versionFlags) != 0 {
+			data.UsageLine += " [" + versionFlags[len(versionFlags)-1] + "]"
+		}
+		data.Synopsis = strings.TrimPrefix(data.UsageLine, title)
 
-		// Compute the maximum length of the subparser names
+		// The subcommands are aligned on the column following the longest of their names, aliases
+		// and indentation included
+		data.Subcommands = ap.helpSubcommands([]string{}, ap.showCommandTree())
 		maxLen := 0
-		for _, name := range names {
-			if len(name) > maxLen {
-				maxLen = len(name)
+		for _, subcommand := range data.Subcommands {
+			maxLen = max(maxLen, 2*subcommand.Depth+len(strings.Join(append([]string{subcommand.Name}, subcommand.Aliases...), ", ")))
+		}
+		data.Column = 3 + maxLen + 2
+		if len(versionFlags) != 0 {
+			data.Column = max(data.Column, 2+len(strings.Join(versionFlags, ", "))+2)
+		}
+		for k, subcommand := range data.Subcommands {
+			styledNames := []string{}
+			for _, name := range append([]string{subcommand.Name}, subcommand.Aliases...) {
+				styledNames = append(styledNames, theme.apply(theme.Subcommand, name))
 			}
+			data.Subcommands[k].Line = formatHelpLine(3+2*subcommand.Depth, strings.Join(styledNames, ", "), subcommand.Summary, data.Column, width)
 		}
-		// Print the subparsers
-		usage += "\n\n"
-		fmtString := fmt.Sprintf("   %%-%ds  %%s\n", maxLen)
-		for _, name := range names {
-			usage += fmt.Sprintf(fmtString, name, ap.SubParsers.Parsers[name].Banner)
+		if len(versionFlags) != 0 {
+			data.Arguments = append(data.Arguments, ap.versionHelpArgument(versionFlags, data.Column, width, theme))
 		}
 
-	} else {
-		// This is the usage line ============================================================
-		// Add positional arguments
-		for _, posarg := range ap.PositionalArguments {
-			usage += fmt.Sprintf(" <%s>", posarg.GetName())
+		return data
+	}
+
+	// The arguments and groups that are not displayed at this help level are left out of both the
+	// usage line and the detailled help
+	groups := ap.visibleGroups(level)
+
+	// This is the usage line ============================================================
+	// Add positional arguments, except the ones displayed with the group they belong to
+	entries := []usageEntry{}
+	groupedPositionals := ap.groupedPositionalNames()
+	combinedPositionals := make(map[string]bool)
+	for _, group := range groups {
+		collectCombinedPositionals(group, combinedPositionals)
+	}
+	for _, posarg := range ap.PositionalArguments {
+		data.Positionals = append(data.Positionals, HelpPositional{
+			Name:        posarg.GetName(),
+			Placeholder: positionalPlaceholder(posarg),
+			Help:        posarg.GetHelp(),
+			Grouped:     groupedPositionals[posarg.GetName()],
+		})
+		if combinedPositionals[posarg.GetName()] {
+			continue
 		}
-		// Append default group arguments
-		for _, argument := range ap.Groups[""].Arguments {
-			output := generateArgumentForUsageLine(argument)
-			if len(output) != 0 {
-				usage += " " + output
-			}
+		if groupedPositionals[posarg.GetName()] {
+			entries = append(entries, usageEntry{Text: "[" + positionalPlaceholder(posarg) + "]", Positional: true})
+		} else {
+			entries = append(entries, usageEntry{Text: positionalPlaceholder(posarg), Positional: true})
+		}
+	}
+	// Append default group arguments
+	for _, argument := range groups[""].Arguments {
+		output := generateArgumentForUsageLine(argument)
+		if len(output) != 0 {
+			entries = append(entries, usageEntry{Text: output})
 		}
-		// Prepare to iterate over groups sorted alphabetically by their names
-		groupNames := make([]string, 0, len(ap.Groups))
-		for groupname := range ap.Groups {
-			groupNames = append(groupNames, groupname)
+	}
+	// Append the version flag, once the version is enabled
+	versionFlags := ap.versionFlags()
+	if len(versionFlags) != 0 {
+		entries = append(entries, usageEntry{Text: "[" + versionFlags[len(versionFlags)-1] + "]"})
+	}
+	// Groups are displayed by their Order, then in the order they were created
+	groupNames := []string{}
+	for _, groupName := range ap.orderedGroupNames() {
+		if groups[groupName] != nil {
+			groupNames = append(groupNames, groupName)
 		}
-		sort.Strings(groupNames)
+	}
 
-		// Append arguments in groups
-		for _, groupname := range groupNames {
-			// This is the default group, we don't want to print it in this block
-			if groupname == "" {
-				continue
-			}
-			group := ap.Groups[groupname]
-			for _, argument := range group.Arguments {
-				output := generateArgumentForUsageLine(argument)
-				if len(output) == 0 {
-					// This should not happen
-				} else {
-					usage += " " + output
-				}
-			}
+	// Append arguments in groups
+	for _, groupname := range groupNames {
+		for _, output := range generateGroupEntriesForUsageLine(groups[groupname]) {
+			entries = append(entries, usageEntry{Text: output})
 		}
-		usage += "\n\n"
-
-		// This is the detailled help for each group ============================================================
-		for _, groupname := range groupNames {
-			group := ap.Groups[groupname]
-
-			// This is the default group, we want to print differently
-			if groupname == "" {
-				fmtString := fmt.Sprintf("  %s %%s\n", computePaddingFormat(ap.Groups[""].Arguments))
-				for _, argument := range group.Arguments {
-					usage += generateArgumentLineInHelp(argument, fmtString)
-				}
-			} else {
-				fmtString := fmt.Sprintf("    %s %%s\n", computePaddingFormat(group.Arguments))
-				usage += fmt.Sprintf("\n  %s:\n", group.Name)
-				for _, argument := range group.Arguments {
-					usage += generateArgumentLineInHelp(argument, fmtString)
-				}
-			}
+	}
+	data.UsageLine = joinUsageEntries(usage, entries, ap.message(MESSAGE_USAGE_OPTIONS), width, theme)
+	data.Synopsis = strings.TrimPrefix(data.UsageLine, title)
+
+	// This is the detailled help for each group ============================================================
+	// The descriptions of all the groups are aligned on the same column
+	data.Column = ap.computeHelpColumn(groups, groupNames, width)
+	decorations := ap.helpDecorations()
 
+	// The default group is printed first and without a title
+	for _, argument := range groups[""].Arguments {
+		data.Arguments = append(data.Arguments, ap.newHelpArgument(argument, 2, data.Column, width, theme, decorations))
+	}
+	if len(versionFlags) != 0 {
+		data.Arguments = append(data.Arguments, ap.versionHelpArgument(versionFlags, data.Column, width, theme))
+	}
+	for _, groupname := range groupNames {
+		data.Groups = append(data.Groups, ap.newHelpGroup(groups[groupname], 0, data.Column, width, theme, decorations))
+	}
+
+	return data
+}
+
+// versionHelpArgument builds the data model of the version flags, listed after the arguments of the
+// default group, or after the subparsers of a parser that has some.
+//
+// Parameters:
+//   - versionFlags: The version flags of the parser.
+//   - column: The column the help descriptions start on.
+//   - width: The help width, in columns.
+//   - theme: The theme styling the help message, which is empty when it is not colored.
+//
+// Returns:
+//   - The data model of the version flags.
+func (ap *ArgumentsParser) versionHelpArgument(versionFlags []string, column int, width int, theme *Theme) HelpArgument {
+	styledVersionFlags := []string{}
+	for _, flag := range versionFlags {
+		styledVersionFlags = append(styledVersionFlags, theme.apply(theme.Flag, flag))
+	}
+	versionHelp := ap.message(MESSAGE_VERSION_HELP)
+	versionArgument := HelpArgument{
+		Help:          versionHelp,
+		Decorations:   []string{},
+		DecoratedHelp: versionHelp,
+		Line:          formatHelpLine(2, strings.Join(styledVersionFlags, ", "), versionHelp, column, width),
+	}
+	for _, flag := range versionFlags {
+		if strings.HasPrefix(flag, "--") {
+			versionArgument.LongName = flag
+		} else {
+			versionArgument.ShortName = flag
 		}
 	}
 
-	// Finally print the usage
-	fmt.Printf("%s\n", usage)
+	return versionArgument
+}
+
+// helpSubcommands builds the data model of the subparsers of the parser listed in its usage message,
+// sorted by name, each one followed by its own subparsers when they are listed recursively. Their
+// lines are left to the caller, which aligns them.
+//
+// Parameters:
+//   - path: The names of the subparsers leading from the parser whose usage message is built to this one.
+//   - recursive: Whether the subparsers of the subparsers are listed as well.
+//
+// Returns:
+//   - The data model of the subparsers, in the order they are listed.
+func (ap *ArgumentsParser) helpSubcommands(path []string, recursive bool) []HelpSubcommand {
+	names := []string{}
+	for name := range ap.SubParsers.Parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	subcommands := []HelpSubcommand{}
+	for _, name := range names {
+		subparser := ap.SubParsers.Parsers[name]
+		subparser.parent = ap
+		subpath := append(append([]string{}, path...), name)
+		subcommands = append(subcommands, HelpSubcommand{
+			Name:    name,
+			Path:    subpath,
+			Aliases: ap.SubParsers.AliasesOf(name),
+			Depth:   len(path),
+			Banner:  subparser.Banner,
+			Summary: subparser.summary(),
+		})
+		if recursive && subparser.SubParsers.Enabled {
+			subcommands = append(subcommands, subparser.helpSubcommands(subpath, recursive)...)
+		}
+	}
+
+	return subcommands
+}
+
+// usageEntry is an entry of the usage line, such as a positional argument, an argument or the
+// members of a group.
+type usageEntry struct {
+	// Text is the entry as displayed in the usage line, such as "<target>" or "[--port <int>]".
+	Text string
+	// Positional indicates whether the entry is a positional argument, which is never collapsed.
+	Positional bool
+}
+
+// joinUsageEntries appends the entries of the usage line to its prefix. When the usage line is
+// wider than the help width, the optional entries, which are the ones between square brackets, are
+// replaced by a single entry such as "[options]" at the position of the first of them, keeping the
+// positional arguments and the entries that need to be given.
+//
+// The entries that need to be given are styled with the Required style of the theme.
+//
+// Parameters:
+//   - prefix: The start of the usage line, such as "Usage: program subcommand".
+//   - entries: The entries of the usage line, in the order they are displayed.
+//   - options: The entry replacing the optional entries, such as "[options]".
+//   - width: The help width, in columns.
+//   - theme: The theme styling the usage line, which is empty when it is not colored.
+//
+// Returns:
+//   - The usage line.
+func joinUsageEntries(prefix string, entries []usageEntry, options string, width int, theme *Theme) string {
+	length := visibleLength(prefix)
+	for _, entry := range entries {
+		length += 1 + utf8.RuneCountInString(entry.Text)
+	}
+	compact := length > width
+
+	usage := prefix
+	collapsed := false
+	for _, entry := range entries {
+		optional := !entry.Positional && strings.HasPrefix(entry.Text, "[")
+		if optional && compact {
+			if !collapsed {
+				collapsed = true
+				usage += " " + options
+			}
+			continue
+		}
+		if !entry.Positional && !optional {
+			usage += " " + theme.apply(theme.Required, entry.Text)
+		} else {
+			usage += " " + entry.Text
+		}
+	}
+
+	return usage
 }
 
 // Usage prints the usage information for the command-line arguments.
@@ -151,6 +395,94 @@ func (ap *ArgumentsParser) Usage() {
 	ap.UsageFrom(0, parsingState)
 }
 
+// argumentFlags joins the short and long names of an argument for display, the short name first,
+// followed by the aliases that are not deprecated.
+//
+// Parameters:
+//   - arg: The argument whose names are displayed.
+//
+// Returns:
+//   - "-s, --long" when the argument has both names, or the only name it has otherwise, followed by
+//     its aliases.
+func argumentFlags(arg arguments.Argument) string {
+	names := []string{}
+	for _, name := range append([]string{arg.GetShortName(), arg.GetLongName()}, displayedAliases(arg)...) {
+		if len(name) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// argumentValuePlaceholder returns the placeholder displayed after the flag of an argument to
+// represent its value, such as "<string>", "<FILE>" or "{json|csv}".
+//
+// The placeholder is derived from the arguments.ArgumentMetadata interface: the metavar set on the
+// argument takes precedence, then its list of choices, then the name of its type. Arguments that
+// are not followed by a value, and custom arguments that do not implement the interface, have no
+// placeholder.
+//
+// Parameters:
+//   - arg: The argument whose value placeholder is displayed.
+//
+// Returns:
+//   - The placeholder, or an empty string when the argument has none.
+func argumentValuePlaceholder(arg arguments.Argument) string {
+	metadata, ok := arg.(arguments.ArgumentMetadata)
+	if !ok || !metadata.ExpectsValue() {
+		return ""
+	}
+
+	if metavar := metadata.GetMetavar(); len(metavar) != 0 {
+		return fmt.Sprintf("<%s>", metavar)
+	}
+	if choices := metadata.GetChoices(); len(choices) != 0 {
+		return fmt.Sprintf("{%s}", strings.Join(choices, "|"))
+	}
+	if typeName := metadata.GetTypeName(); len(typeName) != 0 {
+		return fmt.Sprintf("<%s>", typeName)
+	}
+
+	return ""
+}
+
+// argumentFlagsWithPlaceholder returns the flags of an argument followed by the placeholder of
+// its value, as displayed in the left column of the help message.
+//
+// Parameters:
+//   - arg: The argument to display.
+//
+// Returns:
+//   - The flags of the argument, followed by a space and its value placeholder if it has one.
+func argumentFlagsWithPlaceholder(arg arguments.Argument) string {
+	flags := argumentFlags(arg)
+	if placeholder := argumentValuePlaceholder(arg); len(placeholder) != 0 {
+		flags = flags + " " + placeholder
+	}
+
+	return flags
+}
+
+// positionalPlaceholder returns the placeholder displayed in the usage line for a positional
+// argument: its choices such as "{add|remove}" when it only accepts a fixed set of values, or its
+// name such as "<target>" otherwise.
+//
+// Parameters:
+//   - posarg: The positional argument to display.
+//
+// Returns:
+//   - The placeholder of the positional argument.
+func positionalPlaceholder(posarg positionals.PositionalArgument) string {
+	if withChoices, ok := posarg.(positionals.ChoicesArgument); ok {
+		if choices := withChoices.GetChoices(); len(choices) != 0 {
+			return fmt.Sprintf("{%s}", strings.Join(choices, "|"))
+		}
+	}
+
+	return fmt.Sprintf("<%s>", posarg.GetName())
+}
+
 // generateArgumentForUsageLine generates a formatted string representing a command-line argument
 // for inclusion in the usage line of a help message.
 //
@@ -160,174 +492,361 @@ func (ap *ArgumentsParser) Usage() {
 //
 // Returns:
 //
-//	(string): A string describing the argument, including its type (if applicable) and name,
-//	          enclosed in square brackets if the argument is optional.
+//	(string): A string describing the argument, including its value placeholder (if applicable)
+//	          and name, enclosed in square brackets if the argument is optional.
 //
 // Behavior:
-//   - Determines the argument's type (e.g., `StringArgument`, `IntArgument`, `TcpPortArgument`, etc.)
-//     and appends an appropriate type descriptor (e.g., "<string>", "<int>", "<tcp port>").
-//   - Checks if the argument has a long or short name and uses it to build the output.
+//   - Uses the long name of the argument, or its short name when it has no long name.
+//   - Appends the placeholder of the value of the argument (e.g., "<string>", "<int>", "<FILE>"),
+//     as returned by argumentValuePlaceholder.
 //   - If the argument is not required, encloses the output string in square brackets.
-//   - Logs an error message if the argument type is not recognized.
 func generateArgumentForUsageLine(arg arguments.Argument) string {
-	output := ""
+	output := argumentUsageEntry(arg)
 
-	shortName := arg.GetShortName()
-	longName := arg.GetLongName()
+	if !arg.IsRequired() {
+		output = fmt.Sprintf("[%s]", output)
+	}
 
-	// Don't know why I need to remove the * only for this one, it should be argument.(*arguments.BoolArgument)
-	if _, ok := arg.(*arguments.BoolArgument); ok {
-		if len(longName) != 0 {
-			output = longName
-		} else if len(shortName) != 0 {
-			output = shortName
-		}
-	} else if _, ok := arg.(*arguments.StringArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <string>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <string>", shortName)
-		}
-	} else if _, ok := arg.(*arguments.IntArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <int>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <int>", shortName)
-		}
-	} else if _, ok := arg.(*arguments.IntRangeArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <int>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <int>", shortName)
-		}
-	} else if _, ok := arg.(*arguments.TcpPortArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <tcp port>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <tcp port>", shortName)
+	return output
+}
+
+// argumentUsageEntry returns the name of an argument followed by the placeholder of its value, as
+// displayed in the usage line before the argument is marked as optional or grouped.
+//
+// Parameters:
+//   - arg: The argument to display.
+//
+// Returns:
+//   - The long name of the argument, or its short name when it has no long name, followed by a
+//     space and its value placeholder if it has one.
+func argumentUsageEntry(arg arguments.Argument) string {
+	output := arg.GetLongName()
+	if len(output) == 0 {
+		output = arg.GetShortName()
+	}
+
+	if placeholder := argumentValuePlaceholder(arg); len(placeholder) != 0 {
+		output = fmt.Sprintf("%s %s", output, placeholder)
+	}
+
+	return output
+}
+
+// isCombinedInUsageLine reports whether the members of a group of the given type are displayed
+// together in the usage line, as a single entry showing the constraint of the group.
+//
+// Parameters:
+//   - groupType: The type of the argument group.
+//
+// Returns:
+//   - true for the mutually exclusive and at-least-one group types, false otherwise.
+func isCombinedInUsageLine(groupType int) bool {
+	return groupType == argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE ||
+		groupType == argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE ||
+		groupType == argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE
+}
+
+// collectCombinedPositionals records the names of the positional arguments displayed in the usage
+// line with the group they belong to, which are the ones of groups displayed as a single entry,
+// including the ones of their subgroups.
+//
+// Parameters:
+//   - group: The argument group to inspect, along with its subgroups.
+//   - names: The set the names of the positional arguments are added to.
+func collectCombinedPositionals(group *argumentgroup.ArgumentGroup, names map[string]bool) {
+	if isCombinedInUsageLine(group.Type) {
+		for _, posarg := range group.AllPositionals() {
+			names[posarg.GetName()] = true
 		}
-	} else if _, ok := arg.(*arguments.MapOfHttpHeadersArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <http header>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <http header>", shortName)
+		return
+	}
+
+	for _, subgroup := range group.SubGroups {
+		collectCombinedPositionals(subgroup, names)
+	}
+}
+
+// generateGroupEntriesForUsageLine generates the entries of the usage line for a group and its
+// subgroups: a single entry when the constraint of the group is displayed, or one entry per
+// argument otherwise, followed by the entries of its subgroups. The positional arguments of a group
+// whose constraint is not displayed are part of the positional arguments of the usage line.
+//
+// Parameters:
+//
+//	group (*argumentgroup.ArgumentGroup): The group to be formatted.
+//
+// Returns:
+//
+//	([]string): The entries of the usage line for the group.
+func generateGroupEntriesForUsageLine(group *argumentgroup.ArgumentGroup) []string {
+	if isCombinedInUsageLine(group.Type) {
+		if output := generateGroupForUsageLine(group); len(output) != 0 {
+			return []string{output}
 		}
-	} else if _, ok := arg.(*arguments.ListOfIntsArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <int>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <int>", shortName)
+		return []string{}
+	}
+
+	entries := []string{}
+	for _, argument := range group.Arguments {
+		entries = append(entries, generateArgumentForUsageLine(argument))
+	}
+	for _, subgroup := range group.SubGroups {
+		entries = append(entries, generateGroupEntriesForUsageLine(subgroup)...)
+	}
+
+	return entries
+}
+
+// generateSubgroupUnitForUsageLine generates the representation of a subgroup as a single member of
+// the constraint of its parent group in the usage line.
+//
+// Parameters:
+//
+//	group (*argumentgroup.ArgumentGroup): The subgroup to be formatted.
+//
+// Returns:
+//
+//	(string): The rendering of the constraint of the subgroup when it is displayed, or its members
+//	          separated by spaces otherwise, between parentheses when there are several.
+func generateSubgroupUnitForUsageLine(group *argumentgroup.ArgumentGroup) string {
+	if isCombinedInUsageLine(group.Type) {
+		return generateGroupForUsageLine(group)
+	}
+
+	members := []string{}
+	for _, posarg := range group.Positionals {
+		members = append(members, positionalPlaceholder(posarg))
+	}
+	for _, arg := range group.Arguments {
+		members = append(members, argumentUsageEntry(arg))
+	}
+	for _, subgroup := range group.SubGroups {
+		if unit := generateSubgroupUnitForUsageLine(subgroup); len(unit) != 0 {
+			members = append(members, unit)
 		}
-	} else if _, ok := arg.(*arguments.ListOfStringsArgument); ok {
-		if len(longName) != 0 {
-			output = fmt.Sprintf("%s <string>", longName)
-		} else if len(shortName) != 0 {
-			output = fmt.Sprintf("%s <string>", shortName)
+	}
+
+	if len(members) > 1 {
+		return "(" + strings.Join(members, " ") + ")"
+	}
+
+	return strings.Join(members, " ")
+}
+
+// newHelpGroup builds the data model of the section of the help message for a group, with its name,
+// its description and its arguments, followed by the sections of its subgroups indented under it.
+//
+// Parameters:
+//
+//	group (*argumentgroup.ArgumentGroup): The group to be described.
+//	depth (int): The nesting level of the group, 0 for the groups of the parser.
+//	column (int): The column the help descriptions of the arguments start on.
+//	width (int): The help width the descriptions are wrapped to.
+//	theme (*Theme): The theme styling the section, which is empty when it is not colored.
+//	decorations ([]int): The HELP_DECORATION_* constants of the details displayed after the help messages.
+//
+// Returns:
+//
+//	(HelpGroup): The data model of the section of the help message for the group and its subgroups.
+func (ap *ArgumentsParser) newHelpGroup(group *argumentgroup.ArgumentGroup, depth int, column int, width int, theme *Theme, decorations []int) HelpGroup {
+	indent := 2 * depth
+
+	helpGroup := HelpGroup{
+		Name:             group.Name,
+		Title:            theme.apply(theme.Title, group.Name+":"),
+		Description:      group.Description,
+		DescriptionLines: utils.WrapText(group.Description, max(width-indent-4, minimumHelpTextWidth)),
+		Depth:            depth,
+		Indent:           indent + 2,
+	}
+
+	for _, argument := range group.Arguments {
+		helpGroup.Arguments = append(helpGroup.Arguments, ap.newHelpArgument(argument, indent+4, column, width, theme, decorations))
+	}
+
+	for _, subgroup := range group.SubGroups {
+		helpGroup.SubGroups = append(helpGroup.SubGroups, ap.newHelpGroup(subgroup, depth+1, column, width, theme, decorations))
+	}
+
+	return helpGroup
+}
+
+// generateGroupForUsageLine generates a single entry of the usage line for the members of a group
+// whose constraint is displayed, positional arguments first and subgroups last.
+//
+// Parameters:
+//
+//	group (*argumentgroup.ArgumentGroup): The group to be formatted.
+//
+// Returns:
+//
+//	(string): "(--a | --b)" for a required mutually exclusive group, "[--a | --b]" for a not required
+//	          mutually exclusive group, "{--a,--b}+" for an at-least-one group, or an empty string
+//	          when the group has no members.
+func generateGroupForUsageLine(group *argumentgroup.ArgumentGroup) string {
+	members := []string{}
+	for _, posarg := range group.Positionals {
+		members = append(members, positionalPlaceholder(posarg))
+	}
+	for _, arg := range group.Arguments {
+		members = append(members, argumentUsageEntry(arg))
+	}
+	for _, subgroup := range group.SubGroups {
+		if unit := generateSubgroupUnitForUsageLine(subgroup); len(unit) != 0 {
+			members = append(members, unit)
 		}
 	}
+	if len(members) == 0 {
+		return ""
+	}
 
-	if !arg.IsRequired() {
-		output = fmt.Sprintf("[%s]", output)
+	switch group.Type {
+	case argumentgroup.ARGUMENT_GROUP_TYPE_REQUIRED_MUTUALLY_EXCLUSIVE:
+		return "(" + strings.Join(members, " | ") + ")"
+	case argumentgroup.ARGUMENT_GROUP_TYPE_NOT_REQUIRED_MUTUALLY_EXCLUSIVE:
+		return "[" + strings.Join(members, " | ") + "]"
+	case argumentgroup.ARGUMENT_GROUP_TYPE_AT_LEAST_ONE:
+		return "{" + strings.Join(members, ",") + "}+"
 	}
 
-	return output
+	return ""
 }
 
-// printArgumentLineInHelp formats and prints a line in the help message for a given command-line argument.
+// generateArgumentLineInHelp formats a line in the help message for a given command-line argument.
 //
 // Parameters:
 //
 //	arg (arguments.Argument): The argument to be described in the help output.
-//	fmtString (string): A format string specifying how to print the argument line.
-//	                    It typically includes placeholders for the argument flags and the help message.
+//	indent (int): The number of spaces before the flags of the argument.
+//	column (int): The column the help description starts on.
+//	width (int): The help width the description is wrapped to.
+//	theme (*Theme): The theme styling the flags, which is empty when they are not colored.
+//	decorations ([]int): The HELP_DECORATION_* constants of the details displayed after the help message.
 //
 // Behavior:
-//   - Retrieves the short and long names of the argument, then combines them into a flags string.
-//   - Depending on the argument type, appends additional information to the flags string to indicate the expected type
-//     (e.g., "<string>" or "<int>").
-//   - If the argument is of type `BoolArgument`, the help message includes its default value.
-//   - Outputs the formatted argument line using the provided format string.
-func generateArgumentLineInHelp(arg arguments.Argument, fmtString string) string {
-	shortName := arg.GetShortName()
-	longName := arg.GetLongName()
-	help := arg.GetHelp()
-
-	// Prepare flags format
-	flags_string := ""
-	if (len(shortName) != 0) && (len(longName) != 0) {
-		flags_string = fmt.Sprintf("%s, %s", shortName, longName)
-	} else if (len(shortName) == 0) && (len(longName) != 0) {
-		flags_string = longName
-	} else if (len(shortName) != 0) && (len(longName) == 0) {
-		flags_string = shortName
-	}
-
-	// Update flags with types
-	if _, ok := arg.(*arguments.BoolArgument); ok {
-		help = fmt.Sprintf("%s (default: %v)", help, arg.GetDefaultValue())
-	} else if _, ok := arg.(*arguments.StringArgument); ok {
-		flags_string = flags_string + " <string>"
-	} else if _, ok := arg.(*arguments.ListOfStringsArgument); ok {
-		flags_string = flags_string + " <string>"
-	} else if _, ok := arg.(*arguments.IntArgument); ok {
-		flags_string = flags_string + " <int>"
-	} else if _, ok := arg.(*arguments.IntRangeArgument); ok {
-		flags_string = flags_string + " <int>"
-	} else if _, ok := arg.(*arguments.ListOfIntsArgument); ok {
-		flags_string = flags_string + " <int>"
-	} else if _, ok := arg.(*arguments.TcpPortArgument); ok {
-		flags_string = flags_string + " <tcp port>"
-	} else if _, ok := arg.(*arguments.MapOfHttpHeadersArgument); ok {
-		flags_string = flags_string + " <http header>"
-	}
-
-	return fmt.Sprintf(fmtString, flags_string, help)
+//   - Combines the short and long names of the argument with the placeholder of its value
+//     (e.g., "<string>" or "<int>") into a flags string, styled with the theme.
+//   - Follows the help message with the details of the argument, such as its default value or its
+//     environment variable, the same way for every type of argument, see decoratedHelp.
+//   - Formats the argument line with formatHelpLine.
+func (ap *ArgumentsParser) generateArgumentLineInHelp(arg arguments.Argument, indent int, column int, width int, theme *Theme, decorations []int) string {
+	return formatHelpLine(indent, styledArgumentFlags(arg, theme), ap.decoratedHelp(arg, decorations), column, width)
 }
 
-// computePaddingFormat calculates the format string for padding argument flags.
+// styledArgumentFlags returns the flags of an argument followed by the placeholder of its value, as
+// argumentFlagsWithPlaceholder does, with the flags and the placeholder styled with the theme.
 //
-// The function iterates through all the arguments in the DefaultGroup and determines the maximum length
-// of the combined short and long name strings. It then creates a format string that can be used to
-// align the argument flags in the usage output.
+// Parameters:
+//   - arg: The argument to display.
+//   - theme: The theme styling the flags, which is empty when they are not colored.
 //
 // Returns:
-// - A format string that can be used to pad the argument flags to the maximum length.
-func computePaddingFormat(args []arguments.Argument) string {
-	max_len_flags_string := 15
-	for _, argument := range args {
-		shortName := argument.GetShortName()
-		longName := argument.GetLongName()
-
-		flags_string := ""
-		if (len(shortName) != 0) && (len(longName) != 0) {
-			flags_string = fmt.Sprintf("%s, %s", shortName, longName)
-		} else if (len(shortName) == 0) && (len(longName) != 0) {
-			flags_string = longName
-		} else if (len(shortName) != 0) && (len(longName) == 0) {
-			flags_string = shortName
+//   - The styled flags of the argument, followed by a space and its styled value placeholder if it has one.
+func styledArgumentFlags(arg arguments.Argument, theme *Theme) string {
+	names := []string{}
+	for _, name := range append([]string{arg.GetShortName(), arg.GetLongName()}, displayedAliases(arg)...) {
+		if len(name) != 0 {
+			names = append(names, theme.apply(theme.Flag, name))
 		}
+	}
 
-		arg := argument
-		if _, ok := arg.(*arguments.StringArgument); ok {
-			flags_string = flags_string + " <string>"
-		} else if _, ok := arg.(*arguments.ListOfStringsArgument); ok {
-			flags_string = flags_string + " <string>"
-		} else if _, ok := arg.(*arguments.IntArgument); ok {
-			flags_string = flags_string + " <int>"
-		} else if _, ok := arg.(*arguments.IntRangeArgument); ok {
-			flags_string = flags_string + " <int>"
-		} else if _, ok := arg.(*arguments.ListOfIntsArgument); ok {
-			flags_string = flags_string + " <int>"
-		} else if _, ok := arg.(*arguments.TcpPortArgument); ok {
-			flags_string = flags_string + " <tcp port>"
-		} else if _, ok := arg.(*arguments.MapOfHttpHeadersArgument); ok {
-			flags_string = flags_string + " <http header>"
-		}
+	flags := strings.Join(names, ", ")
+	if placeholder := argumentValuePlaceholder(arg); len(placeholder) != 0 {
+		flags = flags + " " + theme.apply(theme.Metavar, placeholder)
+	}
 
-		if len(flags_string) > max_len_flags_string {
-			max_len_flags_string = len(flags_string)
-		}
+	return flags
+}
+
+// minimumHelpTextWidth is the width help descriptions are wrapped to at least, so that they stay
+// readable in a narrow terminal.
+const minimumHelpTextWidth = 20
+
+// minimumHelpColumn is the column help descriptions start on at least, which leaves room for short
+// flags such as "-v, --verbose".
+const minimumHelpColumn = 18
+
+// formatHelpLine formats the line of the help message of an argument, with its flags on the left and
+// its help description on the right, starting on the given column and wrapped to the help width. The
+// lines following the first one are indented to the same column. When the flags do not leave room for
+// the description on their line, the description starts on the next line.
+//
+// Parameters:
+//   - indent: The number of spaces before the flags.
+//   - flags: The flags of the argument, followed by the placeholder of its value, possibly styled.
+//   - help: The help description of the argument.
+//   - column: The column the help description starts on.
+//   - width: The help width the description is wrapped to.
+//
+// Returns:
+//   - The lines of the help message for the argument, each one ending with a line break.
+func formatHelpLine(indent int, flags string, help string, column int, width int) string {
+	output := strings.Repeat(" ", indent) + flags
+
+	lines := utils.WrapText(help, max(width-column, minimumHelpTextWidth))
+	if len(lines) == 0 {
+		return output + "\n"
+	}
+
+	if length := visibleLength(output); length >= column {
+		output += "\n" + strings.Repeat(" ", column)
+	} else {
+		output += strings.Repeat(" ", column-length)
+	}
+	output += lines[0] + "\n"
+	for _, line := range lines[1:] {
+		output += strings.Repeat(" ", column) + line + "\n"
+	}
+
+	return output
+}
+
+// computeHelpColumn calculates the column the help descriptions start on, shared by the default group
+// and the named groups so that all the descriptions are aligned.
+//
+// The column follows the longest flags of the arguments, counting their indentation, with a minimum of
+// 18 characters. It is limited to half of the help width, the descriptions of the arguments whose flags
+// go past it starting on the next line.
+//
+// Parameters:
+//   - groups: The argument groups displayed in the help message, keyed by their name.
+//   - groupNames: The names of the named groups displayed in the help message.
+//   - width: The help width, in columns.
+//
+// Returns:
+//   - The column the help descriptions start on.
+func (ap *ArgumentsParser) computeHelpColumn(groups map[string]*argumentgroup.ArgumentGroup, groupNames []string, width int) int {
+	longest := 0
+	for _, argument := range groups[""].Arguments {
+		longest = max(longest, 2+len(argumentFlagsWithPlaceholder(argument)))
+	}
+	if versionFlags := ap.versionFlags(); len(versionFlags) != 0 {
+		longest = max(longest, 2+len(strings.Join(versionFlags, ", ")))
+	}
+	for _, groupName := range groupNames {
+		longest = max(longest, longestGroupFlags(groups[groupName], 4))
+	}
+
+	return max(min(longest+1, width/2), minimumHelpColumn)
+}
+
+// longestGroupFlags calculates the length of the longest flags of the arguments of a group and of its
+// subgroups, counting their indentation in the help message.
+//
+// Parameters:
+//   - group: The argument group to inspect, along with its subgroups.
+//   - indent: The indentation of the arguments of the group.
+//
+// Returns:
+//   - The length of the longest flags, indentation included.
+func longestGroupFlags(group *argumentgroup.ArgumentGroup, indent int) int {
+	longest := 0
+	for _, argument := range group.Arguments {
+		longest = max(longest, indent+len(argumentFlagsWithPlaceholder(argument)))
+	}
+	for _, subgroup := range group.SubGroups {
+		longest = max(longest, longestGroupFlags(subgroup, indent+2))
 	}
 
-	return fmt.Sprintf("%%-%ds", max_len_flags_string)
+	return longest
 }
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"testing"
+)
+
+// customArgument is an Argument implementation that does not implement
+// arguments.ArgumentMetadata, standing for arguments defined outside of goopts.
+type customArgument struct {
+	value   string
+	present bool
+}
+
+func (arg customArgument) GetShortName() string { return "-c" }
+func (arg customArgument) GetLongName() string  { return "--custom" }
+func (arg customArgument) GetHelp() string      { return "A custom argument." }
+func (arg customArgument) GetValue() any        { return arg.value }
+func (arg *customArgument) SetValue(value any)  { arg.value = value.(string) }
+func (arg customArgument) GetDefaultValue() any { return "" }
+func (arg *customArgument) ResetDefaultValue()  { arg.value = "" }
+func (arg customArgument) IsRequired() bool     { return false }
+func (arg customArgument) IsPresent() bool      { return arg.present }
+func (arg *customArgument) Consume(arguments []string) ([]string, error) {
+	if len(arguments) >= 2 && (arguments[0] == "-c" || arguments[0] == "--custom") {
+		arg.value = arguments[1]
+		arg.present = true
+		return arguments[2:], nil
+	}
+	return arguments, nil
+}
+
+// TestUsageLineOfCustomArgumentKeepsItsName verifies that an argument which does not describe
+// itself through arguments.ArgumentMetadata is still displayed with its name. Before the usage
+// code relied on the metadata interface, such an argument matched none of the type switches and
+// was rendered as "[]".
+func TestUsageLineOfCustomArgumentKeepsItsName(t *testing.T) {
+	arg := &customArgument{}
+
+	if got := generateArgumentForUsageLine(arg); got != "[--custom]" {
+		t.Fatalf("expected usage line entry \"[--custom]\", got %q", got)
+	}
+	if got := argumentFlagsWithPlaceholder(arg); got != "-c, --custom" {
+		t.Fatalf("expected help flags \"-c, --custom\", got %q", got)
+	}
+}
+
+// TestUsageLineUsesTypeNameAndMetavar verifies that the value placeholder comes from the type name
+// of the argument, and from its metavar once one is set.
+func TestUsageLineUsesTypeNameAndMetavar(t *testing.T) {
+	var output string
+	var port int
+	var verbose bool
+	ap := NewParser("test")
+
+	if err := ap.NewStringArgument(&output, "-o", "--output", "", true, "Output file."); err != nil {
+		t.Fatalf("NewStringArgument failed: %v", err)
+	}
+	if err := ap.NewTcpPortArgument(&port, "-p", "--port", 80, false, "Port."); err != nil {
+		t.Fatalf("NewTcpPortArgument failed: %v", err)
+	}
+	if err := ap.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose."); err != nil {
+		t.Fatalf("NewBoolArgument failed: %v", err)
+	}
+
+	args := ap.Groups[""].Arguments
+	expected := []string{"--output <string>", "[--port <tcp port>]", "[--verbose]"}
+	for k, arg := range args {
+		if got := generateArgumentForUsageLine(arg); got != expected[k] {
+			t.Errorf("expected usage line entry %q, got %q", expected[k], got)
+		}
+	}
+
+	if err := ap.SetArgumentMetavar("--output", "FILE"); err != nil {
+		t.Fatalf("SetArgumentMetavar failed: %v", err)
+	}
+	if got := generateArgumentForUsageLine(args[0]); got != "--output <FILE>" {
+		t.Errorf("expected usage line entry \"--output <FILE>\", got %q", got)
+	}
+	if got := argumentFlagsWithPlaceholder(args[0]); got != "-o, --output <FILE>" {
+		t.Errorf("expected help flags \"-o, --output <FILE>\", got %q", got)
+	}
+
+	if err := ap.SetArgumentMetavar("--missing", "X"); err == nil {
+		t.Errorf("expected an error when setting the metavar of an unknown argument")
+	}
+}
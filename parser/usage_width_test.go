@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestHelpWidth verifies that the help width is read from the option, then from the parent parser,
+// then from the COLUMNS environment variable.
+func TestHelpWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "")
+	ap := NewParser("test")
+	if got := ap.helpWidth(); got != defaultHelpWidth {
+		t.Errorf("expected the default width %d, got %d", defaultHelpWidth, got)
+	}
+
+	t.Setenv("COLUMNS", "120")
+	if got := ap.helpWidth(); got != 120 {
+		t.Errorf("expected the width of COLUMNS, got %d", got)
+	}
+
+	var mode string
+	ap.SetOptHelpWidth(60)
+	ap.SetupSubParsing("mode", &mode, false)
+	sub := ap.AddSubParser("scan", "Scan.")
+	if got := sub.helpWidth(); got != 60 {
+		t.Errorf("expected the subparser to use the width of its parent, got %d", got)
+	}
+}
+
+// TestHelpLineWrapping verifies that help descriptions are wrapped under their column, and start on
+// the next line when the flags are wider than the column.
+func TestHelpLineWrapping(t *testing.T) {
+	got := formatHelpLine(2, "-o, --output <FILE>", "File the results are written to, created if it does not exist.", 24, 60)
+	expected := "  -o, --output <FILE>   File the results are written to,\n" +
+		"                        created if it does not exist.\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	got = formatHelpLine(4, "--very-long-option-name <string>", "Help.", 24, 60)
+	expected = "    --very-long-option-name <string>\n" +
+		"                        Help.\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// TestHelpColumnIsSharedByGroups verifies that the descriptions of the default group and of the named
+// groups start on the same column.
+func TestHelpColumnIsSharedByGroups(t *testing.T) {
+	var verbose bool
+	var domain string
+	ap := NewParser("test")
+	ap.NewBoolArgument(&verbose, "-v", "--verbose", false, "Verbose mode.")
+	group, _ := ap.NewArgumentGroup("Authentication")
+	group.NewStringArgument(&domain, "-d", "--domain", "", false, "Domain.")
+
+	column := ap.computeHelpColumn(ap.visibleGroups(arguments.VISIBILITY_DEFAULT), ap.orderedGroupNames(), 80)
+	if column != len("    -d, --domain <string> ") {
+		t.Errorf("expected the column to follow the longest flags of the named group, got %d", column)
+	}
+	if got := ap.generateArgumentLineInHelp(ap.Groups[""].Arguments[0], 2, column, 80, &Theme{}, defaultHelpDecorations); !strings.HasPrefix(got, "  -v, --verbose"+strings.Repeat(" ", column-15)+"Verbose mode.") {
+		t.Errorf("expected the default group to be aligned on column %d, got %q", column, got)
+	}
+}
+
+// TestUsageLineIsCompacted verifies that the optional entries of a usage line wider than the help
+// width are collapsed into "[options]".
+func TestUsageLineIsCompacted(t *testing.T) {
+	entries := []usageEntry{
+		{Text: "<target>", Positional: true},
+		{Text: "--user <string>"},
+		{Text: "[--port <int>]"},
+		{Text: "[--verbose]"},
+		{Text: "(--password <string> | --hash <string>)"},
+	}
+
+	if got := joinUsageEntries("Usage: test", entries, "[options]", 200, &Theme{}); got != "Usage: test <target> --user <string> [--port <int>] [--verbose] (--password <string> | --hash <string>)" {
+		t.Errorf("expected the full usage line, got %q", got)
+	}
+	if got := joinUsageEntries("Usage: test", entries, "[options]", 80, &Theme{}); got != "Usage: test <target> --user <string> [options] (--password <string> | --hash <string>)" {
+		t.Errorf("expected the compact usage line, got %q", got)
+	}
+	if got := joinUsageEntries("Utilisation : test", entries, "[options…]", 80, &Theme{}); got != "Utilisation : test <target> --user <string> [options…] (--password <string> | --hash <string>)" {
+		t.Errorf("expected the optional entries to be replaced by the given entry, got %q", got)
+	}
+}
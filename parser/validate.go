@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// Validate checks the definition of the parser and of all its subparsers for mistakes that would
+// otherwise only show at runtime, or not at all. It does not parse anything, and is meant to be called
+// from a test, see the parsertest package.
+//
+// The following mistakes are reported:
+//   - Two arguments of the same parser with the same short or long name, even in different groups.
+//   - Short names that are not a single dash followed by a single character, and long names that do
+//     not start with two dashes.
+//   - Arguments whose name clashes with the help flags, "-h" and "--help" unless configured with
+//     SetHelpFlags, with the help-all flags, "-hh" and "--help-all" unless configured with
+//     SetHelpAllFlags, or with the version flags once enabled, which makes these flags unusable.
+//   - Required arguments in a group that decides whether its members are set, such as a mutually
+//     exclusive group, where their required flag is ignored.
+//   - Exactly-N and at-most-N groups whose count is lower than 1, and exactly-N groups with fewer
+//     members than their count.
+//   - Positional arguments with the same name, and positional arguments on a parser with subparsers,
+//     which are never parsed.
+//   - Default values rejected by the argument itself, such as an IntRangeArgument default out of range.
+//
+// Returns:
+//   - The list of mistakes found, each error naming the subparser it was found in, or nil if there are none.
+func (ap *ArgumentsParser) Validate() []error {
+	return ap.validateDefinition("")
+}
+
+// validateDefinition checks the definition of the parser, then of its subparsers sorted by name.
+//
+// Parameters:
+//   - path: The names of the subparsers leading to this parser, separated by spaces, empty for the
+//     top-level parser.
+//
+// Returns:
+//   - The list of mistakes found in the parser and its subparsers.
+func (ap *ArgumentsParser) validateDefinition(path string) []error {
+	var errs []error
+	report := func(format string, a ...any) {
+		message := fmt.Sprintf(format, a...)
+		if len(path) != 0 {
+			message = fmt.Sprintf("subparser \"%s\": %s", path, message)
+		}
+		errs = append(errs, fmt.Errorf("%s", message))
+	}
+
+	// Names of the arguments, to detect duplicates across groups
+	names := make(map[string]string)
+	for _, groupName := range ap.sortedGroupNames() {
+		group := ap.Groups[groupName]
+		ap.validateGroup(group, false, names, report)
+	}
+
+	// Positional arguments
+	positionalNames := make(map[string]bool)
+	for _, posarg := range ap.PositionalArguments {
+		if positionalNames[posarg.GetName()] {
+			report("positional argument <%s> is registered more than once", posarg.GetName())
+		}
+		positionalNames[posarg.GetName()] = true
+	}
+	if ap.SubParsers.Enabled && len(ap.PositionalArguments) != 0 {
+		report("positional arguments cannot be used on a parser with subparsers, the first positional argument selects the subparser")
+	}
+
+	// Subparsers
+	subparserNames := make([]string, 0, len(ap.SubParsers.Parsers))
+	for name := range ap.SubParsers.Parsers {
+		subparserNames = append(subparserNames, name)
+	}
+	sort.Strings(subparserNames)
+	for _, name := range subparserNames {
+		ap.SubParsers.Parsers[name].parent = ap
+		errs = append(errs, ap.SubParsers.Parsers[name].validateDefinition(strings.TrimSpace(path+" "+name))...)
+	}
+
+	return errs
+}
+
+// validateGroup checks the definition of a group and of its subgroups.
+//
+// Parameters:
+//   - group: The argument group to check.
+//   - decidedByParent: Whether a parent group already decides whether the members of the group are set.
+//   - names: The names of the arguments already seen in the parser, mapped to the group they are in.
+//   - report: The function recording a mistake.
+func (ap *ArgumentsParser) validateGroup(group *argumentgroup.ArgumentGroup, decidedByParent bool, names map[string]string, report func(format string, a ...any)) {
+	groupLabel := "the default group"
+	if len(group.Name) != 0 {
+		groupLabel = fmt.Sprintf("group \"%s\"", group.Name)
+	}
+	decided := decidedByParent || groupDecidesPresence(group.Type)
+
+	switch group.Type {
+	case argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N, argumentgroup.ARGUMENT_GROUP_TYPE_AT_MOST_N:
+		members := len(group.Positionals) + len(group.Arguments) + len(group.SubGroups)
+		if group.Count < 1 {
+			report("%s needs a count of at least 1, got %d", groupLabel, group.Count)
+		} else if group.Type == argumentgroup.ARGUMENT_GROUP_TYPE_EXACTLY_N && members < group.Count {
+			report("%s requires exactly %d arguments to be set but only has %d members", groupLabel, group.Count, members)
+		}
+	}
+
+	for _, arg := range group.Arguments {
+		validateArgumentNames(arg, groupLabel, ap.helpFlags(), ap.helpAllFlags(), ap.versionFlags(), names, report)
+
+		if arg.IsRequired() && decided {
+			report("argument \"%s\" in %s is marked as required, which is ignored since the group decides whether it is set", argumentDisplayName(arg), groupLabel)
+		}
+
+		if checker, ok := arg.(arguments.DefaultValueChecker); ok {
+			if err := checker.CheckDefaultValue(); err != nil {
+				report("argument \"%s\": %s", argumentDisplayName(arg), err)
+			}
+		}
+	}
+
+	for _, subgroup := range group.SubGroups {
+		ap.validateGroup(subgroup, decided, names, report)
+	}
+}
+
+// validateArgumentNames checks the short and long names of an argument, and records them along with
+// its aliases to detect duplicates.
+//
+// Parameters:
+//   - arg: The argument to check.
+//   - groupLabel: The description of the group the argument is in, for the error messages.
+//   - helpFlags: The help flags of the parser the argument is in.
+//   - helpAllFlags: The help-all flags of the parser the argument is in, empty when it has none.
+//   - versionFlags: The version flags of the parser the argument is in, empty when it has none.
+//   - names: The names of the arguments already seen in the parser, mapped to the group they are in.
+//   - report: The function recording a mistake.
+func validateArgumentNames(arg arguments.Argument, groupLabel string, helpFlags, helpAllFlags, versionFlags []string, names map[string]string, report func(format string, a ...any)) {
+	shortName, longName := arg.GetShortName(), arg.GetLongName()
+
+	if len(shortName) == 0 && len(longName) == 0 {
+		report("an argument in %s has neither a short nor a long name", groupLabel)
+		return
+	}
+	if shortRunes := []rune(shortName); len(shortRunes) != 0 && (len(shortRunes) != 2 || shortRunes[0] != '-' || shortRunes[1] == '-') {
+		report("short name \"%s\" needs to be a dash followed by a single character", shortName)
+	}
+	if len(longName) != 0 && (len(longName) < 3 || !strings.HasPrefix(longName, "--")) {
+		report("long name \"%s\" needs to start with two dashes followed by a name", longName)
+	}
+
+	// The aliases are matched like the names of the argument, so they clash with the same flags
+	allNames := []string{shortName, longName}
+	for _, alias := range argumentAliases(arg) {
+		allNames = append(allNames, alias.Name)
+	}
+	for _, name := range allNames {
+		if len(name) == 0 {
+			continue
+		}
+		if slices.Contains(helpFlags, name) {
+			report("argument name \"%s\" clashes with the help flags", name)
+		}
+		if slices.Contains(helpAllFlags, name) {
+			report("argument name \"%s\" clashes with the help-all flags", name)
+		}
+		if slices.Contains(versionFlags, name) {
+			report("argument name \"%s\" clashes with the version flags", name)
+		}
+		if otherGroup, exists := names[name]; exists {
+			report("argument name \"%s\" is used in both %s and %s", name, otherGroup, groupLabel)
+		} else {
+			names[name] = groupLabel
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestValidateValidParser verifies that a correct definition, with subparsers, reports no mistake.
+func TestValidateValidParser(t *testing.T) {
+	var mode, user, password, file, lettre string
+	var port int
+	ap := NewParser("test")
+	ap.SetupSubParsing("mode", &mode, false)
+
+	connect := ap.AddSubParser("connect", "Connect.")
+	connect.NewTcpPortArgument(&port, "-P", "--port", 445, false, "Port.")
+	auth, _ := connect.NewNotRequiredMutuallyExclusiveArgumentGroup("Authentication")
+	auth.NewStringArgument(&user, "-u", "--user", "", false, "User.")
+	auth.NewStringArgument(&password, "-p", "--password", "", false, "Password.")
+
+	upload := ap.AddSubParser("upload", "Upload.")
+	upload.NewStringPositionalArgument(&file, "file", "File.")
+	upload.NewTcpPortArgument(&port, "-P", "--port", 445, false, "Port.")
+	upload.NewStringArgument(&lettre, "-é", "--lettre", "", false, "A short name of a single non-ASCII character.")
+
+	if errs := ap.Validate(); len(errs) != 0 {
+		t.Errorf("expected no mistakes, got %v", errs)
+	}
+}
+
+// TestValidateReportsMistakes verifies that each kind of mistake is reported, naming the subparser
+// it was found in.
+func TestValidateReportsMistakes(t *testing.T) {
+	var mode, a, c, target string
+	var port, level int
+	ap := NewParser("test")
+	ap.SetupSubParsing("mode", &mode, false)
+	ap.NewStringPositionalArgument(&target, "target", "Target.")
+
+	sub := ap.AddSubParser("scan", "Scan.")
+	sub.NewIntArgument(&port, "", "--port", 0, false, "Port.")
+	other, _ := sub.NewArgumentGroup("Other")
+	other.NewIntArgument(&port, "", "--port", 0, false, "Port again.")
+	sub.NewStringArgument(&a, "-ab", "--alpha", "", false, "Alpha.")
+	sub.NewStringArgument(&c, "-h", "", "", false, "Clashes with help.")
+	exclusive, _ := sub.NewRequiredMutuallyExclusiveArgumentGroup("Mode")
+	exclusive.NewStringArgument(&c, "", "--gamma", "", true, "Gamma.")
+	pick, _ := sub.NewExactlyNArgumentGroup("Pick", 2)
+	pick.NewIntRangeArgument(&level, "", "--level", 10, 1, 5, false, "Level.")
+
+	expected := []string{
+		"positional arguments cannot be used on a parser with subparsers",
+		"subparser \"scan\": argument name \"--port\" is used in both the default group and group \"Other\"",
+		"subparser \"scan\": short name \"-ab\" needs to be a dash followed by a single character",
+		"subparser \"scan\": argument name \"-h\" clashes with the help flags",
+		"subparser \"scan\": argument \"--gamma\" in group \"Mode\" is marked as required",
+		"subparser \"scan\": group \"Pick\" requires exactly 2 arguments to be set but only has 1 members",
+		"subparser \"scan\": argument \"--level\": default value 10 is not in range [1, 5]",
+	}
+
+	errs := ap.Validate()
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	all := strings.Join(messages, "\n")
+	for _, message := range expected {
+		if !strings.Contains(all, message) {
+			t.Errorf("expected a mistake containing %q, got:\n%s", message, all)
+		}
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d mistakes, got %d:\n%s", len(expected), len(errs), all)
+	}
+}
+
+// TestValidateReportsAliasClashes verifies that the aliases of the arguments are checked against the
+// help, help-all and version flags and against the names and aliases of the other arguments.
+func TestValidateReportsAliasClashes(t *testing.T) {
+	var a, b string
+	ap := NewParser("test")
+	ap.SetVersion("1.0")
+	ap.NewStringArgument(&a, "", "--alpha", "", false, "Alpha.")
+	ap.NewStringArgument(&b, "", "--beta", "", false, "Beta.")
+
+	// The aliases are added to the arguments directly, which skips the checks done when registering them
+	alpha := ap.Groups[""].Arguments[0].(*arguments.StringArgument)
+	beta := ap.Groups[""].Arguments[1].(*arguments.StringArgument)
+	alpha.AddAlias(arguments.Alias{Name: "--help"})
+	alpha.AddAlias(arguments.Alias{Name: "--version", Deprecated: true})
+	alpha.AddAlias(arguments.Alias{Name: "--help-all"})
+	alpha.AddAlias(arguments.Alias{Name: "--beta"})
+	alpha.AddAlias(arguments.Alias{Name: "--shared"})
+	beta.AddAlias(arguments.Alias{Name: "--shared"})
+
+	expected := []string{
+		"argument name \"--help\" clashes with the help flags",
+		"argument name \"--version\" clashes with the version flags",
+		"argument name \"--help-all\" clashes with the help-all flags",
+		"argument name \"--beta\" is used in both the default group and the default group",
+		"argument name \"--shared\" is used in both the default group and the default group",
+	}
+
+	errs := ap.Validate()
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	all := strings.Join(messages, "\n")
+	for _, message := range expected {
+		if !strings.Contains(all, message) {
+			t.Errorf("expected a mistake containing %q, got:\n%s", message, all)
+		}
+	}
+	if len(errs) != len(expected) {
+		t.Errorf("expected %d mistakes, got %d:\n%s", len(expected), len(errs), all)
+	}
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// ValidationHook checks the parsed arguments as a whole, typically relations between several of them
+// such as "--min must be lower than or equal to --max" that no single argument can check on its own.
+// It returns an error describing the problem, or nil when the arguments are acceptable. An error
+// joining several errors with errors.Join is reported as one error message per joined error.
+type ValidationHook func() error
+
+// AddArgumentValidator adds a validator to a registered argument. The validator is run by ParseFrom
+// once the arguments have been parsed, when the argument was given on the command line or through its
+// environment variable, and the error it returns is reported like the other parsing errors.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - validator: The function checking the value of the argument.
+//
+// Returns:
+//   - An error if no argument is registered with this name, or if the argument does not implement
+//     arguments.ValidatedArgument.
+func (ap *ArgumentsParser) AddArgumentValidator(argumentFlag string, validator arguments.Validator) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
+	}
+
+	validatedArgument, ok := arg.(arguments.ValidatedArgument)
+	if !ok {
+		return fmt.Errorf("argument '%s' does not support validators", argumentFlag)
+	}
+	validatedArgument.AddValidator(validator)
+
+	return nil
+}
+
+// AddValidationHook adds a hook checking the parsed arguments as a whole. The hooks are run by
+// ParseFrom in the order they were added, after the argument group constraints have been checked,
+// and the errors they return are reported like the other parsing errors.
+//
+// Parameters:
+//   - hook: The function checking the parsed arguments.
+func (ap *ArgumentsParser) AddValidationHook(hook ValidationHook) {
+	ap.validationHooks = append(ap.validationHooks, hook)
+}
+
+// runArgumentValidators runs the validators of every argument that is present, and records the
+// errors they return in the parsing state.
+//
+// Parameters:
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) runArgumentValidators(parsingState *ParsingState) {
+	for _, arg := range ap.allArguments {
+		if !arg.IsPresent() {
+			continue
+		}
+
+		validatedArgument, ok := arg.(arguments.ValidatedArgument)
+		if !ok {
+			continue
+		}
+
+		name := argumentDisplayName(arg)
+		for _, validator := range validatedArgument.GetValidators() {
+			if err := validator(arg.GetValue()); err != nil {
+				parsingState.AddError(ParsingError{Kind: ERROR_KIND_INVALID_VALUE, Argument: name, Message: ap.message(MESSAGE_INVALID_VALUE, name, err)})
+				// The following validators could report the same problem again
+				break
+			}
+		}
+	}
+}
+
+// runValidationHooks runs the validation hooks of the parser, and records the errors they return in
+// the parsing state, one error message per error joined with errors.Join.
+//
+// Parameters:
+//   - parsingState: The parsing state that records the error messages.
+func (ap *ArgumentsParser) runValidationHooks(parsingState *ParsingState) {
+	for _, hook := range ap.validationHooks {
+		err := hook()
+		if err == nil {
+			continue
+		}
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, joinedErr := range joined.Unwrap() {
+				parsingState.AddErrorMessage(joinedErr.Error())
+			}
+		} else {
+			parsingState.AddErrorMessage(err.Error())
+		}
+	}
+}
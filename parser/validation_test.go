@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestValidatorsAndHooksPassOnValidValues verifies that valid values go through the argument
+// validators and the validation hooks without error.
+func TestValidatorsAndHooksPassOnValidValues(t *testing.T) {
+	var min, max int
+	var name string
+	ap := NewParser("test")
+	ap.NewIntArgument(&min, "", "--min", 0, false, "Minimum.")
+	ap.NewIntArgument(&max, "", "--max", 10, false, "Maximum.")
+	ap.NewStringArgument(&name, "-n", "--name", "", false, "Name.")
+
+	if err := ap.AddArgumentValidator("--name", arguments.MatchesRegexp(regexp.MustCompile(`^[a-z]+$`))); err != nil {
+		t.Fatalf("AddArgumentValidator failed: %v", err)
+	}
+	hookRan := false
+	ap.AddValidationHook(func() error {
+		hookRan = true
+		if min > max {
+			return fmt.Errorf("--min must be lower than or equal to --max")
+		}
+		return nil
+	})
+
+	ap.ParsingState.SetRawArguments([]string{"test", "--min", "2", "--max", "5", "-n", "alice"})
+	ap.ParseFrom(1, &ap.ParsingState)
+
+	if !hookRan {
+		t.Errorf("expected the validation hook to run")
+	}
+	if len(ap.ParsingState.ErrorMessages) != 0 {
+		t.Errorf("expected no error messages, got %v", ap.ParsingState.ErrorMessages)
+	}
+}
+
+// TestAddArgumentValidatorUnknownArgument verifies that adding a validator to an argument that is
+// not registered returns an error.
+func TestAddArgumentValidatorUnknownArgument(t *testing.T) {
+	ap := NewParser("test")
+	if err := ap.AddArgumentValidator("--missing", arguments.IntInRange(0, 1)); err == nil {
+		t.Errorf("expected an error for an unknown argument")
+	}
+}
+
+// TestValidatorsAndHooksErrorsAreReported verifies that the errors of the argument validators and of
+// the validation hooks are reported with the usage, like the other parsing errors.
+func TestValidatorsAndHooksErrorsAreReported(t *testing.T) {
+	if os.Getenv("GOOPTS_VALIDATION_SUBPROCESS") == "1" {
+		var min, max int
+		var name string
+		ap := NewParser("test")
+		ap.NewIntArgument(&min, "", "--min", 0, false, "Minimum.")
+		ap.NewIntArgument(&max, "", "--max", 10, false, "Maximum.")
+		err := ap.Flag("name").Short("n").Help("Name.").Validate(arguments.MatchesRegexp(regexp.MustCompile(`^[a-z]+$`))).String(&name)
+		if err != nil {
+			os.Exit(2)
+		}
+		ap.AddValidationHook(func() error {
+			if min > max {
+				return errors.Join(
+					fmt.Errorf("--min must be lower than or equal to --max"),
+					fmt.Errorf("the range [%d, %d] is empty", min, max),
+				)
+			}
+			return nil
+		})
+		ap.ParsingState.SetRawArguments([]string{"test", "--min", "7", "--max", "5", "--name", "Alice"})
+		ap.ParseFrom(1, &ap.ParsingState)
+		os.Exit(0)
+	}
+
+	out, code := runTestSubprocess(t, "TestValidatorsAndHooksErrorsAreReported", "GOOPTS_VALIDATION_SUBPROCESS=1")
+	if code != 1 {
+		t.Fatalf("expected the parser to exit with code 1, got %d", code)
+	}
+
+	output := out
+	expected := []string{
+		"Usage: test",
+		"[!] Invalid value for argument \"--name\": \"Alice\" does not match the pattern \"^[a-z]+$\"",
+		"[!] --min must be lower than or equal to --max",
+		"[!] the range [7, 5] is empty",
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+	if strings.Index(output, expected[1]) > strings.Index(output, expected[2]) {
+		t.Errorf("expected the argument validators to be reported before the validation hooks, got:\n%s", output)
+	}
+}
+
+// runTestSubprocess re-executes the given test in a subprocess with additional environment variables,
+// such as the marker telling the test to run the code under test, and returns its standard output and
+// standard error interleaved, along with its exit code. ParseFrom calls os.Exit when it records error
+// messages or displays the usage, so these paths have to be exercised out of process.
+func runTestSubprocess(t *testing.T, testName string, env ...string) (string, int) {
+	t.Helper()
+
+	output := &bytes.Buffer{}
+	code := runTestSubprocessWithOutputs(t, testName, output, output, env...)
+	return output.String(), code
+}
+
+// runTestSubprocessWithOutputs re-executes the given test in a subprocess like runTestSubprocess,
+// writing its standard output and standard error to the given writers, and returns its exit code.
+func runTestSubprocessWithOutputs(t *testing.T, testName string, stdout, stderr io.Writer, env ...string) int {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^"+testName+"$")
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err := cmd.Run()
+
+	if err == nil {
+		return 0
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("running %s in a subprocess failed: %s", testName, err)
+	}
+	return exitErr.ExitCode()
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// defaultVersionFlags are the version flags of a parser that did not configure its own.
+var defaultVersionFlags = []string{"--version"}
+
+// readBuildInfo reads the build information of the running binary. It is a variable so that tests
+// can replace it.
+var readBuildInfo = debug.ReadBuildInfo
+
+// SetVersion enables the version flags of the parser, "--version" unless configured with
+// SetVersionFlags. When one of them is given, the version is printed and the program exits, before
+// the required arguments and the argument groups are checked. The subparsers that did not set their
+// own version use the one of their parent parser.
+//
+// Parameters:
+// - version: The version to print. When empty, the version is read from the build information of
+// the binary, see BuildInfoVersion.
+func (ap *ArgumentsParser) SetVersion(version string) {
+	ap.Options.Version = version
+	ap.Options.VersionEnabled = true
+}
+
+// SetVersionFlags sets the flags printing the version, for example SetVersionFlags("-V", "--version").
+// They are only used once the version is enabled with SetVersion.
+//
+// Parameters:
+// - flags: The short or long names of the version flags.
+func (ap *ArgumentsParser) SetVersionFlags(flags ...string) {
+	ap.Options.VersionFlags = append([]string{}, flags...)
+}
+
+// versionParser returns the parser whose version settings apply to this parser, which is the parser
+// itself if it enabled its version, or else the closest parent parser that did.
+//
+// Returns:
+// - The parser holding the version settings, or nil if the version is not enabled.
+func (ap *ArgumentsParser) versionParser() *ArgumentsParser {
+	for current := ap; current != nil; current = current.parent {
+		if current.Options.VersionEnabled {
+			return current
+		}
+	}
+
+	return nil
+}
+
+// versionFlags returns the flags printing the version of the parser.
+//
+// Returns:
+// - The version flags of the parser, empty when the version is not enabled.
+func (ap *ArgumentsParser) versionFlags() []string {
+	vp := ap.versionParser()
+	if vp == nil {
+		return []string{}
+	}
+	if vp.Options.VersionFlags != nil {
+		return vp.Options.VersionFlags
+	}
+
+	return defaultVersionFlags
+}
+
+// isVersionFlag reports whether a token is one of the version flags of the parser.
+//
+// Parameters:
+// - token: The command line token to check.
+//
+// Returns:
+// - true if the token is a version flag, false otherwise.
+func (ap *ArgumentsParser) isVersionFlag(token string) bool {
+	return slices.Contains(ap.versionFlags(), token)
+}
+
+// versionString returns the version printed by the version flags.
+//
+// Returns:
+// - The version set with SetVersion, or the version read from the build information of the binary.
+func (ap *ArgumentsParser) versionString() string {
+	vp := ap.versionParser()
+	if vp != nil && len(vp.Options.Version) != 0 {
+		return vp.Options.Version
+	}
+
+	info, ok := readBuildInfo()
+	if !ok {
+		return "(unknown version)"
+	}
+
+	return BuildInfoVersion(info)
+}
+
+// BuildInfoVersion formats the build information of a binary as a version string, with the path and
+// version of the main module, the VCS revision and whether the working tree had local modifications,
+// and the Go version it was built with.
+//
+// Parameters:
+// - info: The build information, as returned by runtime/debug.ReadBuildInfo.
+//
+// Returns:
+// - The version string, such as "example.com/tool v1.2.0 (revision 0123456789ab, modified) go1.22.1".
+func BuildInfoVersion(info *debug.BuildInfo) string {
+	version := info.Main.Version
+	if len(version) == 0 {
+		version = "(devel)"
+	}
+
+	output := strings.TrimSpace(info.Main.Path + " " + version)
+
+	revision, modified := "", false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if len(revision) != 0 {
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		if modified {
+			output += fmt.Sprintf(" (revision %s, modified)", revision)
+		} else {
+			output += fmt.Sprintf(" (revision %s)", revision)
+		}
+	}
+
+	if len(info.GoVersion) != 0 {
+		output += " " + info.GoVersion
+	}
+
+	return output
+}
+
+// printVersion prints the version of the parser.
+func (ap *ArgumentsParser) printVersion() {
+	if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+		writeJSON(os.Stdout, map[string]string{"version": ap.versionString()})
+		return
+	}
+
+	fmt.Printf("%s\n", ap.versionString())
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"os"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// TestBuildInfoVersion verifies the formatting of the build information of a binary.
+func TestBuildInfoVersion(t *testing.T) {
+	info := &debug.BuildInfo{
+		GoVersion: "go1.22.1",
+		Main:      debug.Module{Path: "example.com/tool", Version: "v1.2.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	if got := BuildInfoVersion(info); got != "example.com/tool v1.2.0 (revision 0123456789ab, modified) go1.22.1" {
+		t.Errorf("unexpected version string %q", got)
+	}
+
+	info = &debug.BuildInfo{Main: debug.Module{Path: "example.com/tool"}}
+	if got := BuildInfoVersion(info); got != "example.com/tool (devel)" {
+		t.Errorf("unexpected version string %q", got)
+	}
+}
+
+// TestVersionFromBuildInfo verifies that the version is read from the build information when none is set.
+func TestVersionFromBuildInfo(t *testing.T) {
+	defer func(previous func() (*debug.BuildInfo, bool)) { readBuildInfo = previous }(readBuildInfo)
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{GoVersion: "go1.22.1", Main: debug.Module{Path: "example.com/tool", Version: "v0.1.0"}}, true
+	}
+
+	var mode string
+	ap := NewParser("test")
+	ap.SetVersion("")
+	ap.SetupSubParsing("mode", &mode, false)
+	sub := ap.AddSubParser("scan", "Scan.")
+
+	if got := sub.versionString(); got != "example.com/tool v0.1.0 go1.22.1" {
+		t.Errorf("expected the subparser to use the build information, got %q", got)
+	}
+	if got := strings.Join(sub.versionFlags(), ","); got != "--version" {
+		t.Errorf("expected the subparser to inherit the version flags, got %q", got)
+	}
+}
+
+// TestVersionFlagSubprocess is the body of the subprocesses started by TestVersionFlag, and does
+// nothing when run directly.
+func TestVersionFlagSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_VERSION_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	var user string
+	ap := NewParser("test")
+	ap.SetVersion("tool 1.2.3")
+	ap.SetVersionFlags("-V", "--version")
+	ap.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	switch scenario {
+	case "version":
+		ap.ParsingState.SetRawArguments([]string{"test", "-V"})
+	case "help":
+		ap.ParsingState.SetRawArguments([]string{"test", "--help"})
+	}
+	ap.ParseFrom(1, &ap.ParsingState)
+	os.Exit(0)
+}
+
+// TestVersionFlag verifies that a version flag prints the version without checking the required
+// arguments, and that it appears in the usage.
+func TestVersionFlag(t *testing.T) {
+	for scenario, expected := range map[string][]string{
+		"version": {"tool 1.2.3\n"},
+		"help":    {"Usage: test --user <string> [--version]", "  -V, --version       Show the version and exit."},
+	} {
+		out, code := runTestSubprocess(t, "TestVersionFlagSubprocess", "GOOPTS_VERSION_SUBPROCESS="+scenario)
+		if code != 0 {
+			t.Fatalf("expected scenario %q to exit with code 0, got %d:\n%s", scenario, code, out)
+		}
+		for _, line := range expected {
+			if !strings.Contains(out, line) {
+				t.Errorf("expected output of scenario %q to contain %q, got:\n%s", scenario, line, out)
+			}
+		}
+		if strings.Contains(out, "[!]") {
+			t.Errorf("expected no error in scenario %q, got:\n%s", scenario, out)
+		}
+	}
+}
+
+// TestVersionFlagInHelpOfParserWithSubParsers verifies that the version flags are displayed in the
+// usage line and the help message of a parser with subparsers.
+func TestVersionFlagInHelpOfParserWithSubParsers(t *testing.T) {
+	var mode string
+	ap := NewParser("Tool")
+	ap.SetOptColor(COLOR_MODE_NEVER)
+	ap.SetVersion("1.2.3")
+	ap.SetupSubParsing("mode", &mode, false)
+	ap.AddSubParser("scan", "Scan a target")
+
+	got := ap.renderHelp(ap.helpData(1, &ParsingState{RawArguments: []string{"tool"}}, arguments.VISIBILITY_DEFAULT))
+	expected := "Usage: tool <scan> [--version]\n" +
+		"\n" +
+		"   scan      Scan a target\n" +
+		"  --version  Show the version and exit.\n" +
+		"\n"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
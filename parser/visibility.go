@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TheManticoreProject/goopts/argumentgroup"
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// defaultHelpAllFlags are the flags displaying the full help message, advanced arguments included, of
+// a parser that did not configure its own.
+var defaultHelpAllFlags = []string{"-hh", "--help-all"}
+
+// SetArgumentVisibility sets where a registered argument is displayed: in the help message, only in
+// the full help message requested with the help-all flags, or nowhere. A hidden argument is still
+// parsed, and the generated documentation includes the advanced arguments but not the hidden ones.
+//
+// Parameters:
+//   - argumentFlag: The short or long name of the argument.
+//   - visibility: One of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+//   - An error if no argument is registered with this name, if the argument does not implement
+//     arguments.VisibilityArgument, if the visibility is unknown, or if the argument is required,
+//     as users could not learn about an argument they have to give.
+func (ap *ArgumentsParser) SetArgumentVisibility(argumentFlag string, visibility int) error {
+	arg := ap.findArgument(argumentFlag)
+	if arg == nil {
+		return fmt.Errorf("argument '%s' not found", argumentFlag)
+	}
+
+	visibilityArgument, ok := arg.(arguments.VisibilityArgument)
+	if !ok {
+		return fmt.Errorf("argument '%s' does not support setting its visibility", argumentFlag)
+	}
+	if err := checkVisibility(visibility, arg.IsRequired()); err != nil {
+		return fmt.Errorf("argument '%s': %w", argumentFlag, err)
+	}
+	visibilityArgument.SetVisibility(visibility)
+
+	return nil
+}
+
+// checkVisibility verifies that an argument can have a visibility.
+//
+// Parameters:
+//   - visibility: One of the arguments.VISIBILITY_* constants.
+//   - required: Whether the argument is required.
+//
+// Returns:
+//   - An error if the visibility is unknown, or if a required argument would not be displayed by
+//     the help message.
+func checkVisibility(visibility int, required bool) error {
+	switch visibility {
+	case arguments.VISIBILITY_DEFAULT:
+		return nil
+	case arguments.VISIBILITY_ADVANCED, arguments.VISIBILITY_HIDDEN:
+		if required {
+			return fmt.Errorf("a required argument cannot be advanced or hidden")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown visibility %d", visibility)
+}
+
+// SetHelpAllFlags sets the flags displaying the full help message, which includes the advanced arguments,
+// "-hh" and "--help-all" by default. Calling it without flags disables the full help message. The
+// subparsers that did not set their own help-all flags use the ones of their parent parser.
+//
+// Parameters:
+// - flags: The short or long names of the help-all flags.
+func (ap *ArgumentsParser) SetHelpAllFlags(flags ...string) {
+	ap.Options.HelpAllFlags = append([]string{}, flags...)
+}
+
+// helpAllFlags returns the flags displaying the full help message of the parser, which are its own if it
+// set them, or else the ones of its parent parser, or else "-hh" and "--help-all".
+//
+// Returns:
+// - The help-all flags of the parser, empty when they are disabled.
+func (ap *ArgumentsParser) helpAllFlags() []string {
+	if ap.Options.HelpAllFlags != nil {
+		return ap.Options.HelpAllFlags
+	}
+	if ap.parent != nil {
+		return ap.parent.helpAllFlags()
+	}
+
+	return defaultHelpAllFlags
+}
+
+// helpLevel returns the help level requested by a token, which is the most restricted visibility of
+// the arguments displayed by the help message it requests.
+//
+// Parameters:
+// - token: The command line token to check.
+//
+// Returns:
+//   - arguments.VISIBILITY_ADVANCED for a help-all flag, arguments.VISIBILITY_DEFAULT for a help flag,
+//     or -1 when the token does not request the help message.
+func (ap *ArgumentsParser) helpLevel(token string) int {
+	if slices.Contains(ap.helpAllFlags(), token) {
+		return arguments.VISIBILITY_ADVANCED
+	}
+	if ap.isHelpFlag(token) {
+		return arguments.VISIBILITY_DEFAULT
+	}
+
+	return -1
+}
+
+// visibleGroups returns the argument groups of the parser displayed at a help level, keyed by their
+// name like the Groups map, the default group included.
+//
+// Parameters:
+// - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+// - The groups displayed at the help level, holding only their arguments and subgroups displayed at it.
+func (ap *ArgumentsParser) visibleGroups(level int) map[string]*argumentgroup.ArgumentGroup {
+	groups := make(map[string]*argumentgroup.ArgumentGroup)
+	for name, group := range ap.Groups {
+		if visible := visibleGroup(group, level); visible != nil {
+			groups[name] = visible
+		}
+	}
+	if groups[""] == nil {
+		groups[""] = &argumentgroup.ArgumentGroup{}
+	}
+
+	return groups
+}
+
+// visibleGroup returns a copy of a group holding only its arguments and subgroups displayed at a help
+// level, or nil when the group is not displayed. A group whose members are all left out is not
+// displayed either.
+//
+// Parameters:
+// - group: The argument group to filter, along with its subgroups.
+// - level: The most restricted visibility displayed, one of the arguments.VISIBILITY_* constants.
+//
+// Returns:
+// - The copy of the group, or nil when it is not displayed.
+func visibleGroup(group *argumentgroup.ArgumentGroup, level int) *argumentgroup.ArgumentGroup {
+	if group == nil || group.Visibility > level {
+		return nil
+	}
+
+	visible := *group
+	visible.Arguments = group.VisibleArguments(level)
+	visible.SubGroups = []*argumentgroup.ArgumentGroup{}
+	for _, subgroup := range group.SubGroups {
+		if visibleSubgroup := visibleGroup(subgroup, level); visibleSubgroup != nil {
+			visible.SubGroups = append(visible.SubGroups, visibleSubgroup)
+		}
+	}
+
+	hadMembers := len(group.Arguments) != 0 || len(group.SubGroups) != 0
+	hasMembers := len(visible.Arguments) != 0 || len(visible.SubGroups) != 0 || len(group.Positionals) != 0
+	if hadMembers && !hasMembers {
+		return nil
+	}
+
+	return &visible
+}
+
+// hasAdvancedArguments reports whether the full help message of the parser displays more than its
+// usual help message, so that the help message can point to the help-all flags.
+//
+// Returns:
+// - true if an argument or a group of the parser is only displayed by the full help message.
+func (ap *ArgumentsParser) hasAdvancedArguments() bool {
+	defaultGroups := ap.visibleGroups(arguments.VISIBILITY_DEFAULT)
+	for name, group := range ap.visibleGroups(arguments.VISIBILITY_ADVANCED) {
+		if len(group.AllArguments()) != len(defaultGroups[name].AllArguments()) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TheManticoreProject/goopts/arguments"
+)
+
+// newVisibilityParser returns a parser with a default, an advanced and a hidden argument, and a hidden
+// group.
+func newVisibilityParser(verbose *bool, retries *int, trace *bool, dump *string) *ArgumentsParser {
+	ap := NewParser("test")
+	ap.Flag("--verbose").Short("-v").Help("Verbose mode.").Bool(verbose)
+	ap.Flag("--retries").Help("Number of retries.").Default(3).Advanced().Int(retries)
+	ap.Flag("--trace").Help("Trace the requests.").Hidden().Bool(trace)
+	group, _ := ap.NewArgumentGroup("Debug")
+	group.NewStringArgument(dump, "", "--dump", "", false, "Dump file.")
+	group.Visibility = arguments.VISIBILITY_HIDDEN
+	return ap
+}
+
+// TestHelpLevels verifies that the advanced arguments are only displayed by the full help message, and
+// that the hidden arguments and groups are never displayed.
+func TestHelpLevels(t *testing.T) {
+	var (
+		verbose, trace bool
+		retries        int
+		dump           string
+	)
+	ap := newVisibilityParser(&verbose, &retries, &trace, &dump)
+	state := &ParsingState{RawArguments: []string{"test"}}
+
+	help := ap.renderHelp(ap.helpData(1, state, arguments.VISIBILITY_DEFAULT))
+	if !strings.HasPrefix(help, "Usage: test [--verbose]\n") {
+		t.Errorf("expected the usage line to only hold the default argument, got:\n%s", help)
+	}
+	if strings.Contains(help, "--retries") || strings.Contains(help, "--trace") || strings.Contains(help, "Debug") {
+		t.Errorf("expected the advanced and hidden arguments to be left out, got:\n%s", help)
+	}
+	if !strings.Contains(help, "Use --help-all to display the advanced options.") {
+		t.Errorf("expected the help message to point to the full help message, got:\n%s", help)
+	}
+
+	help = ap.renderHelp(ap.helpData(1, state, arguments.VISIBILITY_ADVANCED))
+	if !strings.HasPrefix(help, "Usage: test [--verbose] [--retries <int>]\n") || !strings.Contains(help, "Number of retries. (default: 3)") {
+		t.Errorf("expected the full help message to display the advanced argument, got:\n%s", help)
+	}
+	if strings.Contains(help, "--trace") || strings.Contains(help, "Debug") || strings.Contains(help, "--help-all") {
+		t.Errorf("expected the full help message to leave the hidden arguments out, got:\n%s", help)
+	}
+
+	markdown := ap.GenerateMarkdown("test")
+	if !strings.Contains(markdown, "--retries") || strings.Contains(markdown, "--trace") || strings.Contains(markdown, "--dump") {
+		t.Errorf("expected the documentation to include the advanced arguments only, got:\n%s", markdown)
+	}
+}
+
+// TestSetArgumentVisibility verifies that a required argument cannot be left out of the help message.
+func TestSetArgumentVisibility(t *testing.T) {
+	var user, host string
+	ap := NewParser("test")
+	ap.NewStringArgument(&user, "-u", "--user", "", true, "User.")
+	ap.NewStringArgument(&host, "", "--host", "", false, "Host.")
+
+	if err := ap.SetArgumentVisibility("--user", arguments.VISIBILITY_HIDDEN); err == nil {
+		t.Error("expected an error hiding a required argument")
+	}
+	if err := ap.SetArgumentVisibility("--host", 5); err == nil {
+		t.Error("expected an error for an unknown visibility")
+	}
+	if err := ap.SetArgumentVisibility("--missing", arguments.VISIBILITY_HIDDEN); err == nil {
+		t.Error("expected an error for an unknown argument")
+	}
+	if err := ap.SetArgumentVisibility("--host", arguments.VISIBILITY_ADVANCED); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if arguments.VisibilityOf(ap.findArgument("--host")) != arguments.VISIBILITY_ADVANCED {
+		t.Error("expected the argument to be advanced")
+	}
+
+	var token string
+	if err := ap.Flag("--token").Required().Hidden().String(&token); err == nil {
+		t.Error("expected the builder to reject a hidden required argument")
+	}
+}
+
+// TestHelpAllFlagSubprocess is the body of the subprocesses started by TestHelpAllFlag, and does
+// nothing when run directly.
+func TestHelpAllFlagSubprocess(t *testing.T) {
+	scenario := os.Getenv("GOOPTS_HELP_ALL_SUBPROCESS")
+	if len(scenario) == 0 {
+		return
+	}
+
+	var (
+		verbose, trace bool
+		retries        int
+		dump           string
+	)
+	ap := newVisibilityParser(&verbose, &retries, &trace, &dump)
+	ap.ParsingState.SetRawArguments(append([]string{"test"}, strings.Fields(scenario)...))
+	ap.ParseFrom(1, &ap.ParsingState)
+	if trace && dump == "out.txt" {
+		os.Stdout.WriteString("parsed hidden arguments\n")
+	}
+	os.Exit(0)
+}
+
+// TestHelpAllFlag verifies that "-hh" and "--help-all" display the advanced arguments, and that the
+// hidden arguments are parsed.
+func TestHelpAllFlag(t *testing.T) {
+	for scenario, expected := range map[string]string{
+		"-h":                     "Use --help-all to display the advanced options.",
+		"-hh":                    "Number of retries.",
+		"--verbose --help-all":   "Number of retries.",
+		"--trace --dump out.txt": "parsed hidden arguments",
+	} {
+		out, code := runTestSubprocess(t, "TestHelpAllFlagSubprocess", "GOOPTS_HELP_ALL_SUBPROCESS="+scenario, "NO_COLOR=1")
+		if code != 0 {
+			t.Fatalf("expected scenario %q to exit with code 0, got %d:\n%s", scenario, code, out)
+		}
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output of scenario %q to contain %q, got:\n%s", scenario, expected, out)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+)
+
+// SetWarningHandler sets the function receiving the warnings of the parser, such as the use of a
+// deprecated alias. Warnings do not stop parsing, and by default they are printed to the standard
+// error, so that they do not mix with the output of the program. The subparsers that did not set their
+// own handler use the one of their parent parser.
+//
+// Parameters:
+// - handler: The function called with each warning message, or nil to restore the default handler.
+func (ap *ArgumentsParser) SetWarningHandler(handler func(message string)) {
+	ap.Options.WarningHandler = handler
+}
+
+// warningHandler returns the function receiving the warnings of the parser, which is its own if it set
+// one, or else the one of its closest parent parser that set one, or else printJSONWarning in the JSON
+// output format and printWarning otherwise.
+//
+// Returns:
+// - The function called with each warning message.
+func (ap *ArgumentsParser) warningHandler() func(message string) {
+	for parser := ap; parser != nil; parser = parser.parent {
+		if parser.Options.WarningHandler != nil {
+			return parser.Options.WarningHandler
+		}
+	}
+	if ap.outputFormat() == OUTPUT_FORMAT_JSON {
+		return printJSONWarning
+	}
+
+	return printWarning
+}
+
+// printWarning prints a warning message to the standard error.
+//
+// Parameters:
+// - message: The warning message.
+func printWarning(message string) {
+	fmt.Fprintf(os.Stderr, "[warning] %s\n", message)
+}
+
+// emitWarnings passes the warnings recorded while parsing to the warning handler of the parser.
+//
+// Parameters:
+// - parsingState: The parsing state holding the warnings.
+func (ap *ArgumentsParser) emitWarnings(parsingState *ParsingState) {
+	handler := ap.warningHandler()
+	for _, message := range parsingState.WarningMessages {
+		handler(message)
+	}
+}
@@ -0,0 +1,51 @@
+package positionals
+
+import (
+	"flag"
+	"net/netip"
+	"testing"
+)
+
+func TestFlagValuePositionalArgument_Consume(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	count := fs.Int("count", 0, "Count")
+
+	arg := FlagValuePositionalArgument{}
+	arg.Init(fs.Lookup("count").Value, "count", "Count")
+
+	remainingArgs, err := arg.Consume([]string{"12", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *count != 12 || arg.GetValue() != 12 {
+		t.Errorf("Expected value to be 12, got %d", *count)
+	}
+	if len(remainingArgs) != 1 {
+		t.Errorf("Expected one remaining argument, got '%v'", remainingArgs)
+	}
+
+	if _, err := arg.Consume([]string{"abc"}); err == nil {
+		t.Errorf("Expected an error for an invalid value")
+	}
+}
+
+func TestTextUnmarshalerPositionalArgument_Consume(t *testing.T) {
+	var addr netip.Addr
+
+	arg := TextUnmarshalerPositionalArgument{}
+	arg.Init(&addr, "target", "Target address")
+
+	if !arg.IsRequired() {
+		t.Errorf("Expected the positional argument to be required")
+	}
+	if _, err := arg.Consume([]string{"192.168.1.1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if addr.String() != "192.168.1.1" {
+		t.Errorf("Expected address to be '192.168.1.1', got '%s'", addr)
+	}
+
+	if _, err := arg.Consume([]string{"not-an-ip"}); err == nil {
+		t.Errorf("Expected an error for an invalid value")
+	}
+}
@@ -57,6 +57,16 @@ func (arg BoolPositionalArgument) IsRequired() bool {
 	return true
 }
 
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// a boolean value.
+//
+// Returns:
+//
+//	(string): "bool".
+func (arg BoolPositionalArgument) GetTypeName() string {
+	return "bool"
+}
+
 // Init initializes the `BoolPositionalArgument` with a specified value, name, and help message.
 //
 // Parameters:
@@ -0,0 +1,149 @@
+package positionals
+
+import (
+	"fmt"
+
+	"github.com/TheManticoreProject/goopts/utils"
+)
+
+// ChoicesArgument is an optional interface implemented by positional arguments that only accept
+// a fixed set of values. The usage message displays these choices in place of the name of the
+// positional argument.
+type ChoicesArgument interface {
+	// GetChoices returns the list of values accepted by the positional argument.
+	GetChoices() []string
+}
+
+// EnumPositionalArgument represents a positional command-line argument that expects one value out
+// of a fixed set of choices. The type parameter allows the value to be stored in a string or in a
+// type of typed constants based on string.
+//
+// Fields:
+//
+//	Name (string): The name of the argument, used for display and reference purposes.
+//	Help (string): A help message describing the purpose of the argument, shown in usage instructions.
+//	Value (*T): A pointer to where the parsed choice will be stored.
+//	Choices ([]T): The list of values accepted by the argument.
+//	CaseInsensitive (bool): Whether the value matches a choice regardless of its case. The value stored
+//	                        is always spelled as in Choices.
+//	Required (bool): A flag indicating whether this argument must be provided. If set to true, the argument
+//	                 is mandatory; otherwise, it is optional.
+type EnumPositionalArgument[T ~string] struct {
+	Name            string
+	Help            string // Help message
+	Value           *T     // Values
+	Choices         []T
+	CaseInsensitive bool
+	Required        bool
+}
+
+// GetName retrieves the name of the positional argument.
+//
+// Returns:
+//
+//	(string): The name of the argument.
+func (arg EnumPositionalArgument[T]) GetName() string {
+	return arg.Name
+}
+
+// GetHelp retrieves the help message associated with the positional argument.
+//
+// Returns:
+//
+//	(string): The help message describing the argument.
+func (arg EnumPositionalArgument[T]) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue retrieves the current value of the positional argument.
+//
+// Returns:
+//
+//	(any): The current choice of the argument.
+func (arg EnumPositionalArgument[T]) GetValue() any {
+	return *arg.Value
+}
+
+// GetChoices retrieves the list of values accepted by the positional argument.
+//
+// Returns:
+//
+//	([]string): The accepted choices.
+func (arg EnumPositionalArgument[T]) GetChoices() []string {
+	return utils.ChoicesToStrings(arg.Choices)
+}
+
+// IsRequired indicates whether the positional argument is mandatory.
+//
+// Returns:
+//
+//	(bool): True if the argument is required; otherwise, false.
+func (arg EnumPositionalArgument[T]) IsRequired() bool {
+	return arg.Required
+}
+
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// one of its choices.
+//
+// Returns:
+//
+//	(string): "choice".
+func (arg EnumPositionalArgument[T]) GetTypeName() string {
+	return "choice"
+}
+
+// Init initializes the `EnumPositionalArgument` with a specified value, name, choices and help message.
+//
+// Parameters:
+//
+//	value (*T): A pointer to where the choice of the argument will be stored.
+//	name (string): The name of the positional argument.
+//	choices ([]T): The list of values accepted by the argument.
+//	caseInsensitive (bool): Whether the value matches a choice regardless of its case.
+//	help (string): The help message describing the argument.
+//
+// Behavior:
+//
+//	This method sets up the `EnumPositionalArgument` and marks it as required.
+func (arg *EnumPositionalArgument[T]) Init(value *T, name string, choices []T, caseInsensitive bool, help string) {
+	arg.Name = name
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.Choices = choices
+
+	arg.CaseInsensitive = caseInsensitive
+
+	arg.Required = true
+}
+
+// Consume processes the command-line arguments and sets the value of the EnumPositionalArgument.
+//
+// The first argument is looked up in the choices, regardless of its case if CaseInsensitive is set,
+// and the matching choice is stored in the value.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the EnumPositionalArgument.
+// - An error listing the accepted choices if the value is not one of them.
+func (arg EnumPositionalArgument[T]) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 1
+
+	if len(arguments) >= sizeToConsume {
+		choice, ok := utils.MatchChoice(arguments[0], arg.Choices, arg.CaseInsensitive)
+		if !ok {
+			// Return the original arguments if the value is not an accepted choice
+			return arguments, fmt.Errorf("invalid choice \"%s\", choose from %s", arguments[0], utils.ListOfStrings(arg.GetChoices()))
+		}
+
+		*arg.Value = choice
+
+		return arguments[sizeToConsume:], nil
+	}
+
+	return arguments, nil
+}
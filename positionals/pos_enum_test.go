@@ -0,0 +1,32 @@
+package positionals
+
+import (
+	"testing"
+)
+
+func TestEnumPositionalArgument_Consume(t *testing.T) {
+	var value string
+
+	arg := EnumPositionalArgument[string]{}
+	arg.Init(&value, "action", []string{"add", "remove"}, true, "Action to perform")
+
+	remainingArgs, err := arg.Consume([]string{"Remove", "anotherArg"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if value != "remove" {
+		t.Errorf("Expected value to be 'remove', got '%s'", value)
+	}
+	if len(remainingArgs) != 1 {
+		t.Errorf("Expected one remaining argument, got '%v'", remainingArgs)
+	}
+
+	if _, err := arg.Consume([]string{"list"}); err == nil {
+		t.Errorf("Expected an error for an invalid choice")
+	}
+
+	var withChoices PositionalArgument = arg
+	if _, ok := withChoices.(ChoicesArgument); !ok {
+		t.Errorf("Expected EnumPositionalArgument to implement ChoicesArgument")
+	}
+}
@@ -0,0 +1,118 @@
+package positionals
+
+import (
+	"flag"
+	"fmt"
+)
+
+// FlagValuePositionalArgument represents a positional command-line argument whose value is handled
+// by a flag.Value, the interface used by the standard flag package.
+//
+// Fields:
+//
+//	Name (string): The name of the argument, used for display and reference purposes.
+//	Help (string): A help message describing the purpose of the argument, shown in usage instructions.
+//	Value (flag.Value): The flag.Value that parses and stores the value of the argument.
+//	Required (bool): A flag indicating whether this argument must be provided. If set to true, the argument
+//	                 is mandatory; otherwise, it is optional.
+type FlagValuePositionalArgument struct {
+	Name     string
+	Help     string     // Help message
+	Value    flag.Value // Values
+	Required bool
+}
+
+// GetName retrieves the name of the positional argument.
+//
+// Returns:
+//
+//	(string): The name of the argument.
+func (arg FlagValuePositionalArgument) GetName() string {
+	return arg.Name
+}
+
+// GetHelp retrieves the help message associated with the positional argument.
+//
+// Returns:
+//
+//	(string): The help message describing the argument.
+func (arg FlagValuePositionalArgument) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue retrieves the current value of the positional argument.
+//
+// Returns:
+//
+//	(any): The result of the Get method of the value if it implements flag.Getter, the value itself otherwise.
+func (arg FlagValuePositionalArgument) GetValue() any {
+	if getter, ok := arg.Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return arg.Value
+}
+
+// IsRequired indicates whether the positional argument is mandatory.
+//
+// Returns:
+//
+//	(bool): True if the argument is required; otherwise, false.
+func (arg FlagValuePositionalArgument) IsRequired() bool {
+	return arg.Required
+}
+
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// a value parsed by its flag.Value.
+//
+// Returns:
+//
+//	(string): "value".
+func (arg FlagValuePositionalArgument) GetTypeName() string {
+	return "value"
+}
+
+// Init initializes the `FlagValuePositionalArgument` with a specified value, name, and help message.
+//
+// Parameters:
+//
+//	value (flag.Value): The flag.Value that will parse and hold the argument's value.
+//	name (string): The name of the positional argument.
+//	help (string): The help message describing the argument.
+//
+// Behavior:
+//
+//	This method sets up the `FlagValuePositionalArgument` and marks it as required.
+func (arg *FlagValuePositionalArgument) Init(value flag.Value, name string, help string) {
+	arg.Name = name
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.Required = true
+}
+
+// Consume processes the command-line arguments and sets the value of the FlagValuePositionalArgument.
+//
+// The first argument is given to the Set method of the value.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the FlagValuePositionalArgument.
+// - An error if the value rejected the argument.
+func (arg FlagValuePositionalArgument) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 1
+
+	if len(arguments) >= sizeToConsume {
+		if err := arg.Value.Set(arguments[0]); err != nil {
+			// Return the original arguments if parsing fails
+			return arguments, fmt.Errorf("invalid value \"%s\": %s", arguments[0], err)
+		}
+
+		return arguments[sizeToConsume:], nil
+	}
+
+	return arguments, nil
+}
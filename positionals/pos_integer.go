@@ -56,6 +56,16 @@ func (arg IntPositionalArgument) IsRequired() bool {
 	return arg.Required
 }
 
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// an integer value.
+//
+// Returns:
+//
+//	(string): "int".
+func (arg IntPositionalArgument) GetTypeName() string {
+	return "int"
+}
+
 // Init initializes the `IntPositionalArgument` with a specified value, name, and help message.
 //
 // Parameters:
@@ -54,6 +54,16 @@ func (arg StringPositionalArgument) IsRequired() bool {
 	return arg.Required
 }
 
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// a string value.
+//
+// Returns:
+//
+//	(string): "string".
+func (arg StringPositionalArgument) GetTypeName() string {
+	return "string"
+}
+
 // Init initializes the StringPositionalArgument with the provided values.
 //
 // The function sets the short name, long name, help message, value, and default value for the StringPositionalArgument.
@@ -0,0 +1,115 @@
+package positionals
+
+import (
+	"encoding"
+	"fmt"
+)
+
+// TextUnmarshalerPositionalArgument represents a positional command-line argument whose value is parsed
+// by an encoding.TextUnmarshaler, such as *net.IP, *netip.Addr, *slog.Level or *big.Int.
+//
+// Fields:
+//
+//	Name (string): The name of the argument, used for display and reference purposes.
+//	Help (string): A help message describing the purpose of the argument, shown in usage instructions.
+//	Value (encoding.TextUnmarshaler): The encoding.TextUnmarshaler that parses and stores the value of the argument.
+//	Required (bool): A flag indicating whether this argument must be provided. If set to true, the argument
+//	                 is mandatory; otherwise, it is optional.
+type TextUnmarshalerPositionalArgument struct {
+	Name     string
+	Help     string                   // Help message
+	Value    encoding.TextUnmarshaler // Values
+	Required bool
+}
+
+// GetName retrieves the name of the positional argument.
+//
+// Returns:
+//
+//	(string): The name of the argument.
+func (arg TextUnmarshalerPositionalArgument) GetName() string {
+	return arg.Name
+}
+
+// GetHelp retrieves the help message associated with the positional argument.
+//
+// Returns:
+//
+//	(string): The help message describing the argument.
+func (arg TextUnmarshalerPositionalArgument) GetHelp() string {
+	return arg.Help
+}
+
+// GetValue retrieves the current value of the positional argument.
+//
+// Returns:
+//
+//	(any): The encoding.TextUnmarshaler holding the value of the argument.
+func (arg TextUnmarshalerPositionalArgument) GetValue() any {
+	return arg.Value
+}
+
+// IsRequired indicates whether the positional argument is mandatory.
+//
+// Returns:
+//
+//	(bool): True if the argument is required; otherwise, false.
+func (arg TextUnmarshalerPositionalArgument) IsRequired() bool {
+	return arg.Required
+}
+
+// GetTypeName retrieves the name of the type of value the positional argument expects, which is
+// a value parsed by its encoding.TextUnmarshaler.
+//
+// Returns:
+//
+//	(string): "value".
+func (arg TextUnmarshalerPositionalArgument) GetTypeName() string {
+	return "value"
+}
+
+// Init initializes the `TextUnmarshalerPositionalArgument` with a specified value, name, and help message.
+//
+// Parameters:
+//
+//	value (encoding.TextUnmarshaler): The encoding.TextUnmarshaler that will parse and hold the argument's value.
+//	name (string): The name of the positional argument.
+//	help (string): The help message describing the argument.
+//
+// Behavior:
+//
+//	This method sets up the `TextUnmarshalerPositionalArgument` and marks it as required.
+func (arg *TextUnmarshalerPositionalArgument) Init(value encoding.TextUnmarshaler, name string, help string) {
+	arg.Name = name
+
+	arg.Help = help
+
+	arg.Value = value
+
+	arg.Required = true
+}
+
+// Consume processes the command-line arguments and sets the value of the TextUnmarshalerPositionalArgument.
+//
+// The first argument is given to the UnmarshalText method of the value.
+//
+// Parameters:
+//   - arguments: A slice of strings representing the command-line arguments.
+//
+// Returns:
+// - A slice of strings representing the remaining arguments after processing the TextUnmarshalerPositionalArgument.
+// - An error if the value rejected the argument.
+func (arg TextUnmarshalerPositionalArgument) Consume(arguments []string) ([]string, error) {
+	sizeToConsume := 1
+
+	if len(arguments) >= sizeToConsume {
+		if err := arg.Value.UnmarshalText([]byte(arguments[0])); err != nil {
+			// Return the original arguments if parsing fails
+			return arguments, fmt.Errorf("invalid value \"%s\": %s", arguments[0], err)
+		}
+
+		return arguments[sizeToConsume:], nil
+	}
+
+	return arguments, nil
+}
@@ -23,3 +23,10 @@ type PositionalArgument interface {
 	// Parse the argument from the provided input
 	Consume(arguments []string) ([]string, error)
 }
+
+// TypedArgument is an optional interface implemented by positional arguments describing the type of value
+// they expect, such as "int" or "string". All the positional argument types of this package implement it.
+type TypedArgument interface {
+	// GetTypeName returns a short, human readable name of the type of value the argument expects.
+	GetTypeName() string
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // StripLeftDashes removes all leading dash characters ('-') from the input string.
@@ -108,3 +110,150 @@ func StringToInt(value string) (int, error) {
 		return strconv.Atoi(value)
 	}
 }
+
+// MatchChoice looks up a value in a list of accepted choices.
+//
+// Parameters:
+//   - value: The value given on the command line.
+//   - choices: The accepted values.
+//   - caseInsensitive: Whether the value matches a choice regardless of its case.
+//
+// Returns:
+//   - The matching choice, spelled as in the list of choices, and true if the value matches one
+//     of them. The zero value and false otherwise.
+func MatchChoice[T ~string](value string, choices []T, caseInsensitive bool) (T, bool) {
+	for _, choice := range choices {
+		if string(choice) == value || (caseInsensitive && strings.EqualFold(string(choice), value)) {
+			return choice, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// ChoicesToStrings converts a list of choices of a string based type to a slice of strings.
+//
+// Parameters:
+//   - choices: The choices to convert.
+//
+// Returns:
+//   - The choices as a slice of strings, or nil when there are no choices.
+func ChoicesToStrings[T ~string](choices []T) []string {
+	if len(choices) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		values = append(values, string(choice))
+	}
+
+	return values
+}
+
+// WrapText splits a text into lines no longer than the given width, breaking it between words.
+// The line breaks already in the text are kept, and a word longer than the width is put on a line
+// of its own rather than being cut.
+//
+// Parameters:
+//
+//	text (string): The text to wrap.
+//	width (int): The maximum length of a line, in characters.
+//
+// Returns:
+//
+//	[]string: The lines of the wrapped text, without their line breaks, or no lines for an empty text.
+func WrapText(text string, width int) []string {
+	lines := []string{}
+	if len(strings.TrimSpace(text)) == 0 {
+		return lines
+	}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if len(line) == 0 {
+				line = word
+			} else if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width {
+				line += " " + word
+			} else {
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// SplitCommandLine splits a command line into its arguments as a POSIX shell would, without
+// expanding anything. Arguments are separated by whitespace, single quotes keep their content as it
+// is, double quotes keep their content except for the backslashes escaping a double quote or a
+// backslash, and a backslash outside quotes escapes the next character.
+//
+// Parameters:
+//
+//	commandLine (string): The command line to split, such as `tool --name "John Doe"`.
+//
+// Returns:
+//
+//	[]string: The arguments of the command line.
+//	error: An error if a quote is not closed or the command line ends with a backslash.
+func SplitCommandLine(commandLine string) ([]string, error) {
+	arguments := []string{}
+	current := strings.Builder{}
+	inArgument := false
+	runes := []rune(commandLine)
+
+	for k := 0; k < len(runes); k++ {
+		r := runes[k]
+		switch {
+		case r == '\'':
+			inArgument = true
+			end := k + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated single quote in command line")
+			}
+			current.WriteString(string(runes[k+1 : end]))
+			k = end
+		case r == '"':
+			inArgument = true
+			k++
+			for ; k < len(runes) && runes[k] != '"'; k++ {
+				if runes[k] == '\\' && k+1 < len(runes) && (runes[k+1] == '"' || runes[k+1] == '\\') {
+					k++
+				}
+				current.WriteRune(runes[k])
+			}
+			if k == len(runes) {
+				return nil, fmt.Errorf("unterminated double quote in command line")
+			}
+		case r == '\\':
+			if k+1 == len(runes) {
+				return nil, fmt.Errorf("command line ends with a backslash")
+			}
+			inArgument = true
+			k++
+			current.WriteRune(runes[k])
+		case unicode.IsSpace(r):
+			if inArgument {
+				arguments = append(arguments, current.String())
+				current.Reset()
+				inArgument = false
+			}
+		default:
+			inArgument = true
+			current.WriteRune(r)
+		}
+	}
+	if inArgument {
+		arguments = append(arguments, current.String())
+	}
+
+	return arguments, nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -92,3 +93,53 @@ func TestStringToInt(t *testing.T) {
 		}
 	}
 }
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		{"", 10, []string{}},
+		{"short", 10, []string{"short"}},
+		{"the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"a verylongword b", 5, []string{"a", "verylongword", "b"}},
+		{"first\nsecond line", 20, []string{"first", "second line"}},
+		{"éléments à côté", 11, []string{"éléments à", "côté"}},
+	}
+
+	for _, test := range tests {
+		result := WrapText(test.text, test.width)
+		if strings.Join(result, "|") != strings.Join(test.expected, "|") || len(result) != len(test.expected) {
+			t.Errorf("WrapText(%q, %d) = %q; expected %q", test.text, test.width, result, test.expected)
+		}
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		commandLine string
+		expected    []string
+	}{
+		{"tool scan  --port 445", []string{"tool", "scan", "--port", "445"}},
+		{`tool --name "John Doe" --note 'it''s'`, []string{"tool", "--name", "John Doe", "--note", "its"}},
+		{`tool --quote "say \"hi\"" a\ b ""`, []string{"tool", "--quote", `say "hi"`, "a b", ""}},
+	}
+
+	for _, test := range tests {
+		result, err := SplitCommandLine(test.commandLine)
+		if err != nil {
+			t.Errorf("SplitCommandLine(%q) returned an error: %v", test.commandLine, err)
+			continue
+		}
+		if strings.Join(result, "|") != strings.Join(test.expected, "|") || len(result) != len(test.expected) {
+			t.Errorf("SplitCommandLine(%q) = %q; expected %q", test.commandLine, result, test.expected)
+		}
+	}
+
+	for _, commandLine := range []string{`tool "open`, "tool 'open", `tool \`} {
+		if _, err := SplitCommandLine(commandLine); err == nil {
+			t.Errorf("SplitCommandLine(%q) should have returned an error", commandLine)
+		}
+	}
+}